@@ -0,0 +1,60 @@
+//go:build !wasm
+
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	synapseq "github.com/synapseq-foundation/synapseq/v3/core"
+	"github.com/synapseq-foundation/synapseq/v3/internal/cli"
+)
+
+// runChart draws a chart of the loaded sequence to the output file, or as
+// PNG to the standard output. The spectrogram follows the loudness and time
+// range options of the output.
+func runChart(appCtx *synapseq.AppContext, opts *cli.CLIOptions) error {
+	var err error
+	if opts.Normalize {
+		if appCtx, err = appCtx.WithLoudness(opts.Loudness); err != nil {
+			return err
+		}
+	}
+	if opts.Start != 0 || opts.End != 0 {
+		if appCtx, err = appCtx.WithRange(opts.Start, opts.End); err != nil {
+			return err
+		}
+	}
+	if opts.EdgeFade != 0 {
+		if appCtx, err = appCtx.WithEdgeFade(opts.EdgeFade); err != nil {
+			return err
+		}
+	}
+
+	if appCtx.OutputFile() == "-" {
+		if err := appCtx.Chart(opts.Chart, "png", os.Stdout); err != nil {
+			return fmt.Errorf("failed to draw chart. Error\n  %w", err)
+		}
+		return nil
+	}
+
+	if err := appCtx.SaveChart(opts.Chart); err != nil {
+		return fmt.Errorf("failed to draw chart. Error\n  %w", err)
+	}
+
+	if !opts.Quiet {
+		fmt.Println("Chart saved successfully.")
+	}
+	return nil
+}
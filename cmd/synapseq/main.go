@@ -107,6 +107,18 @@ func run(opts *cli.CLIOptions, args []string) error {
 		return fmt.Errorf("invalid number of flags\nUse -help for usage information")
 	}
 
+	// --- Handle Verify mode
+	if opts.Verify {
+		if len(args) == 1 {
+			// The metadata does not record the time range of a render
+			if opts.Start != 0 || opts.End != 0 {
+				return fmt.Errorf("verifying a time range needs the sequence file: -verify -start/-end <input> <file.wav>")
+			}
+			return runVerifyEmbedded(args[0], opts.Quiet)
+		}
+		return runVerify(args[0], args[1], opts)
+	}
+
 	// Determine output format
 	outputFormat := "wav"
 	if opts.Mp3 {
@@ -115,6 +127,9 @@ func run(opts *cli.CLIOptions, args []string) error {
 
 	inputFile := args[0]
 	outputFile := getDefaultOutputFile(inputFile, outputFormat)
+	if opts.Chart != "" {
+		outputFile = getDefaultOutputFile(inputFile, "png")
+	}
 	if len(args) == 2 {
 		outputFile = args[1]
 	}
@@ -209,6 +224,11 @@ func run(opts *cli.CLIOptions, args []string) error {
 		return nil
 	}
 
+	// --- Handle Chart mode
+	if opts.Chart != "" {
+		return runChart(appCtx, opts)
+	}
+
 	// --- Process output using centralized handler
 	outputOpts := &outputOptions{
 		OutputFile:       outputFile,
@@ -216,6 +236,11 @@ func run(opts *cli.CLIOptions, args []string) error {
 		Play:             opts.Play,
 		Mp3:              opts.Mp3,
 		UnsafeNoMetadata: opts.UnsafeNoMetadata,
+		Normalize:        opts.Normalize,
+		Loudness:         opts.Loudness,
+		Start:            opts.Start,
+		End:              opts.End,
+		EdgeFade:         opts.EdgeFade,
 		FFplayPath:       opts.FFplayPath,
 		FFmpegPath:       opts.FFmpegPath,
 	}
@@ -14,8 +14,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"time"
 
 	synapseq "github.com/synapseq-foundation/synapseq/v3/core"
 )
@@ -27,15 +30,49 @@ type outputOptions struct {
 	Play             bool
 	Mp3              bool
 	UnsafeNoMetadata bool
+	Normalize        bool
+	Loudness         float64
+	Start            time.Duration
+	End              time.Duration
+	EdgeFade         time.Duration
 	FFplayPath       string
 	FFmpegPath       string
 }
 
 // processSequenceOutput processes the output of a loaded sequence
 func processSequenceOutput(appCtx *synapseq.AppContext, opts *outputOptions) error {
+	// --- Loudness normalization
+	if opts.Normalize {
+		var err error
+		appCtx, err = appCtx.WithLoudness(opts.Loudness)
+		if err != nil {
+			return err
+		}
+	}
+
+	// --- Time range
+	if opts.Start != 0 || opts.End != 0 {
+		var err error
+		appCtx, err = appCtx.WithRange(opts.Start, opts.End)
+		if err != nil {
+			return err
+		}
+	}
+	if opts.EdgeFade != 0 {
+		var err error
+		appCtx, err = appCtx.WithEdgeFade(opts.EdgeFade)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Ctrl+C stops the render and removes a partial WAV file
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	// --- Handle Stream mode (output = "-")
 	if opts.OutputFile == "-" {
-		return appCtx.Stream(os.Stdout)
+		return appCtx.StreamContext(ctx, os.Stdout)
 	}
 
 	// --- Unsafe mode
@@ -65,5 +102,5 @@ func processSequenceOutput(appCtx *synapseq.AppContext, opts *outputOptions) err
 	}
 
 	// Default: Render to WAV
-	return appCtx.WAV()
+	return appCtx.WAVContext(ctx)
 }
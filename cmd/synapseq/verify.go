@@ -0,0 +1,146 @@
+//go:build !wasm
+
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	synapseq "github.com/synapseq-foundation/synapseq/v3/core"
+	"github.com/synapseq-foundation/synapseq/v3/internal/cli"
+)
+
+// runVerify verifies a WAV file against a sequence file
+func runVerify(inputFile, wavFile string, opts *cli.CLIOptions) error {
+	appCtx, err := synapseq.NewAppContext(inputFile, "", detectFormat(opts))
+	if err != nil {
+		return err
+	}
+
+	// The gain of a normalized file without metadata is measured again
+	if opts.Normalize {
+		appCtx, err = appCtx.WithLoudness(opts.Loudness)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := appCtx.LoadSequence(); err != nil {
+		return err
+	}
+
+	// A file rendered from a time range holds only that range
+	if opts.Start != 0 || opts.End != 0 {
+		if appCtx, err = appCtx.WithRange(opts.Start, opts.End); err != nil {
+			return err
+		}
+	}
+	if opts.EdgeFade != 0 {
+		if appCtx, err = appCtx.WithEdgeFade(opts.EdgeFade); err != nil {
+			return err
+		}
+	}
+
+	report, err := appCtx.Verify(wavFile, synapseq.DefaultVerifyTolerance())
+	if err != nil {
+		return fmt.Errorf("failed to verify %s. Error\n  %w", wavFile, err)
+	}
+
+	return verifyReport(report, opts.Quiet)
+}
+
+// runVerifyEmbedded verifies a WAV file against the sequence in its metadata
+func runVerifyEmbedded(wavFile string, quiet bool) error {
+	report, err := synapseq.VerifyEmbedded(wavFile, synapseq.DefaultVerifyTolerance())
+	if err != nil {
+		return fmt.Errorf("failed to verify %s. Error\n  %w", wavFile, err)
+	}
+
+	return verifyReport(report, quiet)
+}
+
+// verifyReport prints the measures of every tone and fails on a mismatch
+func verifyReport(report synapseq.VerifyReport, quiet bool) error {
+	if !quiet {
+		printVerifyReport(report)
+	}
+
+	if failed := report.Failed(); failed > 0 {
+		return fmt.Errorf("verification failed: %d of %d tones out of tolerance", failed, len(report.Results))
+	}
+
+	if !quiet {
+		fmt.Println("Verification passed.")
+	}
+	return nil
+}
+
+// printVerifyReport prints the expected and measured tones of a report
+func printVerifyReport(report synapseq.VerifyReport) {
+	if len(report.Results) == 0 {
+		fmt.Println("No tones to verify.")
+		return
+	}
+
+	tol := synapseq.DefaultVerifyTolerance()
+	fmt.Printf("Tolerances: carrier %.2f Hz, beat %.2f Hz, amplitude %.2f dB\n\n", tol.Carrier, tol.Beat, tol.Amplitude)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "PERIOD\tTIME\tCH\tTYPE\tCARRIER (Hz)\tBEAT (Hz)\tAMPLITUDE L/R (%)\tRESULT")
+
+	for _, res := range report.Results {
+		beat := "-"
+		if res.BeatChecked {
+			beat = fmt.Sprintf("%.2f → %.2f", res.Expected.Beat, res.Measured.Beat)
+		}
+
+		amplitude := "-"
+		if res.AmplitudeChecked {
+			amplitude = fmt.Sprintf("%.1f/%.1f → %.1f/%.1f",
+				res.Expected.Amplitude[0], res.Expected.Amplitude[1],
+				res.Measured.Amplitude[0], res.Measured.Amplitude[1])
+		}
+
+		var failed []string
+		if !res.CarrierOK {
+			failed = append(failed, "carrier")
+		}
+		if !res.BeatOK {
+			failed = append(failed, "beat")
+		}
+		if !res.AmplitudeOK {
+			failed = append(failed, "amplitude")
+		}
+		result := "ok"
+		if len(failed) > 0 {
+			result = "FAIL (" + strings.Join(failed, ", ") + ")"
+		}
+
+		fmt.Fprintf(w, "%d\t%v\t%d\t%s\t%.2f → %.2f\t%s\t%s\t%s\n",
+			res.Period+1,
+			res.Time,
+			res.Channel+1,
+			res.Type,
+			res.Expected.Carrier, res.Measured.Carrier,
+			beat,
+			amplitude,
+			result,
+		)
+	}
+
+	w.Flush()
+	fmt.Println()
+}
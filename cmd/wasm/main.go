@@ -93,11 +93,20 @@ func streamPcm(this js.Value, args []js.Value) interface{} {
 					return
 				}
 
+				// The browser player is stereo, other layouts fold into the front speakers
 				renderer, err := audio.NewAudioRenderer(seq.Periods, &audio.AudioRendererOptions{
 					SampleRate:     seq.Options.SampleRate,
 					Volume:         seq.Options.Volume,
 					GainLevel:      seq.Options.GainLevel,
 					BackgroundPath: seq.Options.BackgroundPath,
+					BackgroundLoop: seq.Options.BackgroundLoop,
+					Backgrounds:    seq.Options.Backgrounds,
+					Cues:           seq.Options.Cues,
+					Waveforms:      seq.Options.Waveforms,
+					Seed:           seq.Options.Seed,
+					Balance:        seq.Options.Balance,
+					Limiter:        seq.Options.Limiter,
+					Dither:         seq.Options.Dither,
 				})
 				if err != nil {
 					onError.Invoke(err.Error())
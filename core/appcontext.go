@@ -14,6 +14,8 @@ package core
 import (
 	"fmt"
 	"io"
+	"math"
+	"time"
 
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
@@ -28,8 +30,42 @@ type AppContext struct {
 	unsafeNoMetadata bool
 	statusOutput     io.Writer
 	sequence         *t.Sequence
+	clipStats        ClipStats
+	normalize        bool
+	loudnessTarget   float64
+	loudness         LoudnessStats
+	start, end       time.Duration
+	edgeFade         time.Duration
 }
 
+// ClipStats reports the samples that exceeded full scale on the master bus
+type ClipStats struct {
+	// Number of samples (per channel) above full scale
+	Samples int64
+	// Highest peak above full scale, in dB
+	MaxOvershoot float64
+	// Peaks were softly limited (@limiter soft) instead of hard clipped
+	Limited bool
+}
+
+// LoudnessStats reports the loudness normalization of the output
+type LoudnessStats struct {
+	// Target integrated loudness, in LUFS
+	Target float64
+	// Integrated loudness of the sequence before normalization, in LUFS
+	// (negative infinity when silent)
+	Measured float64
+	// Gain applied to reach the target, in dB
+	Gain float64
+}
+
+const (
+	// minLoudnessTarget is the lowest normalization target, the absolute gate of BS.1770
+	minLoudnessTarget = -70.0
+	// maxLoudnessTarget is the highest normalization target
+	maxLoudnessTarget = 0.0
+)
+
 // NewAppContext creates a new AppContext instance.
 //
 // Parameters:
@@ -89,6 +125,27 @@ func (ac *AppContext) UnsafeNoMetadata() bool {
 	return ac.unsafeNoMetadata
 }
 
+// Normalize returns whether loudness normalization is enabled.
+func (ac *AppContext) Normalize() bool {
+	return ac.normalize
+}
+
+// LoudnessTarget returns the target integrated loudness in LUFS.
+func (ac *AppContext) LoudnessTarget() float64 {
+	return ac.loudnessTarget
+}
+
+// Range returns the time range of the output.
+// An end of 0 is the end of the sequence.
+func (ac *AppContext) Range() (start, end time.Duration) {
+	return ac.start, ac.end
+}
+
+// EdgeFade returns the fade length at the edges of the time range.
+func (ac *AppContext) EdgeFade() time.Duration {
+	return ac.edgeFade
+}
+
 // WithVerbose returns a new AppContext with verbose mode enabled.
 // Status output will be written to the provided writer (typically os.Stderr).
 //
@@ -117,3 +174,65 @@ func (ac *AppContext) WithUnsafeNoMetadata() (*AppContext, error) {
 	newCtx.unsafeNoMetadata = true
 	return &newCtx, nil
 }
+
+// WithLoudness returns a new AppContext that normalizes the output to a target
+// integrated loudness in LUFS (EBU R128 / ITU-R BS.1770).
+// The sequence is rendered twice: once to measure, once with the gain applied.
+//
+// Example:
+//
+//	ctx, err = ctx.WithLoudness(-23)
+//
+// Returns an error if the target is outside -70 to 0 LUFS.
+func (ac *AppContext) WithLoudness(target float64) (*AppContext, error) {
+	if math.IsNaN(target) || target < minLoudnessTarget || target > maxLoudnessTarget {
+		return nil, fmt.Errorf("loudness target must be between %.0f and %.0f LUFS, got %.2f",
+			minLoudnessTarget, maxLoudnessTarget, target)
+	}
+
+	newCtx := *ac
+	newCtx.normalize = true
+	newCtx.loudnessTarget = target
+	return &newCtx, nil
+}
+
+// WithRange returns a new AppContext that renders a time range of the sequence,
+// matching the same span of a full render. An end of 0 is the end of the sequence.
+//
+// Example:
+//
+//	ctx, err = ctx.WithRange(10*time.Minute, 12*time.Minute)
+//
+// Returns an error if a time is negative or the end is not after the start.
+// Times past the end of the sequence are reported when rendering.
+func (ac *AppContext) WithRange(start, end time.Duration) (*AppContext, error) {
+	if start < 0 || end < 0 {
+		return nil, fmt.Errorf("range times cannot be negative")
+	}
+	if end != 0 && end <= start {
+		return nil, fmt.Errorf("range end (%v) must be after its start (%v)", end, start)
+	}
+
+	newCtx := *ac
+	newCtx.start = start
+	newCtx.end = end
+	return &newCtx, nil
+}
+
+// WithEdgeFade returns a new AppContext that fades in and out the edges of the
+// time range, to avoid clicks in previews.
+//
+// Example:
+//
+//	ctx, err = ctx.WithEdgeFade(500 * time.Millisecond)
+//
+// Returns an error if the fade is negative.
+func (ac *AppContext) WithEdgeFade(fade time.Duration) (*AppContext, error) {
+	if fade < 0 {
+		return nil, fmt.Errorf("edge fade cannot be negative, got %v", fade)
+	}
+
+	newCtx := *ac
+	newCtx.edgeFade = fade
+	return &newCtx, nil
+}
@@ -0,0 +1,97 @@
+//go:build !wasm
+
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/synapseq-foundation/synapseq/v3/internal/audio"
+	"github.com/synapseq-foundation/synapseq/v3/internal/chart"
+)
+
+const (
+	// ChartTimeline charts the carrier, beat and amplitude of every channel
+	ChartTimeline = "timeline"
+	// ChartSpectrogram charts the spectrum of the rendered audio
+	ChartSpectrogram = "spectrogram"
+)
+
+// timelineSteps is the number of timeline points sampled in each period
+const timelineSteps = 64
+
+// Chart draws a chart of the loaded sequence as a PNG or SVG image. The
+// timeline chart is computed from the periods of the sequence, the
+// spectrogram renders the audio (with the time range and loudness of the
+// context) and charts the frequencies of its tones.
+func (ac *AppContext) Chart(kind, format string, data io.Writer) error {
+	f, err := chart.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+
+	return ac.chart(kind, f, data)
+}
+
+// SaveChart draws a chart of the loaded sequence to the output file, whose
+// extension (.png or .svg) sets the image format
+func (ac *AppContext) SaveChart(kind string) error {
+	f, err := chart.FormatFromPath(ac.outputFile)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = ac.chart(kind, f, &buf); err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(ac.outputFile, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// chart draws a chart of the loaded sequence in an image format
+func (ac *AppContext) chart(kind string, format chart.Format, data io.Writer) error {
+	if ac.sequence == nil {
+		return fmt.Errorf("sequence is nil")
+	}
+
+	periods := ac.sequence.Periods
+	points := audio.Timeline(periods, ac.sequence.Options.SampleRate, timelineSteps)
+
+	switch kind {
+	case ChartTimeline:
+		return chart.WriteTimeline(data, format, periods, points)
+	case ChartSpectrogram:
+		renderer, err := ac.normalized(context.Background())
+		if err != nil {
+			return err
+		}
+
+		sp, err := renderer.Spectrogram(chart.SpectrogramColumns, chart.FrequencyRange(points, ac.sequence.Options.SampleRate))
+		if err != nil {
+			return err
+		}
+
+		return chart.WriteSpectrogram(data, format, periods, sp)
+	default:
+		return fmt.Errorf("invalid chart: %s (expected %s or %s)", kind, ChartTimeline, ChartSpectrogram)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	synapseq "github.com/synapseq-foundation/synapseq/v3/core"
 )
@@ -99,6 +100,52 @@ func ExampleAppContext_Stream() {
 	// Output: RAW data streamed successfully with format: text
 }
 
+func ExampleAppContext_WAVContext() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Stop the render after a minute, the partial file is removed
+	// jobCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	// defer cancel()
+	// if err := ctx.WAVContext(jobCtx); errors.Is(err, context.DeadlineExceeded) {
+	//	fmt.Println("Render cancelled")
+	// }
+
+	fmt.Printf("WAV file generated with a deadline from format: %s\n", ctx.Format())
+	// Output: WAV file generated with a deadline from format: text
+}
+
+func ExampleAppContext_StreamContext() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Stream to an HTTP client, stopping when it disconnects
+	// func(w http.ResponseWriter, req *http.Request) {
+	//	if err := ctx.StreamContext(req.Context(), w); err != nil {
+	//		log.Println(err)
+	//	}
+	// }
+
+	fmt.Printf("RAW data streamed until cancelled with format: %s\n", ctx.Format())
+	// Output: RAW data streamed until cancelled with format: text
+}
+
 func ExampleAppContext_Comments() {
 	// Create a new application context for text format
 	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
@@ -181,6 +228,204 @@ func ExampleAppContext_GainLevel() {
 	// Output: Gain level retrieved successfully with format: text
 }
 
+func ExampleAppContext_BackgroundGainDB() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Get the background gain from the loaded sequence, in dB of attenuation
+	// gain := ctx.BackgroundGainDB()
+	// fmt.Printf("Background gain: -%.2f dB\n", gain)
+
+	fmt.Printf("Background gain retrieved successfully with format: %s\n", ctx.Format())
+	// Output: Background gain retrieved successfully with format: text
+}
+
+func ExampleAppContext_Seed() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Get the noise seed from the loaded sequence
+	// seed := ctx.Seed()
+	// fmt.Printf("Seed: %d\n", seed)
+
+	fmt.Printf("Seed retrieved successfully with format: %s\n", ctx.Format())
+	// Output: Seed retrieved successfully with format: text
+}
+
+func ExampleAppContext_Balance() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Get the ear balance from the loaded sequence
+	// balance := ctx.Balance()
+	// fmt.Printf("Balance: %.2f\n", balance)
+
+	fmt.Printf("Balance retrieved successfully with format: %s\n", ctx.Format())
+	// Output: Balance retrieved successfully with format: text
+}
+
+func ExampleAppContext_Layout() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Get the speaker layout, stereo until a sequence sets @layout
+	fmt.Printf("Layout: %s, channels: %d\n", ctx.Layout(), ctx.Channels())
+	// Output: Layout: stereo, channels: 2
+}
+
+func ExampleAppContext_Limiter() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Get the master limiter from the loaded sequence ("off" or "soft")
+	// limiter := ctx.Limiter()
+	// fmt.Printf("Limiter: %s\n", limiter)
+
+	fmt.Printf("Limiter retrieved successfully with format: %s\n", ctx.Format())
+	// Output: Limiter retrieved successfully with format: text
+}
+
+func ExampleAppContext_Dither() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Get the output dither, truncation until a sequence sets @dither
+	fmt.Printf("Dither: %s\n", ctx.Dither())
+	// Output: Dither: off
+}
+
+func ExampleAppContext_ClipStats() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence and render it
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+	// if err := ctx.WAV(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Check if the amplitudes are too hot for the output
+	stats := ctx.ClipStats()
+	// if stats.Samples > 0 {
+	//	fmt.Printf("%d samples clipped, up to %.2f dB over full scale\n", stats.Samples, stats.MaxOvershoot)
+	// }
+
+	fmt.Printf("Clipped samples before rendering: %d\n", stats.Samples)
+	// Output: Clipped samples before rendering: 0
+}
+
+func ExampleAppContext_WithLoudness() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Normalize the output to -23 LUFS (EBU R128)
+	ctx, err = ctx.WithLoudness(-23)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence and render it in two passes
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+	// if err := ctx.WAV(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Check the measured loudness and the applied gain
+	// loudness := ctx.Loudness()
+	// fmt.Printf("Measured %.2f LUFS, gain %+.2f dB\n", loudness.Measured, loudness.Gain)
+
+	fmt.Printf("Normalize: %v, target: %.2f LUFS\n", ctx.Normalize(), ctx.LoudnessTarget())
+	// Output: Normalize: true, target: -23.00 LUFS
+}
+
+func ExampleAppContext_WithRange() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "preview.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Render two minutes from the middle of the sequence, faded at the edges
+	ctx, err = ctx.WithRange(10*time.Minute, 12*time.Minute)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx, err = ctx.WithEdgeFade(500 * time.Millisecond)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence and render the excerpt
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+	// if err := ctx.WAV(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Or stream any range as raw PCM
+	// if err := ctx.RenderRange(30*time.Second, time.Minute, os.Stdout); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	start, end := ctx.Range()
+	fmt.Printf("Range: %v to %v, fade %v\n", start, end, ctx.EdgeFade())
+	// Output: Range: 10m0s to 12m0s, fade 500ms
+}
+
 func ExampleAppContext_BackgroundPath() {
 	// Create a new application context for text format
 	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
@@ -201,6 +446,48 @@ func ExampleAppContext_BackgroundPath() {
 	// Output: Background path retrieved successfully with format: text
 }
 
+func ExampleAppContext_Backgrounds() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Get the named background audio sources from the loaded sequence
+	// for name, path := range ctx.Backgrounds() {
+	//	fmt.Printf("Background %s: %s\n", name, path)
+	// }
+
+	fmt.Printf("Backgrounds retrieved successfully with format: %s\n", ctx.Format())
+	// Output: Backgrounds retrieved successfully with format: text
+}
+
+func ExampleAppContext_Cues() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Get the one-shot audio cues from the loaded sequence
+	// for name, path := range ctx.Cues() {
+	//	fmt.Printf("Cue %s: %s\n", name, path)
+	// }
+
+	fmt.Printf("Cues retrieved successfully with format: %s\n", ctx.Format())
+	// Output: Cues retrieved successfully with format: text
+}
+
 func ExampleAppContext_RawContent() {
 	// Create a new application context for text format
 	ctx, err := synapseq.NewAppContext("input.spsq", "output.wav", "text")
@@ -265,6 +552,87 @@ func ExampleAppContext_SaveText() {
 	// Output: Sequence saved as text format successfully from format: xml
 }
 
+func ExampleAppContext_Verify() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Measure the tones of a WAV file rendered from the sequence
+	tol := synapseq.DefaultVerifyTolerance()
+	// report, err := ctx.Verify("output.wav", tol)
+	// if err != nil {
+	//	log.Fatal(err)
+	// }
+	// if !report.Passed() {
+	//	fmt.Printf("%d tones out of tolerance\n", report.Failed())
+	// }
+
+	fmt.Printf("Verifying %s with tolerances: carrier %.1f Hz, beat %.1f Hz, amplitude %.1f dB\n",
+		ctx.Format(), tol.Carrier, tol.Beat, tol.Amplitude)
+	// Output: Verifying text with tolerances: carrier 0.5 Hz, beat 0.2 Hz, amplitude 1.0 dB
+}
+
+func ExampleAppContext_Chart() {
+	// Create a new application context for text format
+	ctx, err := synapseq.NewAppContext("input.spsq", "", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Draw the timeline chart as SVG to stdout
+	// if err := ctx.Chart(synapseq.ChartTimeline, "svg", os.Stdout); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	fmt.Printf("Chart drawn from format: %s\n", ctx.Format())
+	// Output: Chart drawn from format: text
+}
+
+func ExampleAppContext_SaveChart() {
+	// Create a new application context with a PNG output file
+	ctx, err := synapseq.NewAppContext("input.spsq", "spectrogram.png", "text")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the sequence
+	// if err := ctx.LoadSequence(); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	// Render the sequence and save its spectrogram
+	// if err := ctx.SaveChart(synapseq.ChartSpectrogram); err != nil {
+	//	log.Fatal(err)
+	// }
+
+	fmt.Printf("Chart saved to: %s\n", ctx.OutputFile())
+	// Output: Chart saved to: spectrogram.png
+}
+
+func ExampleVerifyEmbedded() {
+	// Verify a WAV file against the text sequence embedded in its metadata
+	// report, err := synapseq.VerifyEmbedded("input.wav", synapseq.DefaultVerifyTolerance())
+	// if err != nil {
+	//	log.Fatal(err)
+	// }
+	// fmt.Printf("Passed: %v\n", report.Passed())
+
+	fmt.Println("WAV file verified against its embedded sequence.")
+	// Output: WAV file verified against its embedded sequence.
+}
+
 func ExampleExtract() {
 	// Extract text sequence from WAV file
 	// content, err := synapseq.Extract("input.wav")
@@ -14,16 +14,61 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"math"
+	"time"
 
 	"github.com/synapseq-foundation/synapseq/v3/internal/audio"
 	"github.com/synapseq-foundation/synapseq/v3/internal/info"
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
 
+// clipStatsFrom converts the renderer clip statistics
+func clipStatsFrom(stats audio.ClipStats) ClipStats {
+	return ClipStats{
+		Samples:      stats.Samples,
+		MaxOvershoot: stats.MaxOvershoot,
+		Limited:      stats.Limited,
+	}
+}
+
+// ClipStats returns the clip statistics of the last WAV or Stream render.
+// Samples above zero mean the amplitudes are too hot for the output.
+func (ac *AppContext) ClipStats() ClipStats {
+	return ac.clipStats
+}
+
+// Loudness returns the loudness normalization of the last WAV or Stream render.
+// It is zero when no loudness target is set.
+func (ac *AppContext) Loudness() LoudnessStats {
+	return ac.loudness
+}
+
 // generate generates the audio renderer based on the loaded sequence
 func (ac *AppContext) generate() (*audio.AudioRenderer, error) {
+	return ac.generateWithGain(0)
+}
+
+// generateWithGain generates the audio renderer with a normalization gain in dB
+func (ac *AppContext) generateWithGain(gain float64) (*audio.AudioRenderer, error) {
+	options, err := ac.rendererOptions(gain)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer, err := audio.NewAudioRenderer(ac.sequence.Periods, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderer, nil
+}
+
+// rendererOptions returns the renderer options of the loaded sequence with a
+// normalization gain in dB
+func (ac *AppContext) rendererOptions(gain float64) (*audio.AudioRendererOptions, error) {
 	sequence := ac.sequence
 	if sequence == nil {
 		return nil, fmt.Errorf("sequence is nil")
@@ -34,30 +79,99 @@ func (ac *AppContext) generate() (*audio.AudioRenderer, error) {
 		return nil, fmt.Errorf("sequence options are nil")
 	}
 
-	renderer, err := audio.NewAudioRenderer(sequence.Periods, &audio.AudioRendererOptions{
+	return &audio.AudioRendererOptions{
 		SampleRate:     options.SampleRate,
 		Volume:         options.Volume,
 		GainLevel:      options.GainLevel,
 		BackgroundPath: options.BackgroundPath,
+		BackgroundLoop: options.BackgroundLoop,
+		Backgrounds:    options.Backgrounds,
+		Cues:           options.Cues,
+		Waveforms:      options.Waveforms,
 		StatusOutput:   ac.statusOutput,
-	})
+		Seed:           options.Seed,
+		Balance:        options.Balance,
+		Limiter:        options.Limiter,
+		Dither:         options.Dither,
+		NormalizeGain:  gain,
+		Layout:         options.Layout,
+		Start:          int(ac.start.Milliseconds()),
+		End:            int(ac.end.Milliseconds()),
+		EdgeFade:       int(ac.edgeFade.Milliseconds()),
+	}, nil
+}
+
+// normalized returns the renderer of the output. When a loudness target is set,
+// a silent first pass measures the sequence and the gain reaches the target.
+func (ac *AppContext) normalized(ctx context.Context) (*audio.AudioRenderer, error) {
+	if !ac.normalize {
+		return ac.generate()
+	}
+
+	gain, err := ac.measureGain(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return renderer, nil
+	return ac.generateWithGain(gain)
+}
+
+// measureGain measures the loudness of the sequence in a silent pass and
+// returns the gain in dB that reaches the loudness target. The whole sequence
+// is measured even for a time range, so the range gets the gain of a full
+// render.
+func (ac *AppContext) measureGain(ctx context.Context) (float64, error) {
+	full := ac.WithVerbose(nil)
+	full.start, full.end, full.edgeFade = 0, 0, 0
+
+	measure, err := full.generate()
+	if err != nil {
+		return 0, err
+	}
+
+	if ac.statusOutput != nil {
+		fmt.Fprintf(ac.statusOutput, "Measuring loudness...\n")
+	}
+
+	measured, err := measure.MeasureLoudnessContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Silence cannot be normalized
+	gain := 0.0
+	if !math.IsInf(measured, -1) {
+		gain = ac.loudnessTarget - measured
+	}
+
+	ac.loudness = LoudnessStats{
+		Target:   ac.loudnessTarget,
+		Measured: measured,
+		Gain:     gain,
+	}
+	audio.NewStatusReporter(ac.statusOutput).DisplayLoudness(measured, ac.loudnessTarget, gain)
+
+	return gain, nil
 }
 
 // WAV generates the WAV file from the loaded sequence
 func (ac *AppContext) WAV() error {
-	renderer, err := ac.generate()
+	return ac.WAVContext(context.Background())
+}
+
+// WAVContext generates the WAV file from the loaded sequence until the
+// context is done. A cancelled or failed render removes the partial file and
+// returns the error.
+func (ac *AppContext) WAVContext(ctx context.Context) error {
+	renderer, err := ac.normalized(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err = renderer.RenderWav(ac.outputFile); err != nil {
+	if err = renderer.RenderWavContext(ctx, ac.outputFile); err != nil {
 		return err
 	}
+	ac.clipStats = clipStatsFrom(renderer.ClipStats())
 
 	presetList := ac.sequence.Options.PresetList
 	if ac.format == t.FormatText && len(presetList) == 0 && !ac.unsafeNoMetadata {
@@ -66,6 +180,10 @@ func (ac *AppContext) WAV() error {
 			return err
 		}
 
+		if ac.normalize {
+			metadata.SetLoudness(ac.loudness.Measured, ac.loudness.Target, ac.loudness.Gain)
+		}
+
 		if err = audio.WriteICMTChunkFromTextFile(ac.outputFile, metadata); err != nil {
 			return err
 		}
@@ -77,15 +195,37 @@ func (ac *AppContext) WAV() error {
 
 // Stream generates the raw audio stream from the loaded sequence
 func (ac *AppContext) Stream(data io.Writer) error {
-	renderer, err := ac.generate()
+	return ac.StreamContext(context.Background(), data)
+}
+
+// StreamContext generates the raw audio stream from the loaded sequence until
+// the context is done, as when the reader of the stream disconnects
+func (ac *AppContext) StreamContext(ctx context.Context, data io.Writer) error {
+	renderer, err := ac.normalized(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = renderer.RenderRaw(data)
+	err = renderer.RenderRawContext(ctx, data)
+	ac.clipStats = clipStatsFrom(renderer.ClipStats())
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// RenderRange generates the raw audio stream of a time range of the loaded
+// sequence, matching the same span of a full render. An end of 0 is the end
+// of the sequence.
+func (ac *AppContext) RenderRange(start, end time.Duration, data io.Writer) error {
+	ranged, err := ac.WithRange(start, end)
+	if err != nil {
+		return err
+	}
+
+	err = ranged.Stream(data)
+	ac.clipStats = ranged.clipStats
+	ac.loudness = ranged.loudness
+	return err
+}
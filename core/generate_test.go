@@ -0,0 +1,80 @@
+//go:build !wasm
+
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package core
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// loadTestSequence loads a text sequence from a temporary file
+func loadTestSequence(ts *testing.T, content string) *AppContext {
+	ts.Helper()
+
+	path := filepath.Join(ts.TempDir(), "seq.spsq")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		ts.Fatalf("write sequence: %v", err)
+	}
+
+	ac, err := NewAppContext(path, "", "text")
+	if err != nil {
+		ts.Fatalf("NewAppContext failed: %v", err)
+	}
+	if err := ac.LoadSequence(); err != nil {
+		ts.Fatalf("LoadSequence failed: %v", err)
+	}
+	return ac
+}
+
+func TestAppContext_RenderRange_MatchesNormalizedFullRender(ts *testing.T) {
+	ac := loadTestSequence(ts, `@samplerate 8000
+
+quiet
+  tone 200 binaural 10 amplitude 5
+loud
+  tone 300 binaural 6 amplitude 60
+
+00:00:00 quiet
+00:00:04 loud
+00:00:08 loud
+`)
+	ac, err := ac.WithLoudness(-23)
+	if err != nil {
+		ts.Fatalf("WithLoudness failed: %v", err)
+	}
+
+	var full bytes.Buffer
+	if err := ac.Stream(&full); err != nil {
+		ts.Fatalf("Stream failed: %v", err)
+	}
+	fullGain := ac.Loudness().Gain
+
+	// The quiet span alone would be measured much quieter than the sequence
+	var ranged bytes.Buffer
+	if err := ac.RenderRange(time.Second, 3*time.Second, &ranged); err != nil {
+		ts.Fatalf("RenderRange failed: %v", err)
+	}
+	if gain := ac.Loudness().Gain; gain != fullGain {
+		ts.Fatalf("range gain %.2f dB, expected the full render gain %.2f dB", gain, fullGain)
+	}
+
+	frameBytes := 2 * 2
+	from, to := 8000*frameBytes, 3*8000*frameBytes
+	if !bytes.Equal(ranged.Bytes(), full.Bytes()[from:to]) {
+		ts.Fatalf("range does not match the full render (%d bytes, expected %d)", ranged.Len(), to-from)
+	}
+}
@@ -14,6 +14,8 @@
 package core
 
 import (
+	"math"
+
 	seq "github.com/synapseq-foundation/synapseq/v3/internal/sequence"
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
@@ -74,12 +76,82 @@ func (ac *AppContext) Volume() int {
 // 3 = -3 dB,
 // 9 = -9 dB,
 // 18 = -18 dB
+//
+// Deprecated: gain levels can be any dB value, which GainLevel rounds to
+// whole dB. Use BackgroundGainDB instead.
 func (ac *AppContext) GainLevel() int {
+	return int(math.Round(ac.BackgroundGainDB()))
+}
+
+// BackgroundGainDB returns the background gain level from the loaded
+// sequence options, as an attenuation in dB (e.g. 4.5 for
+// "@gainlevel -4.5"). The named levels are 3 (high), 9 (medium) and
+// 18 (low).
+func (ac *AppContext) BackgroundGainDB() float64 {
+	if ac.sequence == nil || ac.sequence.Options == nil {
+		return 0
+	}
+
+	return float64(ac.sequence.Options.GainLevel)
+}
+
+// Seed returns the noise generator seed from the loaded sequence options.
+// The same seed always renders the same noise.
+func (ac *AppContext) Seed() int64 {
+	if ac.sequence == nil || ac.sequence.Options == nil {
+		return 0
+	}
+
+	return ac.sequence.Options.Seed
+}
+
+// Balance returns the ear balance from the loaded sequence options.
+// Range is -100 (left ear only) to 100 (right ear only), 0 is centered.
+func (ac *AppContext) Balance() float64 {
 	if ac.sequence == nil || ac.sequence.Options == nil {
 		return 0
 	}
 
-	return int(ac.sequence.Options.GainLevel)
+	return ac.sequence.Options.Balance.ToPercent()
+}
+
+// Limiter returns the master bus limiter from the loaded sequence options,
+// "off" (hard clipping) or "soft".
+func (ac *AppContext) Limiter() string {
+	if ac.sequence == nil || ac.sequence.Options == nil {
+		return t.LimiterOff.String()
+	}
+
+	return ac.sequence.Options.Limiter.String()
+}
+
+// Dither returns the output dither from the loaded sequence options,
+// "off" (truncation), "tpdf" or "shaped".
+func (ac *AppContext) Dither() string {
+	if ac.sequence == nil || ac.sequence.Options == nil {
+		return t.DitherOff.String()
+	}
+
+	return ac.sequence.Options.Dither.String()
+}
+
+// Layout returns the speaker layout from the loaded sequence options,
+// "stereo", "quad" or "5.1". It is also the channel layout name of ffmpeg.
+func (ac *AppContext) Layout() string {
+	if ac.sequence == nil || ac.sequence.Options == nil {
+		return t.LayoutStereo.String()
+	}
+
+	return ac.sequence.Options.Layout.String()
+}
+
+// Channels returns the number of output channels of the speaker layout.
+func (ac *AppContext) Channels() int {
+	if ac.sequence == nil || ac.sequence.Options == nil {
+		return t.LayoutStereo.Channels()
+	}
+
+	return ac.sequence.Options.Layout.Channels()
 }
 
 // BackgroundPath returns the background audio path from the loaded sequence options
@@ -91,6 +163,36 @@ func (ac *AppContext) BackgroundPath() string {
 	return ac.sequence.Options.BackgroundPath
 }
 
+// Backgrounds returns the named background audio sources from the loaded
+// sequence options, keyed by source name
+func (ac *AppContext) Backgrounds() map[string]string {
+	if ac.sequence == nil || ac.sequence.Options == nil {
+		return nil
+	}
+
+	backgrounds := make(map[string]string, len(ac.sequence.Options.Backgrounds))
+	for _, bg := range ac.sequence.Options.Backgrounds {
+		backgrounds[bg.Name] = bg.Path
+	}
+
+	return backgrounds
+}
+
+// Cues returns the one-shot audio cues from the loaded sequence options,
+// keyed by cue name
+func (ac *AppContext) Cues() map[string]string {
+	if ac.sequence == nil || ac.sequence.Options == nil {
+		return nil
+	}
+
+	cues := make(map[string]string, len(ac.sequence.Options.Cues))
+	for _, cue := range ac.sequence.Options.Cues {
+		cues[cue.Name] = cue.Path
+	}
+
+	return cues
+}
+
 // RawContent returns the raw content of the loaded sequence
 func (ac *AppContext) RawContent() []byte {
 	if ac.sequence == nil {
@@ -0,0 +1,227 @@
+//go:build !wasm
+
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/synapseq-foundation/synapseq/v3/internal/audio"
+)
+
+// VerifyTolerance holds the largest accepted differences between the
+// measured and the expected tones
+type VerifyTolerance struct {
+	// Carrier frequency, in Hz
+	Carrier float64
+	// Beat frequency, in Hz
+	Beat float64
+	// Amplitude of each ear, in dB
+	Amplitude float64
+}
+
+// ToneMeasure holds the carrier and beat of a tone and its amplitude in each ear
+type ToneMeasure struct {
+	// Carrier frequency, in Hz
+	Carrier float64
+	// Beat frequency, in Hz
+	Beat float64
+	// Left and right amplitude, in percent
+	Amplitude [2]float64
+}
+
+// VerifyResult is the verification of a tone channel in a period
+type VerifyResult struct {
+	// Period index, from 0
+	Period int
+	// Channel of the tone, from 0
+	Channel int
+	// Center of the analysis window
+	Time time.Duration
+	// Track type of the tone (e.g. binaural)
+	Type string
+	// Expected and measured tone
+	Expected, Measured ToneMeasure
+	// Pure tones have no beat, and the amplitude of isochronic tones and
+	// custom waveforms is not measured
+	BeatChecked, AmplitudeChecked bool
+	// Each measure is within its tolerance (true when not checked)
+	CarrierOK, BeatOK, AmplitudeOK bool
+}
+
+// Passed reports whether every checked measure is within its tolerance
+func (vr VerifyResult) Passed() bool {
+	return vr.CarrierOK && vr.BeatOK && vr.AmplitudeOK
+}
+
+// VerifyReport holds the verification of every tone of a sequence
+type VerifyReport struct {
+	Results []VerifyResult
+}
+
+// Passed reports whether every tone is within the tolerances
+func (vr VerifyReport) Passed() bool {
+	for _, res := range vr.Results {
+		if !res.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the number of tones out of the tolerances
+func (vr VerifyReport) Failed() int {
+	failed := 0
+	for _, res := range vr.Results {
+		if !res.Passed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// DefaultVerifyTolerance returns the default tolerances of a verification:
+// 0.5 Hz for the carrier, 0.2 Hz for the beat and 1 dB for the amplitude
+func DefaultVerifyTolerance() VerifyTolerance {
+	tol := audio.DefaultVerifyTolerance()
+	return VerifyTolerance{Carrier: tol.Carrier, Beat: tol.Beat, Amplitude: tol.Amplitude}
+}
+
+// verifyResultFrom converts a renderer verification result
+func verifyResultFrom(res audio.VerifyResult) VerifyResult {
+	return VerifyResult{
+		Period:           res.Period,
+		Channel:          res.Channel,
+		Time:             time.Duration(res.Time) * time.Millisecond,
+		Type:             res.Type.String(),
+		Expected:         ToneMeasure(res.Expected),
+		Measured:         ToneMeasure(res.Measured),
+		BeatChecked:      res.BeatChecked,
+		AmplitudeChecked: res.AmplitudeChecked,
+		CarrierOK:        res.CarrierOK,
+		BeatOK:           res.BeatOK,
+		AmplitudeOK:      res.AmplitudeOK,
+	}
+}
+
+// Verify measures the tones of a WAV file rendered from the loaded sequence
+// and compares the carrier, beat and amplitude of every period with the
+// sequence. The loudness gain is read from the metadata of the file, or
+// measured when the context normalizes the loudness. A file rendered from a
+// time range is verified with the same range and edge fade in the context,
+// over the periods it overlaps.
+//
+// Returns an error if the file cannot be analyzed, a mismatch is reported
+// by the VerifyReport.
+func (ac *AppContext) Verify(wavPath string, tol VerifyTolerance) (VerifyReport, error) {
+	gain, err := ac.verifyGain(wavPath)
+	if err != nil {
+		return VerifyReport{}, err
+	}
+
+	options, err := ac.rendererOptions(gain)
+	if err != nil {
+		return VerifyReport{}, err
+	}
+
+	results, err := audio.VerifyWAV(wavPath, ac.sequence.Periods, options, audio.VerifyTolerance(tol))
+	if err != nil {
+		return VerifyReport{}, err
+	}
+
+	report := VerifyReport{Results: make([]VerifyResult, len(results))}
+	for i, res := range results {
+		report.Results[i] = verifyResultFrom(res)
+	}
+
+	return report, nil
+}
+
+// verifyGain returns the loudness gain of a WAV file in dB, recorded in its
+// metadata or measured when the context normalizes the loudness
+func (ac *AppContext) verifyGain(wavPath string) (float64, error) {
+	if content, err := extract(wavPath); err == nil {
+		if gain, ok := loudnessGain(content); ok {
+			return gain, nil
+		}
+	}
+
+	if !ac.normalize {
+		return 0, nil
+	}
+
+	return ac.measureGain(context.Background())
+}
+
+// loudnessGain returns the gain in the loudness line of an extracted sequence
+func loudnessGain(content string) (float64, bool) {
+	for line := range strings.SplitSeq(content, "\n") {
+		record, ok := strings.CutPrefix(line, "#  Loudness : ")
+		if !ok {
+			continue
+		}
+
+		_, after, ok := strings.Cut(record, "gain ")
+		if !ok {
+			return 0, false
+		}
+
+		var gain float64
+		if _, err := fmt.Sscanf(after, "%f dB", &gain); err != nil {
+			return 0, false
+		}
+		return gain, true
+	}
+
+	return 0, false
+}
+
+// VerifyEmbedded verifies a WAV file against the text sequence embedded in
+// its metadata. The sequence is written next to the file while it loads, so
+// its relative paths resolve as when it was rendered. The metadata does not
+// record a time range, a file rendered from one is verified with Verify.
+func VerifyEmbedded(wavPath string, tol VerifyTolerance) (VerifyReport, error) {
+	content, err := extract(wavPath)
+	if err != nil {
+		return VerifyReport{}, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(wavPath), ".synapseq-verify-*.spsq")
+	if err != nil {
+		return VerifyReport{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return VerifyReport{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return VerifyReport{}, err
+	}
+
+	ac, err := NewAppContext(tmp.Name(), "", "text")
+	if err != nil {
+		return VerifyReport{}, err
+	}
+	if err := ac.LoadSequence(); err != nil {
+		return VerifyReport{}, err
+	}
+
+	return ac.Verify(wavPath, tol)
+}
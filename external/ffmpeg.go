@@ -82,7 +82,7 @@ func (fm *FFmpeg) Convert(appCtx *synapseq.AppContext, format string) error {
 		"-hide_banner",
 		"-loglevel", "error",
 		"-f", "s16le",
-		"-ch_layout", "stereo",
+		"-ch_layout", appCtx.Layout(),
 		"-ar", strconv.Itoa(appCtx.SampleRate()),
 		"-i", "pipe:0",
 	}
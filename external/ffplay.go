@@ -58,7 +58,7 @@ func (fp *FFplay) Play(appCtx *synapseq.AppContext) error {
 		"-loglevel", "error",
 		"-autoexit",
 		"-f", "s16le",
-		"-ch_layout", "stereo",
+		"-ch_layout", appCtx.Layout(),
 		"-ar", strconv.Itoa(appCtx.SampleRate()),
 		"-i", "pipe:0",
 	)
@@ -15,6 +15,9 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
+	"os"
+	"sync"
 
 	"github.com/gopxl/beep/v2"
 	bwav "github.com/gopxl/beep/v2/wav"
@@ -23,19 +26,43 @@ import (
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
 
-// BackgroundAudio handles background WAV file playback with looping
+// backgroundResampleQuality is the interpolation quality used when resampling
+// background audio to the output sample rate. Resampling happens once at load
+// time, so a high quality is affordable.
+const backgroundResampleQuality = 6
+
+// BackgroundAudio handles background WAV, FLAC, Ogg Vorbis and MP3 file playback with looping
 type BackgroundAudio struct {
 	filePath      string
 	decoder       beep.StreamSeekCloser
+	source        beep.StreamSeeker
+	sourceRate    int
+	stream        *loopStreamer
 	sampleRate    int
 	channels      int
 	bitDepth      int
 	isEnabled     bool
 	hasReachedEOF bool
 
-	// Cache of the loaded file data
+	// Cache of the loaded file data (remote files only, local files are
+	// streamed from disk)
 	cachedData []byte
 
+	// Stereo samples resampled to the output sample rate, only used for
+	// remote files that do not match it
+	pcm []int16
+
+	// Local file resampled to the output sample rate, shared with the clones
+	resampled *resampledFile
+
+	// Loop points from the WAV smpl chunk, in frames of the file
+	smplStart, smplEnd int
+	hasSmplLoop        bool
+
+	// Loop settings, kept to clone the stream
+	loop  t.BackgroundLoop
+	label string
+
 	// Buffer for reading samples
 	buffer     []int
 	bufferSize int
@@ -47,52 +74,63 @@ func NewBackgroundAudio(filePath string) (*BackgroundAudio, error) {
 		return &BackgroundAudio{isEnabled: false}, nil
 	}
 
-	bg := &BackgroundAudio{
-		filePath:   filePath,
-		bufferSize: t.BufferSize * audioChannels, // Stereo
-		isEnabled:  true,
-	}
-
-	if err := bg.loadAndCache(); err != nil {
+	bg, err := openSourceAudio(filePath, "background file")
+	if err != nil {
 		return nil, err
 	}
+	bg.bufferSize = t.BufferSize * audioChannels // Stereo
 
-	if err := bg.openFromCache(); err != nil {
-		return nil, fmt.Errorf("failed to open background file: %w", err)
+	// Loop the whole file, or the smpl loop, until conformed to the output
+	if err := bg.setupLoop(t.BackgroundLoop{}, "background audio"); err != nil {
+		bg.Close()
+		return nil, err
 	}
 
 	bg.buffer = make([]int, bg.bufferSize)
 	return bg, nil
 }
 
-// loadAndCache loads the file (local or remote) into memory cache
-func (bg *BackgroundAudio) loadAndCache() error {
-	if bg.cachedData != nil {
-		return nil
+// openSourceAudio opens the decoder of a local or remote audio file, named by
+// the label in errors. Remote files are loaded into memory with the size limit
+// of their format, their format is sniffed on open like local files.
+func openSourceAudio(path, label string) (*BackgroundAudio, error) {
+	bg := &BackgroundAudio{filePath: path, isEnabled: true}
+	if s.IsRemoteFile(path) {
+		data, err := s.GetFile(path, remoteFileFormat(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", label, err)
+		}
+		bg.cachedData = data
 	}
 
-	data, err := s.GetFile(bg.filePath, t.FormatWAV)
-	if err != nil {
-		return fmt.Errorf("failed to load background file: %w", err)
+	if err := bg.open(); err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", label, err)
 	}
-
-	bg.cachedData = data
-	return nil
+	return bg, nil
 }
 
-// openFromCache opens a decoder from the cached data
-func (bg *BackgroundAudio) openFromCache() error {
-	if bg.cachedData == nil {
-		return fmt.Errorf("no cached data available")
+// open opens a decoder from the cached data or streaming from disk
+func (bg *BackgroundAudio) open() error {
+	var reader io.ReadSeeker
+	if bg.cachedData != nil {
+		reader = bytes.NewReader(bg.cachedData)
+	} else {
+		file, err := os.Open(bg.filePath)
+		if err != nil {
+			return err
+		}
+		reader = file
 	}
 
-	reader := bytes.NewReader(bg.cachedData)
-	s, f, err := bwav.Decode(reader)
+	// The decoder owns the file and closes it on error or Close
+	s, f, err := bg.decode(reader)
 	if err != nil {
 		return err
 	}
 
 	bg.decoder = s
+	bg.source = s
+	bg.sourceRate = int(f.SampleRate)
 	bg.sampleRate = int(f.SampleRate)
 	bg.channels = f.NumChannels
 	bg.bitDepth = f.Precision * 8
@@ -101,29 +139,282 @@ func (bg *BackgroundAudio) openFromCache() error {
 	return nil
 }
 
-// restart reopens the decoder from cache for looping
-func (bg *BackgroundAudio) restart() error {
+// decode detects the format of the background file and decodes it
+func (bg *BackgroundAudio) decode(reader io.ReadSeeker) (beep.StreamSeekCloser, beep.Format, error) {
+	format, err := sniffBackgroundFormat(reader)
+	if err != nil {
+		closeReader(reader)
+		return nil, beep.Format{}, err
+	}
+
+	bg.hasSmplLoop = false
+	switch format {
+	case backgroundWAV:
+		bg.smplStart, bg.smplEnd, bg.hasSmplLoop = readSmplLoop(reader)
+		if _, err := reader.Seek(0, io.SeekStart); err != nil {
+			closeReader(reader)
+			return nil, beep.Format{}, err
+		}
+		return bwav.Decode(reader)
+	case backgroundFLAC:
+		return decodeFLAC(reader, bg.cachedData != nil)
+	case backgroundOgg:
+		return decodeOgg(reader)
+	case backgroundMP3:
+		return decodeMP3(reader)
+	default:
+		closeReader(reader)
+		return nil, beep.Format{}, fmt.Errorf("unsupported background audio format (expected WAV, FLAC, Ogg Vorbis or MP3)")
+	}
+}
+
+// conform prepares the background audio for the output format and applies
+// the loop settings of its source. Files at a different sample rate are
+// resampled once and cached for looping, remote files in memory and local
+// files, which may be of any size, in a temporary WAV file. Mono files are
+// upmixed to stereo.
+func (bg *BackgroundAudio) conform(sampleRate int, src t.BackgroundSource) error {
 	if !bg.isEnabled {
 		return nil
 	}
 
-	// Close current decoder
-	if bg.decoder != nil {
-		_ = bg.decoder.Close()
-		bg.decoder = nil
+	label := "background audio"
+	if src.Name != "" {
+		label = fmt.Sprintf("background audio %q", src.Name)
+	}
+
+	// The decoder duplicates mono into both channels of each frame
+	if bg.channels > audioChannels {
+		return fmt.Errorf("%s must be mono or stereo (%d channels detected)", label, bg.channels)
+	}
+
+	if bg.sampleRate != sampleRate {
+		var err error
+		if bg.cachedData == nil {
+			err = bg.resampleToFile(sampleRate)
+		} else {
+			err = bg.resample(sampleRate)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to resample %s from %d Hz to %d Hz: %w", label, bg.sampleRate, sampleRate, err)
+		}
+	}
+
+	return bg.setupLoop(src.Loop, label)
+}
+
+// setupLoop sets the stream to loop the source. Loop points from the settings
+// take precedence over the ones in the smpl chunk.
+func (bg *BackgroundAudio) setupLoop(loop t.BackgroundLoop, label string) error {
+	rate := float64(bg.sourceRate)
+	frames := func(seconds float64) int {
+		return int(math.Round(seconds * rate))
+	}
+	seconds := func(frames int) float64 {
+		return float64(frames) / rate
+	}
+
+	length := bg.source.Len()
+	start, end := 0, length
+	if loop.HasLoopPoints() {
+		start = frames(loop.Start)
+		if loop.End != 0 {
+			end = frames(loop.End)
+		}
+	} else if bg.hasSmplLoop {
+		// smpl loop points are in frames of the file, before resampling
+		scale := rate / float64(bg.sampleRate)
+		start = int(math.Round(float64(bg.smplStart) * scale))
+		end = int(math.Round(float64(bg.smplEnd) * scale))
+	}
+	crossfade := frames(loop.Crossfade)
+	offset := frames(loop.Offset)
+
+	if end > length {
+		return fmt.Errorf("%s loop end (%.2fs) is past the end of the file (%.2fs)", label, seconds(end), seconds(length))
+	}
+	if start >= end {
+		return fmt.Errorf("%s has an empty loop (%.2fs to %.2fs)", label, seconds(start), seconds(end))
+	}
+	if crossfade > (end-start)/2 {
+		return fmt.Errorf("%s crossfade (%.2fs) cannot exceed half the loop length (%.2fs)", label, loop.Crossfade, seconds(end-start))
+	}
+	if offset >= end {
+		return fmt.Errorf("%s offset (%.2fs) must be before the loop end (%.2fs)", label, loop.Offset, seconds(end))
+	}
+
+	looper, err := newLoopStreamer(bg.source, start, end, crossfade, offset)
+	if err != nil {
+		return fmt.Errorf("failed to loop %s: %w", label, err)
+	}
+
+	bg.stream = looper
+	bg.loop, bg.label = loop, label
+
+	return nil
+}
+
+// resample decodes the whole file at the given sample rate into the cache
+func (bg *BackgroundAudio) resample(sampleRate int) error {
+	pcm, err := bg.decodePCM(sampleRate)
+	if err != nil {
+		return err
+	}
+
+	// The decoder is no longer needed, playback loops over the cache
+	_ = bg.decoder.Close()
+	bg.decoder = nil
+	bg.pcm = pcm
+	bg.source = &pcmStreamer{pcm: pcm}
+	bg.sourceRate = sampleRate
+
+	return nil
+}
+
+// resampleToFile resamples the whole file once into a temporary WAV file and
+// streams from it, keeping memory bounded
+func (bg *BackgroundAudio) resampleToFile(sampleRate int) error {
+	if err := bg.decoder.Seek(0); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "synapseq-background-*.wav")
+	if err != nil {
+		return err
+	}
+
+	stream := beep.Resample(backgroundResampleQuality, beep.SampleRate(bg.sampleRate), beep.SampleRate(sampleRate), bg.decoder)
+	err = bwav.Encode(file, stream, beep.Format{SampleRate: beep.SampleRate(sampleRate), NumChannels: audioChannels, Precision: 2})
+	if err == nil {
+		err = stream.Err()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	// The decoder of the file is no longer needed, playback loops over the
+	// resampled file
+	decoder := bg.decoder
+	if err := bg.openResampled(&resampledFile{path: file.Name()}, sampleRate); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	_ = decoder.Close()
+
+	return nil
+}
+
+// openResampled opens a decoder of a resampled file
+func (bg *BackgroundAudio) openResampled(rf *resampledFile, sampleRate int) error {
+	file, err := os.Open(rf.path)
+	if err != nil {
+		return err
 	}
 
-	// Reopen from cache
-	if err := bg.openFromCache(); err != nil {
-		return fmt.Errorf("failed to restart background file: %w", err)
+	decoder, _, err := bwav.Decode(file)
+	if err != nil {
+		return err
 	}
 
+	rf.acquire()
+	bg.resampled = rf
+	bg.decoder = decoder
+	bg.source = decoder
+	bg.sourceRate = sampleRate
+	bg.hasReachedEOF = false
+
+	return nil
+}
+
+// decodePCM decodes the whole file from its start into stereo samples at the
+// given sample rate
+func (bg *BackgroundAudio) decodePCM(sampleRate int) ([]int16, error) {
+	if err := bg.decoder.Seek(0); err != nil {
+		return nil, err
+	}
+
+	var stream beep.Streamer = bg.decoder
+	if sampleRate != bg.sampleRate {
+		stream = beep.Resample(backgroundResampleQuality, beep.SampleRate(bg.sampleRate), beep.SampleRate(sampleRate), bg.decoder)
+	}
+
+	var pcm []int16
+	buf := make([][2]float64, t.BufferSize)
+	for {
+		n, ok := stream.Stream(buf)
+		for i := 0; i < n; i++ {
+			pcm = append(pcm, int16(toSample(buf[i][0])), int16(toSample(buf[i][1])))
+		}
+		if !ok {
+			break
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	if len(pcm) == 0 {
+		return nil, fmt.Errorf("no audio samples found")
+	}
+
+	return pcm, nil
+}
+
+// clone opens an independent stream of the background at its start, sharing
+// the cached file data and resampled samples or file
+func (bg *BackgroundAudio) clone() (*BackgroundAudio, error) {
+	c := &BackgroundAudio{
+		filePath:    bg.filePath,
+		cachedData:  bg.cachedData,
+		bufferSize:  bg.bufferSize,
+		isEnabled:   true,
+		buffer:      make([]int, bg.bufferSize),
+		sampleRate:  bg.sampleRate,
+		channels:    bg.channels,
+		bitDepth:    bg.bitDepth,
+		smplStart:   bg.smplStart,
+		smplEnd:     bg.smplEnd,
+		hasSmplLoop: bg.hasSmplLoop,
+	}
+
+	var err error
+	switch {
+	case bg.pcm != nil:
+		c.pcm = bg.pcm
+		c.source = &pcmStreamer{pcm: bg.pcm}
+		c.sourceRate = bg.sourceRate
+	case bg.resampled != nil:
+		err = c.openResampled(bg.resampled, bg.sourceRate)
+	default:
+		err = c.open()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open background file: %w", err)
+	}
+
+	if err := c.setupLoop(bg.loop, bg.label); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// seek positions the stream at an output frame, as if every frame before it
+// had been read
+func (bg *BackgroundAudio) seek(frame int64) error {
+	if err := bg.stream.seek(frame); err != nil {
+		return fmt.Errorf("failed to seek %s: %w", bg.label, err)
+	}
 	return nil
 }
 
 // ReadSamples reads background audio samples with automatic looping
 func (bg *BackgroundAudio) ReadSamples(samples []int, numSamples int) (int, error) {
-	if !bg.isEnabled || bg.decoder == nil {
+	if !bg.isEnabled || bg.stream == nil {
 		// Fill with silence if no background
 		for i := 0; i < numSamples; i++ {
 			samples[i] = 0
@@ -141,9 +432,9 @@ func (bg *BackgroundAudio) ReadSamples(samples []int, numSamples int) (int, erro
 		// read at least one full frame and then copy the needed tail.
 		var n int
 		var err error
-		if remaining < bg.channels {
-			tmp := make([]int, bg.channels)
-			n, err = bg.readFromDecoder(tmp, bg.channels)
+		if remaining < audioChannels {
+			tmp := make([]int, audioChannels)
+			n, err = bg.readFromDecoder(tmp, audioChannels)
 			if n > 0 {
 				// copy only what's requested
 				copy(samples[bufferOffset:bufferOffset+remaining], tmp[:remaining])
@@ -155,108 +446,158 @@ func (bg *BackgroundAudio) ReadSamples(samples []int, numSamples int) (int, erro
 		samplesRead += n
 
 		if err == io.EOF {
-			// End of file reached, restart for looping
-			if restartErr := bg.restart(); restartErr != nil {
-				// If restart fails, fill remaining with silence
-				for i := samplesRead; i < numSamples; i++ {
-					samples[i] = 0
-				}
-				return numSamples, nil
+			// The loop streams forever, nothing left means there is no audio
+			for i := samplesRead; i < numSamples; i++ {
+				samples[i] = 0
 			}
-			// Continue reading after restart
-			continue
+			return numSamples, nil
 		} else if err != nil {
 			return samplesRead, fmt.Errorf("error reading background audio: %w", err)
 		}
-
-		// If we read less than requested but no error, we're at EOF
-		if n < remaining {
-			if restartErr := bg.restart(); restartErr != nil {
-				// Fill remaining with silence if restart fails
-				for i := samplesRead; i < numSamples; i++ {
-					samples[i] = 0
-				}
-				return numSamples, nil
-			}
-		}
 	}
 
 	return samplesRead, nil
 }
 
-// readFromDecoder reads raw samples from the WAV decoder
+// readFromDecoder reads raw samples from the looping stream as stereo
 func (bg *BackgroundAudio) readFromDecoder(samples []int, maxSamples int) (int, error) {
-	if bg.decoder == nil {
+	if bg.stream == nil {
 		return 0, io.EOF
 	}
 
 	// Calculate how many frames to read (a frame is a set of samples for all channels)
-	framesToRead := maxSamples / bg.channels
+	framesToRead := maxSamples / audioChannels
 	if framesToRead <= 0 {
 		// Need at least one frame to progress
 		framesToRead = 1
 	}
 
 	buf := make([][2]float64, framesToRead)
-	nFrames, ok := bg.decoder.Stream(buf)
+	nFrames, ok := bg.stream.Stream(buf)
 	if !ok || nFrames == 0 {
-		if err := bg.decoder.Err(); err != nil {
+		if err := bg.stream.Err(); err != nil {
 			return 0, err
 		}
 		return 0, io.EOF
 	}
 
-	const scale = 32768.0 // 2^15 for 16-bit
-	outN := nFrames * bg.channels
+	outN := nFrames * audioChannels
 	// Limit to maxSamples to avoid overrun when we read a full frame but caller
 	// requested fewer samples than a full frame.
 	if outN > maxSamples {
 		outN = maxSamples
 	}
-	framesOut := outN / bg.channels
-	for i := 0; i < framesOut; i++ {
-		l := int(buf[i][0] * scale)
-		r := int(buf[i][1] * scale)
-
-		// clip to valid range
-		if l > audioMaxValue {
-			l = audioMaxValue
-		}
-		if l < audioMinValue {
-			l = audioMinValue
-		}
-		if r > audioMaxValue {
-			r = audioMaxValue
-		}
-		if r < audioMinValue {
-			r = audioMinValue
-		}
-
-		samples[2*i] = l
-		if bg.channels >= 2 {
-			// only write second sample if we still have space
-			if 2*i+1 < outN {
-				samples[2*i+1] = r
-			}
-		} else {
-			if 2*i+1 < outN {
-				samples[2*i+1] = l
-			}
+	for i := 0; i < nFrames; i++ {
+		// Mono files carry the same sample on both sides of the frame
+		samples[2*i] = toSample(buf[i][0])
+		// only write second sample if we still have space
+		if 2*i+1 < outN {
+			samples[2*i+1] = toSample(buf[i][1])
 		}
 	}
 
 	return outN, nil
 }
 
-// Close closes the background audio decoder
+// toSample converts a decoded sample to the 16-bit range, clipping it
+func toSample(v float64) int {
+	const scale = 32768.0 // 2^15 for 16-bit
+	sample := int(v * scale)
+
+	// clip to valid range
+	if sample > audioMaxValue {
+		sample = audioMaxValue
+	}
+	if sample < audioMinValue {
+		sample = audioMinValue
+	}
+	return sample
+}
+
+// Close closes the background audio decoder, and removes the resampled file
+// once its last user is closed
 func (bg *BackgroundAudio) Close() error {
 	bg.isEnabled = false
+
+	var err error
 	if bg.decoder != nil {
-		return bg.decoder.Close()
+		err = bg.decoder.Close()
+	}
+	if bg.resampled != nil {
+		bg.resampled.release()
+		bg.resampled = nil
+	}
+	return err
+}
+
+// resampledFile is a temporary file of resampled audio shared by a background
+// and its clones
+type resampledFile struct {
+	path string
+	mu   sync.Mutex
+	refs int
+}
+
+// acquire adds a user of the file
+func (rf *resampledFile) acquire() {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	rf.refs++
+}
+
+// release removes a user of the file, and the file with the last one
+func (rf *resampledFile) release() {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	rf.refs--
+	if rf.refs == 0 {
+		os.Remove(rf.path)
+	}
+}
+
+// pcmStreamer streams the resampled cache as a seekable source
+type pcmStreamer struct {
+	pcm []int16
+	pos int
+}
+
+// Stream converts cached stereo samples back to the decoder range
+func (ps *pcmStreamer) Stream(samples [][2]float64) (int, bool) {
+	n := 0
+	for n < len(samples) && ps.pos < ps.Len() {
+		samples[n][0] = float64(ps.pcm[2*ps.pos]) / 32768.0
+		samples[n][1] = float64(ps.pcm[2*ps.pos+1]) / 32768.0
+		n++
+		ps.pos++
+	}
+	return n, n > 0
+}
+
+// Err never fails, the cache is in memory
+func (ps *pcmStreamer) Err() error { return nil }
+
+// Len returns the number of cached frames
+func (ps *pcmStreamer) Len() int { return len(ps.pcm) / audioChannels }
+
+// Position returns the current frame
+func (ps *pcmStreamer) Position() int { return ps.pos }
+
+// Seek moves to the given frame
+func (ps *pcmStreamer) Seek(p int) error {
+	if p < 0 || p > ps.Len() {
+		return fmt.Errorf("seek position %d out of range [0, %d]", p, ps.Len())
 	}
+	ps.pos = p
 	return nil
 }
 
+// closeBackgrounds closes every background audio source
+func closeBackgrounds(backgrounds map[string]*BackgroundAudio) {
+	for _, bg := range backgrounds {
+		bg.Close()
+	}
+}
+
 // IsEnabled returns whether background audio is enabled
 func (bg *BackgroundAudio) IsEnabled() bool {
 	return bg.isEnabled
@@ -12,13 +12,17 @@
 package audio
 
 import (
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/gopxl/beep/v2"
 	bwav "github.com/gopxl/beep/v2/wav"
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
 
 const maxBackgroundFileSize = 10 * 1024 * 1024 // 10MB
@@ -285,7 +289,7 @@ func TestBackgroundAudio_Remote10MBLimit(ts *testing.T) {
 	}
 }
 
-func TestBackgroundAudio_Local10MBLimit(ts *testing.T) {
+func TestBackgroundAudio_LocalStreamsLargeFile(ts *testing.T) {
 	// Create a temporary WAV file larger than 10MB
 	tmpDir := ts.TempDir()
 	path := filepath.Join(tmpDir, "large.wav")
@@ -340,13 +344,25 @@ func TestBackgroundAudio_Local10MBLimit(ts *testing.T) {
 
 	bg, err := NewBackgroundAudio(path)
 	if err != nil {
-		ts.Fatalf("NewBackgroundAudio local 10MB limit: %v", err)
+		ts.Fatalf("NewBackgroundAudio local large file: %v", err)
 	}
 	defer bg.Close()
 
-	// Verify that only 10MB was read
-	if len(bg.cachedData) != maxBackgroundFileSize {
-		ts.Fatalf("expected cached data to be limited to %d bytes, got %d", maxBackgroundFileSize, len(bg.cachedData))
+	// Local files are streamed from disk, not cached or truncated
+	if bg.cachedData != nil {
+		ts.Fatalf("expected local file to be streamed, got %d cached bytes", len(bg.cachedData))
+	}
+	if frames := (size - 44) / 4; bg.decoder.Len() != frames {
+		ts.Fatalf("expected %d frames, got %d", frames, bg.decoder.Len())
+	}
+
+	// Reading past the 10MB mark works
+	if err := bg.decoder.Seek(bg.decoder.Len() - 16); err != nil {
+		ts.Fatalf("Seek: %v", err)
+	}
+	buf := make([]int, 64)
+	if n, err := bg.ReadSamples(buf, len(buf)); err != nil || n != len(buf) {
+		ts.Fatalf("ReadSamples past 10MB: n=%d err=%v", n, err)
 	}
 }
 
@@ -364,3 +380,193 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+type sineStreamer struct {
+	framesLeft int
+	phase      float64
+	step       float64
+}
+
+func (ss *sineStreamer) Stream(samples [][2]float64) (n int, ok bool) {
+	n = min(len(samples), ss.framesLeft)
+	for i := 0; i < n; i++ {
+		v := 0.5 * math.Sin(ss.phase)
+		samples[i][0], samples[i][1] = v, v
+		ss.phase += ss.step
+	}
+	ss.framesLeft -= n
+	return n, n > 0
+}
+
+func (ss *sineStreamer) Err() error { return nil }
+
+func writeSineWav(ts *testing.T, sampleRate, channels int, freq float64) string {
+	ts.Helper()
+	path := filepath.Join(ts.TempDir(), "sine.wav")
+	f, err := os.Create(path)
+	if err != nil {
+		ts.Fatalf("create wav: %v", err)
+	}
+	defer f.Close()
+
+	format := beep.Format{SampleRate: beep.SampleRate(sampleRate), NumChannels: channels, Precision: 2}
+	ss := &sineStreamer{framesLeft: sampleRate, step: 2 * math.Pi * freq / float64(sampleRate)}
+	if err := bwav.Encode(f, ss, format); err != nil {
+		ts.Fatalf("encode wav: %v", err)
+	}
+	return path
+}
+
+// checkResampledSine checks one second of a resampled 441 Hz mono sine
+func checkResampledSine(ts *testing.T, out []int) {
+	ts.Helper()
+
+	// Upmixed channels are identical and the pitch is preserved
+	crossings := 0
+	for i := 0; i+3 < 44100*audioChannels; i += audioChannels {
+		if out[i] != out[i+1] {
+			ts.Fatalf("expected identical channels at frame %d: %d / %d", i/2, out[i], out[i+1])
+		}
+		if out[i] < 0 && out[i+2] >= 0 {
+			crossings++
+		}
+	}
+	if crossings < 439 || crossings > 442 {
+		ts.Fatalf("expected about 441 cycles after resampling, got %d", crossings)
+	}
+}
+
+func TestBackgroundAudio_ResampleAndUpmix(ts *testing.T) {
+	bg, err := NewBackgroundAudio(writeSineWav(ts, 22050, 1, 441))
+	if err != nil {
+		ts.Fatalf("NewBackgroundAudio: %v", err)
+	}
+	defer bg.Close()
+
+	if err := bg.conform(44100, t.BackgroundSource{}); err != nil {
+		ts.Fatalf("conform: %v", err)
+	}
+
+	// Local files are resampled once into a file, not into memory
+	if bg.pcm != nil || bg.resampled == nil {
+		ts.Fatalf("expected local file to be resampled into a file")
+	}
+	path := bg.resampled.path
+
+	// Read across the loop seam
+	out := make([]int, 3*44100*audioChannels)
+	if _, err := bg.ReadSamples(out, len(out)); err != nil {
+		ts.Fatalf("ReadSamples: %v", err)
+	}
+	checkResampledSine(ts, out)
+	checkResampledSine(ts, out[2*44100*audioChannels:])
+
+	// Clones stream the same file, removed with its last user
+	c, err := bg.clone()
+	if err != nil {
+		ts.Fatalf("clone: %v", err)
+	}
+	if c.resampled != bg.resampled {
+		ts.Fatalf("expected the clone to share the resampled file")
+	}
+	cloned := make([]int, len(out))
+	if _, err := c.ReadSamples(cloned, len(cloned)); err != nil {
+		ts.Fatalf("ReadSamples clone: %v", err)
+	}
+	if !slices.Equal(cloned, out) {
+		ts.Fatalf("expected the clone to stream the same samples")
+	}
+
+	bg.Close()
+	if _, err := os.Stat(path); err != nil {
+		ts.Fatalf("expected the resampled file kept for the clone: %v", err)
+	}
+	c.Close()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		ts.Fatalf("expected the resampled file removed, got %v", err)
+	}
+}
+
+func TestBackgroundAudio_ResampleRemoteCache(ts *testing.T) {
+	wavData, err := os.ReadFile(writeSineWav(ts, 22050, 1, 441))
+	if err != nil {
+		ts.Fatalf("read wav: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		_, _ = w.Write(wavData)
+	}))
+	defer server.Close()
+
+	bg, err := NewBackgroundAudio(server.URL)
+	if err != nil {
+		ts.Fatalf("NewBackgroundAudio remote: %v", err)
+	}
+	defer bg.Close()
+
+	if err := bg.conform(44100, t.BackgroundSource{}); err != nil {
+		ts.Fatalf("conform: %v", err)
+	}
+
+	// One second of mono at 22050 Hz becomes one second of stereo at 44100 Hz
+	if frames := len(bg.pcm) / audioChannels; frames < 44090 || frames > 44110 {
+		ts.Fatalf("expected about 44100 resampled frames, got %d", frames)
+	}
+
+	out := make([]int, len(bg.pcm)+t.BufferSize)
+	if _, err := bg.ReadSamples(out, len(out)); err != nil {
+		ts.Fatalf("ReadSamples: %v", err)
+	}
+	checkResampledSine(ts, out)
+
+	// Reading past the end loops over the cached samples
+	if out[len(bg.pcm)] != int(bg.pcm[0]) || out[len(bg.pcm)+1] != int(bg.pcm[1]) {
+		ts.Fatalf("expected cache to loop at the end")
+	}
+}
+
+func TestBackgroundAudio_ConformMatchingRate(ts *testing.T) {
+	bg, err := NewBackgroundAudio(writeSineWav(ts, 44100, 2, 441))
+	if err != nil {
+		ts.Fatalf("NewBackgroundAudio: %v", err)
+	}
+	defer bg.Close()
+
+	// A file at the output rate is streamed as is, without a cache
+	if err := bg.conform(44100, t.BackgroundSource{Name: "rain"}); err != nil {
+		ts.Fatalf("conform: %v", err)
+	}
+	if bg.pcm != nil || bg.decoder == nil {
+		ts.Fatalf("expected matching background to stream from the decoder")
+	}
+}
+
+// panicStreamer panics on every read and seek, like a decoder on a
+// malformed stream
+type panicStreamer struct{}
+
+func (panicStreamer) Stream(samples [][2]float64) (int, bool) { panic("bad packet") }
+func (panicStreamer) Err() error                              { return nil }
+func (panicStreamer) Len() int                                { return 100 }
+func (panicStreamer) Position() int                           { return 0 }
+func (panicStreamer) Seek(p int) error                        { panic("bad page") }
+func (panicStreamer) Close() error                            { return nil }
+
+func TestGuardedStreamer_Panic(ts *testing.T) {
+	g := &guardedStreamer{StreamSeekCloser: panicStreamer{}}
+
+	if n, ok := g.Stream(make([][2]float64, 10)); n != 0 || ok {
+		ts.Fatalf("expected the stream to end, got %d, %v", n, ok)
+	}
+	if err := g.Err(); err == nil || !containsHelper(err.Error(), "bad packet") {
+		ts.Fatalf("expected the panic as an error, got %v", err)
+	}
+	if err := g.Seek(0); err == nil {
+		ts.Fatalf("expected seeking a failed stream to fail")
+	}
+
+	g = &guardedStreamer{StreamSeekCloser: panicStreamer{}}
+	if err := g.Seek(10); err == nil || !containsHelper(err.Error(), "bad page") {
+		ts.Fatalf("expected the panic as an error, got %v", err)
+	}
+}
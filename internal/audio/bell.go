@@ -0,0 +1,153 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// bellPartial is a partial of a bell strike, relative to the bell frequency
+type bellPartial struct {
+	// Frequency ratio to the bell frequency
+	ratio float64
+	// Level before normalization
+	level float64
+	// Decay time ratio to the bell decay
+	decay float64
+}
+
+// bellPartials are the inharmonic partials of a church bell, from the hum
+// an octave below to the upper partials, which fade first
+var bellPartials = []bellPartial{
+	{0.5, 0.6, 1.0},   // Hum
+	{1.0, 1.0, 0.8},   // Prime
+	{1.19, 0.5, 0.6},  // Tierce
+	{1.5, 0.35, 0.5},  // Quint
+	{2.0, 0.6, 0.4},   // Nominal
+	{2.51, 0.25, 0.3}, // Deciem
+	{2.66, 0.2, 0.25}, // Undeciem
+	{3.01, 0.15, 0.2}, // Duodeciem
+	{4.1, 0.1, 0.15},  // Upper octave nominal
+}
+
+// bellKey identifies a synthesized bell strike
+type bellKey struct {
+	frequency, decay float64
+}
+
+// synthBell renders a bell strike in the wave table range as a sum of sine
+// partials read from the wave table, each decaying exponentially by 60 dB
+// over its decay time. Partials above the Nyquist frequency are left out.
+func (r *AudioRenderer) synthBell(frequency, decay float64) []int32 {
+	sine := r.waveTables[t.WaveformSine]
+	sampleRate := float64(r.SampleRate)
+	frames := int(math.Ceil(decay * sampleRate))
+
+	total := 0.0
+	for _, p := range bellPartials {
+		total += p.level
+	}
+
+	mixed := make([]float64, frames)
+	for _, p := range bellPartials {
+		freq := frequency * p.ratio
+		if freq >= sampleRate/2 {
+			continue
+		}
+
+		increment := int(freq / sampleRate * t.SineTableSize * t.PhasePrecision)
+		// Falls by 60 dB (a factor of 1000) over the decay time of the partial
+		factor := math.Pow(1e-3, 1/(decay*p.decay*sampleRate))
+		gain := p.level / total
+
+		offset := 0
+		for i := range mixed {
+			mixed[i] += gain * float64(sine[offset>>16])
+			offset += increment
+			offset &= (t.SineTableSize << 16) - 1
+			gain *= factor
+		}
+	}
+
+	strike := make([]int32, frames)
+	for i, v := range mixed {
+		strike[i] = int32(v)
+	}
+	return strike
+}
+
+// scheduleBells returns the bell strikes triggered by the timeline. A bell
+// strikes at the time of every period where it appears, and again every
+// interval until the next period. A strike rings for its decay time unless
+// its channel strikes again, which damps it.
+func (r *AudioRenderer) scheduleBells() []oneShot {
+	var events []oneShot
+
+	strikes := make(map[bellKey][]int32)
+	damp := r.frameAt(oneShotDampMs)
+
+	for ch := range t.NumberOfChannels {
+		last := -1
+		for p, period := range r.periods {
+			tr := period.TrackStart[ch]
+			if tr.Type != t.TrackBell || p+1 == len(r.periods) {
+				continue
+			}
+
+			key := bellKey{tr.Carrier, tr.Decay}
+			strike, ok := strikes[key]
+			if !ok {
+				strike = r.synthBell(tr.Carrier, tr.Decay)
+				strikes[key] = strike
+			}
+
+			periodStart := r.frameAt(period.Time)
+			periodEnd := r.frameAt(r.periods[p+1].Time)
+			left, right := calcPanGains(tr.Pan)
+
+			for k := 0; ; k++ {
+				start := periodStart
+				if k > 0 {
+					if tr.Interval == 0 {
+						break
+					}
+					start += int64(math.Round(float64(k) * tr.Interval * float64(r.SampleRate)))
+				}
+				if start >= periodEnd {
+					break
+				}
+
+				if last >= 0 && events[last].end > start {
+					events[last].damp = start
+					events[last].end = min(events[last].end, start+damp)
+				}
+
+				end := start + int64(len(strike))
+				events = append(events, oneShot{
+					start:     start,
+					end:       end,
+					damp:      end,
+					strike:    strike,
+					amplitude: int(tr.Amplitude),
+					pan:       [2]float64{left, right},
+					panned:    tr.Pan != 0,
+					route:     tr.Route,
+				})
+				last = len(events) - 1
+			}
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,155 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// bellTestPeriods strikes a bell on the first channel of every period, the
+// periods are times in ms and the last one ends the sequence
+func bellTestPeriods(bells []t.Track, times ...int) []t.Period {
+	periods := make([]t.Period, len(times))
+	for i, ms := range times {
+		periods[i].Time = ms
+		if i < len(bells) {
+			periods[i].TrackStart[0] = bells[i]
+		}
+		periods[i].TrackEnd = periods[i].TrackStart
+	}
+	return periods
+}
+
+// goertzelPower returns the power of a frequency in a signal
+func goertzelPower[T int | int32](samples []T, freq, sampleRate float64) float64 {
+	z := make([]complex128, len(samples))
+	for i, v := range samples {
+		z[i] = complex(float64(v), 0)
+	}
+	m := goertzel(z, freq, sampleRate)
+	return m * m
+}
+
+func TestSynthBell_PartialsAndDecay(ts *testing.T) {
+	r := &AudioRenderer{waveTables: InitWaveformTables(), AudioRendererOptions: &AudioRendererOptions{SampleRate: 44100}}
+
+	strike := r.synthBell(440, 1)
+	if len(strike) != 44100 {
+		ts.Fatalf("expected a strike of 44100 frames, got %d", len(strike))
+	}
+
+	// The strike stays in the wave table range
+	for i, v := range strike {
+		if v > t.WaveTableAmplitude || v < -t.WaveTableAmplitude {
+			ts.Fatalf("frame %d out of range: %d", i, v)
+		}
+	}
+
+	// The prime, the hum and the nominal are present, a frequency between them is not
+	head := strike[:8192]
+	prime := goertzelPower(head, 440, 44100)
+	for _, freq := range []float64{220, 880} {
+		if p := goertzelPower(head, freq, 44100); p < prime/10 {
+			ts.Errorf("expected a partial at %.0f Hz, power %.3g vs prime %.3g", freq, p, prime)
+		}
+	}
+	if p := goertzelPower(head, 330, 44100); p > prime/100 {
+		ts.Errorf("unexpected energy at 330 Hz, power %.3g vs prime %.3g", p, prime)
+	}
+
+	// Falls by about 60 dB over the decay time
+	peak := func(samples []int32) float64 {
+		m := 0.0
+		for _, v := range samples {
+			m = max(m, math.Abs(float64(v)))
+		}
+		return m
+	}
+	drop := 20 * math.Log10(peak(strike[:4410])/peak(strike[len(strike)-4410:]))
+	if drop < 50 || drop > 70 {
+		ts.Errorf("expected the strike to fall by about 60 dB, got %.1f dB", drop)
+	}
+
+	// Partials above the Nyquist frequency are left out instead of aliasing
+	high := r.synthBell(15000, 0.1)[:2048]
+	if p, alias := goertzelPower(high, 15000, 44100), goertzelPower(high, 44100-15000*1.5, 44100); alias > p/1000 {
+		ts.Errorf("unexpected alias of the quint, power %.3g vs prime %.3g", alias, p)
+	}
+}
+
+func TestAudioRenderer_ScheduleBells(ts *testing.T) {
+	every := t.Track{Type: t.TrackBell, Carrier: 440, Decay: 0.5, Interval: 1, Amplitude: t.AmplitudePercentToRaw(50)}
+	ring := t.Track{Type: t.TrackBell, Carrier: 440, Decay: 2, Amplitude: t.AmplitudePercentToRaw(50)}
+
+	// Struck every second, then once per period while still ringing
+	periods := bellTestPeriods([]t.Track{every, ring, ring}, 0, 2500, 3000, 6000)
+	r, err := NewAudioRenderer(periods, &AudioRendererOptions{SampleRate: 44100, Volume: 100})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	damp := r.frameAt(oneShotDampMs)
+	expected := [][3]int64{
+		{0, 22050, 22050},
+		{44100, 66150, 66150},
+		{88200, 110250, 110250},
+		{110250, 132300 + damp, 132300},
+		{132300, 220500, 220500},
+	}
+	if len(r.oneShots) != len(expected) {
+		ts.Fatalf("expected %d strikes, got %d", len(expected), len(r.oneShots))
+	}
+	for i, shot := range r.oneShots {
+		if shot.start != expected[i][0] || shot.end != expected[i][1] || shot.damp != expected[i][2] {
+			ts.Errorf("strike %d: expected frames %v, got [%d %d %d]", i, expected[i], shot.start, shot.end, shot.damp)
+		}
+	}
+
+	// Strikes of the same bell share their samples
+	if &r.oneShots[0].strike[0] != &r.oneShots[2].strike[0] {
+		ts.Errorf("expected strikes of the same bell to share their samples")
+	}
+}
+
+func TestAudioRenderer_BellRender(ts *testing.T) {
+	bell := t.Track{Type: t.TrackBell, Carrier: 660, Decay: 0.5, Amplitude: t.AmplitudePercentToRaw(100)}
+	periods := bellTestPeriods([]t.Track{{}, bell}, 0, 250, 1500)
+
+	r, err := NewAudioRenderer(periods, &AudioRendererOptions{SampleRate: 44100, Volume: 100})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+	strike := r.synthBell(660, 0.5)
+
+	var out []int
+	if err := r.Render(func(samples []int) error {
+		out = append(out, samples...)
+		return nil
+	}); err != nil {
+		ts.Fatalf("Render failed: %v", err)
+	}
+
+	// The strike starts inside a buffer and is centered
+	const start = 11025
+	for i := range len(out) / audioChannels {
+		want := 0
+		if k := i - start; k >= 0 && k < len(strike) {
+			want = int(strike[k]) * int(bell.Amplitude) >> audioBitShift
+		}
+		if out[2*i] != want || out[2*i+1] != want {
+			ts.Fatalf("frame %d: expected %d, got %d %d", i, want, out[2*i], out[2*i+1])
+		}
+	}
+}
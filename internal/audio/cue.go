@@ -0,0 +1,100 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"fmt"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// maxCueSeconds bounds the length of a cue, which is decoded whole into memory
+const maxCueSeconds = 300
+
+// loadCue decodes a cue file whole into stereo samples at the output sample rate
+func loadCue(src t.CueSource, sampleRate int) ([]int16, error) {
+	label := fmt.Sprintf("cue %q", src.Name)
+
+	bg, err := openSourceAudio(src.Path, label)
+	if err != nil {
+		return nil, err
+	}
+	defer bg.Close()
+
+	if bg.channels > audioChannels {
+		return nil, fmt.Errorf("%s must be mono or stereo (%d channels detected)", label, bg.channels)
+	}
+	if seconds := float64(bg.decoder.Len()) / float64(bg.sampleRate); seconds > maxCueSeconds {
+		return nil, fmt.Errorf("%s is too long (%.2fs, at most %ds)", label, seconds, maxCueSeconds)
+	}
+
+	pcm, err := bg.decodePCM(sampleRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", label, err)
+	}
+
+	return pcm, nil
+}
+
+// scheduleCues returns the cues triggered by the timeline. A cue starts at the
+// time of the period where its channel switches to it and plays to its end,
+// unless the channel triggers another cue first, which damps it. A channel
+// holding the same cue over several periods plays it once. Cues without audio
+// are silent.
+func (r *AudioRenderer) scheduleCues(cues map[string][]int16) []oneShot {
+	var events []oneShot
+	damp := r.frameAt(oneShotDampMs)
+
+	for ch := range t.NumberOfChannels {
+		last := -1
+		for p, period := range r.periods {
+			tr := period.TrackStart[ch]
+			if tr.Type != t.TrackCue {
+				continue
+			}
+			if p > 0 {
+				prev := r.periods[p-1].TrackStart[ch]
+				if prev.Type == t.TrackCue && prev.Source == tr.Source {
+					continue
+				}
+			}
+
+			start := r.frameAt(period.Time)
+			if last >= 0 && events[last].end > start {
+				events[last].damp = start
+				events[last].end = min(events[last].end, start+damp)
+			}
+
+			pcm, ok := cues[tr.Source]
+			if !ok {
+				last = -1
+				continue
+			}
+
+			left, right := calcPanGains(tr.Pan)
+			end := start + int64(len(pcm)/audioChannels)
+			events = append(events, oneShot{
+				start:     start,
+				end:       end,
+				damp:      end,
+				pcm:       pcm,
+				amplitude: int(tr.Amplitude),
+				pan:       [2]float64{left, right},
+				panned:    tr.Pan != 0,
+				route:     tr.Route,
+			})
+			last = len(events) - 1
+		}
+	}
+
+	return events
+}
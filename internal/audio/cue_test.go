@@ -0,0 +1,156 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// cueTestPeriods plays a cue on the first channel of every period, the
+// periods are times in ms and the last one ends the sequence
+func cueTestPeriods(cues []string, times ...int) []t.Period {
+	periods := make([]t.Period, len(times))
+	for i, ms := range times {
+		periods[i].Time = ms
+		if i < len(cues) && cues[i] != "" {
+			periods[i].TrackStart[0] = t.Track{Type: t.TrackCue, Source: cues[i], Amplitude: t.AmplitudePercentToRaw(100)}
+		}
+		periods[i].TrackEnd = periods[i].TrackStart
+	}
+	return periods
+}
+
+func TestAudioRenderer_ScheduleCues(ts *testing.T) {
+	bell := writeSineWav(ts, 44100, 1, 441) // one second
+
+	// Held, then retriggered after the channel is off, then cut by another cue
+	periods := cueTestPeriods([]string{"bell", "bell", "", "bell", "gong", "missing"}, 0, 200, 1500, 2000, 2500, 2600, 4000)
+	r, err := NewAudioRenderer(periods, &AudioRendererOptions{
+		SampleRate: 44100,
+		Volume:     100,
+		Cues:       []t.CueSource{{Name: "bell", Path: bell}, {Name: "gong", Path: bell}},
+	})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	// A cue cut short fades out over the damp time
+	damp := r.frameAt(oneShotDampMs)
+	expected := [][3]int64{{0, 44100, 44100}, {88200, 110250 + damp, 110250}, {110250, 114660 + damp, 114660}}
+	if len(r.oneShots) != len(expected) {
+		ts.Fatalf("expected %d cues, got %d", len(expected), len(r.oneShots))
+	}
+	for i, cue := range r.oneShots {
+		if cue.start != expected[i][0] || cue.end != expected[i][1] || cue.damp != expected[i][2] {
+			ts.Errorf("cue %d: expected frames %v, got [%d %d %d]", i, expected[i], cue.start, cue.end, cue.damp)
+		}
+	}
+}
+
+func TestAudioRenderer_CueCutFades(ts *testing.T) {
+	bell := writeSineWav(ts, 44100, 1, 441)
+	pcm, err := loadCue(t.CueSource{Name: "bell", Path: bell}, 44100)
+	if err != nil {
+		ts.Fatalf("loadCue failed: %v", err)
+	}
+
+	// The bell is cut at 500 ms by a cue without audio
+	periods := cueTestPeriods([]string{"bell", "missing"}, 0, 500, 1000)
+	r, err := NewAudioRenderer(periods, &AudioRendererOptions{
+		SampleRate: 44100,
+		Volume:     100,
+		Cues:       []t.CueSource{{Name: "bell", Path: bell}},
+	})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	var out []int
+	if err := r.Render(func(samples []int) error {
+		out = append(out, samples...)
+		return nil
+	}); err != nil {
+		ts.Fatalf("Render failed: %v", err)
+	}
+
+	const cut = 22050
+	damp := int(r.frameAt(oneShotDampMs))
+	for i := cut - 10; i < len(out)/audioChannels; i++ {
+		want := 0.0
+		switch {
+		case i < cut:
+			want = float64(pcm[2*i])
+		case i < cut+damp:
+			want = float64(pcm[2*i]) * float64(cut+damp-i) / float64(damp)
+		}
+		if math.Abs(float64(out[2*i])-want) > 1 {
+			ts.Fatalf("frame %d: expected %.0f, got %d", i, want, out[2*i])
+		}
+	}
+}
+
+func TestAudioRenderer_CuePlaysOnce(ts *testing.T) {
+	bell := writeSineWav(ts, 44100, 1, 441)
+	pcm, err := loadCue(t.CueSource{Name: "bell", Path: bell}, 44100)
+	if err != nil {
+		ts.Fatalf("loadCue failed: %v", err)
+	}
+
+	// The cue starts inside a buffer
+	periods := cueTestPeriods([]string{"", "bell", "bell"}, 0, 500, 1000, 3000)
+	r, err := NewAudioRenderer(periods, &AudioRendererOptions{
+		SampleRate: 44100,
+		Volume:     100,
+		Cues:       []t.CueSource{{Name: "bell", Path: bell}},
+	})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	var out []int
+	if err := r.Render(func(samples []int) error {
+		out = append(out, samples...)
+		return nil
+	}); err != nil {
+		ts.Fatalf("Render failed: %v", err)
+	}
+
+	// At full amplitude the cue plays back bit for bit
+	const start = 22050
+	for i := range len(out) / audioChannels {
+		want := 0
+		if k := i - start; k >= 0 && k < len(pcm)/audioChannels {
+			want = int(pcm[2*k])
+		}
+		if out[2*i] != want || out[2*i+1] != want {
+			ts.Fatalf("frame %d: expected %d, got %d %d", i, want, out[2*i], out[2*i+1])
+		}
+	}
+}
+
+func TestLoadCue_Errors(ts *testing.T) {
+	if _, err := loadCue(t.CueSource{Name: "bell", Path: filepath.Join(ts.TempDir(), "missing.wav")}, 44100); err == nil ||
+		!strings.Contains(err.Error(), `cue "bell"`) {
+		ts.Errorf("expected an error naming the cue, got %v", err)
+	}
+
+	long := writeRampWav(ts, (maxCueSeconds+1)*rampSampleRate, nil)
+	if _, err := loadCue(t.CueSource{Name: "long", Path: long}, rampSampleRate); err == nil ||
+		!strings.Contains(err.Error(), "too long") {
+		ts.Errorf("expected an error for a long cue, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+const (
+	// ditherSalt separates the dither stream from the noise streams of the same seed
+	ditherSalt = 0xD1D1
+	// ditherHalfStep is half of the output step in the internal resolution
+	ditherHalfStep = 1 << (audioBitShift - 1)
+	// ditherMask keeps a uniform value of one output step
+	ditherMask = 1<<audioBitShift - 1
+	// shapingWarmUpFrames is the length of the mix the noise shaping is run
+	// over before a range starting later than the sequence (8 buffers, about
+	// 0.2 seconds at 44.1 kHz)
+	shapingWarmUpFrames = 8 * t.BufferSize
+)
+
+// shapingState holds the last two requantization errors of an output channel
+type shapingState [2]int
+
+// newDitherStream returns the random stream of the dither for a seed
+func newDitherStream(seed int64) uint64 {
+	return splitmix64(uint64(seed) ^ splitmix64(ditherSalt))
+}
+
+// quantize scales a master bus sample down to the output resolution. Without
+// dither the sample is truncated. TPDF dither adds triangular noise of one
+// output step on each side before rounding, which turns the truncation
+// distortion of quiet signals into a steady noise floor. Shaped dither also
+// feeds back the error of the last two samples, a second-order filter that
+// moves the noise floor away from the low and middle frequencies towards the
+// top of the spectrum. The dither is derived from the seed, the frame and the
+// output channel. The shaping error is carried over the whole render, a range
+// starting later warms it up over the mix right before its start.
+func (r *AudioRenderer) quantize(v int, frame int64, o int, state *shapingState) int {
+	if r.Dither == t.DitherOff {
+		return v >> audioBitShift
+	}
+
+	if r.Dither == t.DitherShaped {
+		// Noise transfer function (1 - z^-1)^2
+		v += 2*state[0] - state[1]
+	}
+
+	q := (v + r.ditherNoise(frame, o) + ditherHalfStep) >> audioBitShift
+
+	if r.Dither == t.DitherShaped {
+		state[1] = state[0]
+		state[0] = v - q<<audioBitShift
+	}
+
+	return q
+}
+
+// ditherNoise returns the triangular dither of an output channel at a frame,
+// the sum of two uniform values of one output step
+func (r *AudioRenderer) ditherNoise(frame int64, o int) int {
+	h := splitmix64(r.ditherStream + uint64(frame*maxOutputChannels+int64(o))*noiseGamma)
+	return int(h&ditherMask) + int(h>>32&ditherMask) - 2*ditherHalfStep
+}
+
+// shapingStart returns the frame a render must mix from to warm the noise
+// shaping up before a frame, a few buffers earlier. Other dithers start at
+// the frame.
+func (r *AudioRenderer) shapingStart(frame int64) int64 {
+	if r.Dither != t.DitherShaped {
+		return frame
+	}
+	return max(frame-shapingWarmUpFrames, 0)
+}
+
+// warmUp mixes and quantizes the buffers between two frames without output,
+// leaving the noise shaping state of a render from the first one, and returns
+// the period reached. The clip statistics of these buffers are left out.
+func (r *AudioRenderer) warmUp(from, to int64, periodIdx int) int {
+	if from == to {
+		return periodIdx
+	}
+
+	stats := r.clipStats
+	samples := make([]int, t.BufferSize*r.outputChannels)
+	for frame := from; frame < to; frame += t.BufferSize {
+		var currentTimeMs int
+		currentTimeMs, periodIdx = r.periodAt(frame, periodIdx)
+		r.sync(currentTimeMs, periodIdx)
+		r.output(r.mix(samples, frame), frame)
+	}
+	r.clipStats = stats
+
+	return periodIdx
+}
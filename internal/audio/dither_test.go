@@ -0,0 +1,174 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// quantizeConstant quantizes a constant level (in output steps) for one second
+// on the first output channel, carrying the shaping over the whole second as
+// a render does
+func quantizeConstant(dither t.DitherType, seed int64, level float64) []int {
+	r := &AudioRenderer{ditherStream: newDitherStream(seed), AudioRendererOptions: &AudioRendererOptions{Dither: dither}}
+
+	v := int(level * (1 << audioBitShift))
+	out := make([]int, 44100)
+	var state shapingState
+	for i := range out {
+		out[i] = r.quantize(v, int64(i), 0, &state)
+	}
+	return out
+}
+
+func TestQuantize_Off(ts *testing.T) {
+	for _, q := range quantizeConstant(t.DitherOff, 1, 0.3) {
+		if q != 0 {
+			ts.Fatalf("expected truncation to 0, got %d", q)
+		}
+	}
+	for _, q := range quantizeConstant(t.DitherOff, 1, -0.3) {
+		if q != -1 {
+			ts.Fatalf("expected truncation to -1, got %d", q)
+		}
+	}
+}
+
+func TestQuantize_TPDF(ts *testing.T) {
+	out := quantizeConstant(t.DitherTPDF, 1, 0.3)
+
+	// Dithered steps average to the level, the noise spans one step on each side
+	sum := 0
+	for _, q := range out {
+		if q < -1 || q > 1 {
+			ts.Fatalf("expected dithered values within one step, got %d", q)
+		}
+		sum += q
+	}
+	if mean := float64(sum) / float64(len(out)); math.Abs(mean-0.3) > 0.01 {
+		ts.Errorf("expected a mean of 0.3, got %.4f", mean)
+	}
+
+	// The dither is reproducible from the seed
+	if !slices.Equal(out, quantizeConstant(t.DitherTPDF, 1, 0.3)) {
+		ts.Errorf("expected the same dither for the same seed")
+	}
+	if slices.Equal(out, quantizeConstant(t.DitherTPDF, 2, 0.3)) {
+		ts.Errorf("expected a different dither for another seed")
+	}
+}
+
+func TestQuantize_ShapedSpectrum(ts *testing.T) {
+	flat := quantizeConstant(t.DitherTPDF, 1, 0.3)
+	shaped := quantizeConstant(t.DitherShaped, 1, 0.3)
+
+	sum := 0
+	for _, q := range shaped {
+		sum += q
+	}
+	if mean := float64(sum) / float64(len(shaped)); math.Abs(mean-0.3) > 0.01 {
+		ts.Errorf("expected a mean of 0.3, got %.4f", mean)
+	}
+
+	// Noise moves from the low and middle frequencies to the top of the spectrum
+	for _, freq := range []float64{100, 500, 1000} {
+		ratio := 10 * math.Log10(goertzelPower(shaped, freq, 44100)/goertzelPower(flat, freq, 44100))
+		if ratio > -15 {
+			ts.Errorf("expected the shaped noise at %.0f Hz at least 15 dB lower, got %.1f dB", freq, ratio)
+		}
+	}
+	if ratio := 10 * math.Log10(goertzelPower(shaped, 20000, 44100)/goertzelPower(flat, 20000, 44100)); ratio < 6 {
+		ts.Errorf("expected the shaped noise at 20 kHz higher, got %.1f dB", ratio)
+	}
+}
+
+func TestAudioRenderer_ShapedDitherWithoutBufferArtifacts(ts *testing.T) {
+	periods := []t.Period{{Time: 0}, {Time: 2000}}
+	r, err := NewAudioRenderer(periods, &AudioRendererOptions{SampleRate: 44100, Volume: 100, Seed: 5, Dither: t.DitherShaped, Workers: 1})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	var out []int
+	if err := r.Render(func(samples []int) error {
+		out = append(out, samples...)
+		return nil
+	}); err != nil {
+		ts.Fatalf("Render failed: %v", err)
+	}
+
+	// The output of silence is the shaped requantization error. The buffer
+	// rate is bin 64 of 65536 points, restarting the shaping with every
+	// buffer would comb the noise floor at its harmonics.
+	const points = 1 << 16
+	const harmonics = 32
+	// Each bin at the buffer rate relative to the mean of its neighbours
+	var relative float64
+	for o := range audioChannels {
+		x := make([]complex128, points)
+		for i := range x {
+			x[i] = complex(float64(out[i*audioChannels+o]), 0)
+		}
+		fft(x)
+
+		power := func(k int) float64 {
+			return real(x[k])*real(x[k]) + imag(x[k])*imag(x[k])
+		}
+		for m := 1; m <= harmonics; m++ {
+			k := m * points / t.BufferSize
+			var around float64
+			for d := 5; d < 25; d++ {
+				around += (power(k-d) + power(k+d)) / 40
+			}
+			relative += power(k) / around / (harmonics * audioChannels)
+		}
+	}
+	if ratio := 10 * math.Log10(relative); math.Abs(ratio) > 3 {
+		ts.Errorf("expected the noise at the buffer rate like the neighbouring bins, got %.1f dB", ratio)
+	}
+}
+
+func TestAudioRenderer_ShapedDitherCarriedOverRender(ts *testing.T) {
+	periods := []t.Period{{Time: 0}, {Time: 4000}}
+
+	// The output of silence is the shaping carried over every buffer and segment
+	var state [maxOutputChannels]shapingState
+	r := &AudioRenderer{ditherStream: newDitherStream(5), AudioRendererOptions: &AudioRendererOptions{Dither: t.DitherShaped}}
+	want := make([]int, 4*44100*audioChannels)
+	for i := range want {
+		frame, o := i/audioChannels, i%audioChannels
+		want[i] = r.quantize(0, int64(frame), o, &state[o])
+	}
+
+	for _, workers := range []int{1, 3} {
+		r, err := NewAudioRenderer(periods, &AudioRendererOptions{SampleRate: 44100, Volume: 100, Seed: 5, Dither: t.DitherShaped, Workers: workers})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+		r.segmentBuffers = 7
+
+		var out []int
+		if err := r.Render(func(samples []int) error {
+			out = append(out, samples...)
+			return nil
+		}); err != nil {
+			ts.Fatalf("Render failed: %v", err)
+		}
+		if !slices.Equal(out, want) {
+			ts.Fatalf("workers %d: expected the shaping carried over the whole render", workers)
+		}
+	}
+}
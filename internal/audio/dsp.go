@@ -0,0 +1,58 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// fft transforms samples in place, their length is a power of two
+func fft(x []complex128) {
+	n := len(x)
+
+	// Bit-reversal permutation
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := range size / 2 {
+				a, b := x[start+k], x[start+k+size/2]*w
+				x[start+k], x[start+k+size/2] = a+b, a-b
+				w *= step
+			}
+		}
+	}
+}
+
+// goertzel returns the magnitude of the spectrum of samples at a frequency,
+// which need not fall on a bin of a DFT of their length
+func goertzel(samples []complex128, freq, sampleRate float64) float64 {
+	w := 2 * math.Pi * freq / sampleRate
+	coeff := complex(2*math.Cos(w), 0)
+	var s1, s2 complex128
+	for _, x := range samples {
+		s1, s2 = x+coeff*s1-s2, s1
+	}
+	return cmplx.Abs(s1 - cmplx.Exp(complex(0, -w))*s2)
+}
@@ -0,0 +1,51 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestFFT(ts *testing.T) {
+	x := make([]complex128, 64)
+	for i := range x {
+		x[i] = complex(math.Cos(2*math.Pi*5*float64(i)/64), 0)
+	}
+	fft(x)
+
+	for k, v := range x {
+		want := 0.0
+		if k == 5 || k == 59 {
+			want = 32
+		}
+		if math.Abs(cmplx.Abs(v)-want) > 1e-9 {
+			ts.Errorf("bin %d: expected %.1f, got %.6f", k, want, cmplx.Abs(v))
+		}
+	}
+}
+
+func TestGoertzel(ts *testing.T) {
+	// A sine between two bins, its amplitude times half the samples
+	x := make([]complex128, 1000)
+	for i := range x {
+		x[i] = complex(0.5*math.Sin(2*math.Pi*123.4*float64(i)/1000), 0)
+	}
+
+	if got := goertzel(x, 123.4, 1000); math.Abs(got-250) > 1 {
+		ts.Errorf("expected a magnitude of 250 at the sine, got %.3f", got)
+	}
+	if got := goertzel(x, 300, 1000); got > 2 {
+		ts.Errorf("expected no magnitude away from the sine, got %.3f", got)
+	}
+}
@@ -12,12 +12,74 @@
 package audio
 
 import (
+	"math"
+
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
 
+// pulseCurveK is the curve constant for exponential envelope edges
+const pulseCurveK = 5.0
+
+// applyEffect applies the spin or pulse effect of a channel to a stereo source
+// already scaled by the channel amplitude, advancing the effect oscillator
+func (r *AudioRenderer) applyEffect(channel *t.Channel, waveIdx int, left, right int) (int, int) {
+	switch channel.Track.Effect.Type {
+	case t.EffectSpin:
+		channel.Offset[0] += channel.Increment[0]
+		channel.Offset[0] &= (t.SineTableSize << 16) - 1
+
+		spinPos := (channel.Increment[1] * r.waveTables[waveIdx][channel.Offset[0]>>16]) >> 24
+
+		effectIntensity := float64(channel.Track.Intensity) * 0.7
+		spinGain := 0.5 + effectIntensity*3.5
+
+		ampSpin := int(float64(spinPos) * spinGain)
+		if ampSpin > 127 {
+			ampSpin = 127
+		}
+		if ampSpin < -128 {
+			ampSpin = -128
+		}
+
+		posVal := ampSpin
+		if posVal < 0 {
+			posVal = -posVal
+		}
+		if posVal > 128 {
+			posVal = 128
+		}
+
+		if ampSpin >= 0 {
+			return (left * (128 - posVal)) >> 7, right + ((left * posVal) >> 7)
+		}
+		return left + ((right * posVal) >> 7), (right * (128 - posVal)) >> 7
+	case t.EffectPulse:
+		// LFO for pulse modulation
+		channel.Offset[1] += channel.Increment[1]
+		channel.Offset[1] &= (t.SineTableSize << 16) - 1
+
+		// 0..1
+		modFactor := r.calcPulseFactor(channel)
+
+		// Mix the effect (0..1) weighted by intensity
+		effectIntensity := float64(channel.Track.Intensity) * 0.7
+		gain := (1.0 - effectIntensity) + (effectIntensity * modFactor)
+
+		return int(float64(left) * gain), int(float64(right) * gain)
+	default:
+		return left, right
+	}
+}
+
 // calcPulseFactor calculates the pulse effect modulation factor for a channel
 func (r *AudioRenderer) calcPulseFactor(channel *t.Channel) float64 {
-	modVal := float64(r.waveTables[int(channel.Track.Waveform)][channel.Offset[1]>>16])
+	envelope := &channel.Track.Envelope
+	if envelope.Shape != t.EnvelopeDefault {
+		phase := float64(channel.Offset[1]>>16) / float64(t.SineTableSize)
+		return calcEnvelope(envelope, phase, channel.Track.Resonance)
+	}
+
+	modVal := float64(r.waveTables[channel.WaveTable][channel.Offset[1]>>16])
 
 	threshold := 0.3 * float64(t.WaveTableAmplitude)
 	den := 0.7 * float64(t.WaveTableAmplitude)
@@ -30,3 +92,48 @@ func (r *AudioRenderer) calcPulseFactor(channel *t.Channel) float64 {
 
 	return modFactor
 }
+
+// calcEnvelope calculates the envelope gain (0..1) at a phase (0..1) of a pulse cycle.
+// The pulse is on for the duty part of the cycle, rising over the attack time and
+// falling over the release time.
+func calcEnvelope(envelope *t.Envelope, phase, rate float64) float64 {
+	duty := float64(envelope.Duty)
+	if phase >= duty {
+		return 0
+	}
+
+	// Attack and release as fractions of the cycle, fitted into the on part
+	attack := envelope.Attack / 1000 * rate
+	release := envelope.Release / 1000 * rate
+	if edges := attack + release; edges > duty {
+		attack *= duty / edges
+		release *= duty / edges
+	}
+
+	switch {
+	case phase < attack:
+		return envelopeEdge(envelope.Shape, phase/attack, true)
+	case phase >= duty-release:
+		return envelopeEdge(envelope.Shape, (duty-phase)/release, false)
+	default:
+		return 1
+	}
+}
+
+// envelopeEdge calculates the gain of a rising (attack) or falling (release) edge,
+// where x goes from 0 (silent) to 1 (full level)
+func envelopeEdge(shape t.EnvelopeShape, x float64, rising bool) float64 {
+	switch shape {
+	case t.EnvelopeSmoothstep:
+		return x * x * (3 - 2*x)
+	case t.EnvelopeRaisedCosine:
+		return 0.5 - 0.5*math.Cos(math.Pi*x)
+	case t.EnvelopeExponential:
+		if rising {
+			return -math.Expm1(-pulseCurveK*x) / -math.Expm1(-pulseCurveK)
+		}
+		return (math.Exp(-pulseCurveK*(1-x)) - math.Exp(-pulseCurveK)) / -math.Expm1(-pulseCurveK)
+	default:
+		return x
+	}
+}
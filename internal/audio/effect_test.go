@@ -0,0 +1,145 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+func TestCalcEnvelope_DutyAndEdges(ts *testing.T) {
+	shapes := []t.EnvelopeShape{t.EnvelopeSquare, t.EnvelopeSmoothstep, t.EnvelopeRaisedCosine, t.EnvelopeExponential}
+
+	for _, shape := range shapes {
+		// 10 Hz pulse: 100 ms cycle, 40 ms on, 10 ms attack, 10 ms release
+		env := &t.Envelope{Shape: shape, Duty: t.DutyPercentToRaw(40), Attack: 10, Release: 10}
+
+		if v := calcEnvelope(env, 0, 10); v != 0 {
+			ts.Errorf("%v: expected 0 at cycle start, got %f", shape, v)
+		}
+		if v := calcEnvelope(env, 0.2, 10); v != 1 {
+			ts.Errorf("%v: expected 1 on plateau, got %f", shape, v)
+		}
+		if v := calcEnvelope(env, 0.5, 10); v != 0 {
+			ts.Errorf("%v: expected 0 after duty, got %f", shape, v)
+		}
+
+		// Attack rises and release falls monotonically
+		prev := -1.0
+		for p := 0.0; p <= 0.1; p += 0.005 {
+			v := calcEnvelope(env, p, 10)
+			if v < prev-1e-12 || v < 0 || v > 1 {
+				ts.Fatalf("%v: attack not monotonic in [0,1] at phase %.3f: %f", shape, p, v)
+			}
+			prev = v
+		}
+		prev = 2.0
+		for p := 0.3; p < 0.4; p += 0.005 {
+			v := calcEnvelope(env, p, 10)
+			if v > prev+1e-12 || v < 0 || v > 1 {
+				ts.Fatalf("%v: release not monotonic in [0,1] at phase %.3f: %f", shape, p, v)
+			}
+			prev = v
+		}
+	}
+}
+
+func TestCalcEnvelope_Shapes(ts *testing.T) {
+	at := func(shape t.EnvelopeShape) float64 {
+		env := &t.Envelope{Shape: shape, Duty: t.DutyPercentToRaw(50), Attack: 100, Release: 0}
+		// 1 Hz pulse, half way through the attack
+		return calcEnvelope(env, 0.05, 1)
+	}
+
+	if v := at(t.EnvelopeSquare); math.Abs(v-0.5) > 1e-9 {
+		ts.Errorf("square: expected linear edge 0.5, got %f", v)
+	}
+	if v := at(t.EnvelopeSmoothstep); math.Abs(v-0.5) > 1e-9 {
+		ts.Errorf("smoothstep: expected 0.5 at mid edge, got %f", v)
+	}
+	if v := at(t.EnvelopeRaisedCosine); math.Abs(v-0.5) > 1e-9 {
+		ts.Errorf("raised-cosine: expected 0.5 at mid edge, got %f", v)
+	}
+	if v := at(t.EnvelopeExponential); v < 0.9 {
+		ts.Errorf("exponential: expected fast rise above 0.9 at mid edge, got %f", v)
+	}
+
+	// Quarter way distinguishes smoothstep from raised-cosine
+	ss := calcEnvelope(&t.Envelope{Shape: t.EnvelopeSmoothstep, Duty: 0.5, Attack: 100}, 0.025, 1)
+	rc := calcEnvelope(&t.Envelope{Shape: t.EnvelopeRaisedCosine, Duty: 0.5, Attack: 100}, 0.025, 1)
+	if math.Abs(ss-0.15625) > 1e-9 || math.Abs(rc-(0.5-0.5*math.Cos(math.Pi/4))) > 1e-9 {
+		ts.Errorf("unexpected quarter edge values: smoothstep=%f raised-cosine=%f", ss, rc)
+	}
+}
+
+func TestCalcEnvelope_EdgesFitDuty(ts *testing.T) {
+	// 200 ms of edges do not fit a 50 ms on time; they are scaled to fill it
+	env := &t.Envelope{Shape: t.EnvelopeSquare, Duty: t.DutyPercentToRaw(50), Attack: 100, Release: 100}
+	if v := calcEnvelope(env, 0.25, 10); math.Abs(v-1) > 1e-9 {
+		ts.Errorf("expected peak at the middle of the on time, got %f", v)
+	}
+	if v := calcEnvelope(env, 0.125, 10); math.Abs(v-0.5) > 1e-9 {
+		ts.Errorf("expected half level a quarter cycle in, got %f", v)
+	}
+}
+
+func TestCalcPulseFactor_DefaultEnvelope(ts *testing.T) {
+	r := &AudioRenderer{waveTables: InitWaveformTables()}
+	ch := &t.Channel{}
+	ch.Track.Waveform = t.WaveformSine
+
+	for _, idx := range []int{0, 1000, t.SineTableSize / 4, t.SineTableSize / 2, 3 * t.SineTableSize / 4} {
+		ch.Offset[1] = idx << 16
+
+		modVal := float64(r.waveTables[t.WaveformSine][idx])
+		want := 0.0
+		if modVal > 0.3*t.WaveTableAmplitude {
+			x := (modVal - 0.3*t.WaveTableAmplitude) / (0.7 * t.WaveTableAmplitude)
+			want = x * x * (3 - 2*x)
+		}
+
+		if got := r.calcPulseFactor(ch); math.Abs(got-want) > 1e-12 {
+			ts.Errorf("default envelope at %d: got %f want %f", idx, got, want)
+		}
+	}
+}
+
+func TestSync_InterpolatesEnvelope(ts *testing.T) {
+	var p0, pEnd t.Period
+	p0.Time = 0
+	p0.TrackStart[0] = t.Track{
+		Type:      t.TrackIsochronicBeat,
+		Carrier:   200,
+		Resonance: 10,
+		Amplitude: t.AmplitudePercentToRaw(20),
+		Envelope:  t.Envelope{Shape: t.EnvelopeRaisedCosine, Duty: t.DutyPercentToRaw(20), Attack: 0, Release: 10},
+	}
+	p0.TrackEnd[0] = p0.TrackStart[0]
+	p0.TrackEnd[0].Envelope = t.Envelope{Shape: t.EnvelopeRaisedCosine, Duty: t.DutyPercentToRaw(60), Attack: 20, Release: 30}
+	pEnd.Time = 1000
+
+	r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{SampleRate: 44100, Volume: 100})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	r.sync(500, 0)
+	got := r.channels[0].Track.Envelope
+	if got.Shape != t.EnvelopeRaisedCosine ||
+		math.Abs(got.Duty.ToPercent()-40) > 1e-9 ||
+		math.Abs(got.Attack-10) > 1e-9 ||
+		math.Abs(got.Release-20) > 1e-9 {
+		ts.Fatalf("unexpected interpolated envelope: %+v", got)
+	}
+}
@@ -0,0 +1,518 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gopxl/beep/v2"
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// flacBitWriter writes big endian bit fields for the test encoder
+type flacBitWriter struct {
+	buf []byte
+	cur uint64
+	n   uint
+}
+
+func (bw *flacBitWriter) writeBits(v uint64, n uint) {
+	for i := int(n) - 1; i >= 0; i-- {
+		bw.cur = bw.cur<<1 | v>>uint(i)&1
+		bw.n++
+		if bw.n == 8 {
+			bw.buf = append(bw.buf, byte(bw.cur))
+			bw.cur, bw.n = 0, 0
+		}
+	}
+}
+
+func (bw *flacBitWriter) writeSigned(v int32, n int) {
+	bw.writeBits(uint64(v)&(1<<uint(n)-1), uint(n))
+}
+
+func (bw *flacBitWriter) align() {
+	for bw.n != 0 {
+		bw.writeBits(0, 1)
+	}
+}
+
+// CRC tables of the frame header (polynomial 0x07) and frame (polynomial
+// 0x8005) for the test encoder
+var (
+	crc8Table  = makeCRC8Table(0x07)
+	crc16Table = makeCRC16Table(0x8005)
+)
+
+func makeCRC8Table(poly uint8) (table [256]uint8) {
+	for i := range table {
+		crc := uint8(i)
+		for range 8 {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ poly
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}
+
+func makeCRC16Table(poly uint16) (table [256]uint16) {
+	for i := range table {
+		crc := uint16(i) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ poly
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}
+
+// flacTestFrame selects how the test encoder writes a frame
+type flacTestFrame struct {
+	size        int
+	channelCode int
+	kind        string // constant, verbatim, fixed or lpc
+}
+
+// lpcTestCoefficients predict 2x[n-1] - x[n-2] in Q10
+var lpcTestCoefficients = []int32{2048, -1024}
+
+const lpcTestShift = 10
+
+// writeResidual writes the Rice coded residual of samples after order, using
+// 5 bit Rice parameters when 4 bits are not enough
+func writeResidual(bw *flacBitWriter, samples []int32, order int, predict func(i int) int32) {
+	partitionOrder := 0
+	if len(samples)%2 == 0 && len(samples)/2 >= order {
+		partitionOrder = 1
+	}
+
+	partitions := make([][]uint32, 1<<partitionOrder)
+	params := make([]int, len(partitions))
+	method, paramBits := uint64(0), uint(4)
+	i := order
+	for p := range partitions {
+		count := len(samples) / len(partitions)
+		if p == 0 {
+			count -= order
+		}
+
+		var maxCode uint32
+		for range count {
+			r := samples[i] - predict(i)
+			u := uint32(r<<1) ^ uint32(r>>31)
+			partitions[p] = append(partitions[p], u)
+			maxCode = max(maxCode, u)
+			i++
+		}
+		params[p] = max(0, bits.Len32(maxCode)-2)
+		if params[p] > 14 {
+			method, paramBits = 1, 5
+		}
+	}
+
+	bw.writeBits(method, 2)
+	bw.writeBits(uint64(partitionOrder), 4)
+	for p, codes := range partitions {
+		param := uint(params[p])
+		bw.writeBits(uint64(param), paramBits)
+		for _, u := range codes {
+			for q := u >> param; q > 0; q-- {
+				bw.writeBits(0, 1)
+			}
+			bw.writeBits(1, 1)
+			bw.writeBits(uint64(u), param)
+		}
+	}
+}
+
+// writeSubframe writes one channel of a frame
+func writeSubframe(bw *flacBitWriter, samples []int32, sampleBits int, kind string) {
+	switch kind {
+	case "constant":
+		bw.writeBits(0, 8)
+		bw.writeSigned(samples[0], sampleBits)
+	case "verbatim":
+		bw.writeBits(1<<1, 8)
+		for _, v := range samples {
+			bw.writeSigned(v, sampleBits)
+		}
+	case "fixed":
+		coefs := []int32{2, -1}
+		bw.writeBits(uint64(8+2)<<1, 8)
+		bw.writeSigned(samples[0], sampleBits)
+		bw.writeSigned(samples[1], sampleBits)
+		writeResidual(bw, samples, 2, func(i int) int32 {
+			return coefs[0]*samples[i-1] + coefs[1]*samples[i-2]
+		})
+	case "lpc":
+		order := len(lpcTestCoefficients)
+		bw.writeBits(uint64(32+order-1)<<1, 8)
+		for i := range order {
+			bw.writeSigned(samples[i], sampleBits)
+		}
+		bw.writeBits(15-1, 4)
+		bw.writeSigned(lpcTestShift, 5)
+		for _, c := range lpcTestCoefficients {
+			bw.writeSigned(c, 15)
+		}
+		writeResidual(bw, samples, order, func(i int) int32 {
+			var sum int64
+			for j, c := range lpcTestCoefficients {
+				sum += int64(c) * int64(samples[i-j-1])
+			}
+			return int32(sum >> lpcTestShift)
+		})
+	}
+}
+
+// encodeTestFLAC encodes channels of samples as a FLAC stream
+func encodeTestFLAC(ts *testing.T, channels [][]int32, sampleRate, sampleBits int, frames []flacTestFrame, storeTotal bool) []byte {
+	ts.Helper()
+
+	total := len(channels[0])
+	out := []byte("fLaC")
+
+	// Every frame but the last has the largest block size
+	blockSize := 16
+	for _, frame := range frames {
+		blockSize = max(blockSize, frame.size)
+	}
+
+	// STREAMINFO, followed by a PADDING block that must be skipped
+	info := &flacBitWriter{}
+	info.writeBits(16, 16)
+	info.writeBits(uint64(blockSize), 16)
+	info.writeBits(0, 24)
+	info.writeBits(0, 24)
+	info.writeBits(uint64(sampleRate), 20)
+	info.writeBits(uint64(len(channels)-1), 3)
+	info.writeBits(uint64(sampleBits-1), 5)
+	if storeTotal {
+		info.writeBits(uint64(total), 36)
+	} else {
+		info.writeBits(0, 36)
+	}
+	info.buf = append(info.buf, make([]byte, 16)...)
+	out = append(out, 0x00, 0, 0, byte(len(info.buf)))
+	out = append(out, info.buf...)
+	out = append(out, 0x81, 0, 0, 4, 0, 0, 0, 0)
+
+	sizeCodes := map[int]uint64{8: 1, 12: 2, 16: 4, 20: 5, 24: 6, 32: 7}
+
+	pos := 0
+	for index, frame := range frames {
+		bw := &flacBitWriter{}
+		bw.writeBits(0x3FFE, 14)
+		bw.writeBits(0, 2)
+		bw.writeBits(7, 4) // 16 bit block size after the header
+		bw.writeBits(0, 4) // sample rate from STREAMINFO
+		bw.writeBits(uint64(frame.channelCode), 4)
+		bw.writeBits(sizeCodes[sampleBits], 3)
+		bw.writeBits(0, 1)
+		bw.writeBits(uint64(index), 8)
+		bw.writeBits(uint64(frame.size-1), 16)
+
+		var crc8 uint8
+		for _, b := range bw.buf {
+			crc8 = crc8Table[crc8^b]
+		}
+		bw.writeBits(uint64(crc8), 8)
+
+		block := make([][]int32, len(channels))
+		for ch := range channels {
+			block[ch] = channels[ch][pos : pos+frame.size]
+		}
+		subframes := block
+		if frame.channelCode >= 8 {
+			left, right := block[0], block[1]
+			side := make([]int32, frame.size)
+			mid := make([]int32, frame.size)
+			for i := range side {
+				side[i] = left[i] - right[i]
+				mid[i] = (left[i] + right[i]) >> 1
+			}
+			switch frame.channelCode {
+			case 8:
+				subframes = [][]int32{left, side}
+			case 9:
+				subframes = [][]int32{side, right}
+			case 10:
+				subframes = [][]int32{mid, side}
+			}
+		}
+
+		for ch, samples := range subframes {
+			chBits := sampleBits
+			if (frame.channelCode == 8 && ch == 1) || (frame.channelCode == 9 && ch == 0) || (frame.channelCode == 10 && ch == 1) {
+				chBits++
+			}
+			writeSubframe(bw, samples, chBits, frame.kind)
+		}
+
+		bw.align()
+		var crc16 uint16
+		for _, b := range bw.buf {
+			crc16 = crc16<<8 ^ crc16Table[byte(crc16>>8)^b]
+		}
+		bw.writeBits(uint64(crc16), 16)
+
+		out = append(out, bw.buf...)
+		pos += frame.size
+	}
+
+	if pos != total {
+		ts.Fatalf("test frames cover %d samples, expected %d", pos, total)
+	}
+	return out
+}
+
+// testFlacSignal returns a stereo signal with different left and right
+func testFlacSignal(frames int, amplitude float64) [][]int32 {
+	left := make([]int32, frames)
+	right := make([]int32, frames)
+	for i := range frames {
+		left[i] = int32(amplitude * math.Sin(2*math.Pi*float64(i)/50))
+		right[i] = int32(amplitude*math.Sin(2*math.Pi*float64(i)/33)) + int32(i%7) - 3
+	}
+	return [][]int32{left, right}
+}
+
+func readFlacFrames(ts *testing.T, d beep.Streamer, frames int) [][2]float64 {
+	ts.Helper()
+	out := make([][2]float64, frames)
+	n, _ := d.Stream(out)
+	if err := d.Err(); err != nil {
+		ts.Fatalf("unexpected stream error: %v", err)
+	}
+	return out[:n]
+}
+
+func checkFlacFrames(ts *testing.T, got [][2]float64, channels [][]int32, from, sampleBits int) {
+	ts.Helper()
+	scale := float64(int64(1) << (sampleBits - 1))
+	right := min(1, len(channels)-1)
+	for i, frame := range got {
+		wantL := channels[0][from+i]
+		wantR := channels[right][from+i]
+		if int32(frame[0]*scale) != wantL || int32(frame[1]*scale) != wantR {
+			ts.Fatalf("frame %d: got (%v, %v), expected (%d, %d)", from+i, frame[0]*scale, frame[1]*scale, wantL, wantR)
+		}
+	}
+}
+
+func TestFlacCRC(ts *testing.T) {
+	var crc8 uint8
+	var crc16 uint16
+	for _, b := range []byte("123456789") {
+		crc8 = crc8Table[crc8^b]
+		crc16 = crc16<<8 ^ crc16Table[byte(crc16>>8)^b]
+	}
+	if crc8 != 0xF4 {
+		ts.Errorf("expected CRC-8 0xF4, got %#x", crc8)
+	}
+	if crc16 != 0xFEE8 {
+		ts.Errorf("expected CRC-16 0xFEE8, got %#x", crc16)
+	}
+}
+
+func TestDecodeFLAC_StereoModes(ts *testing.T) {
+	channels := testFlacSignal(64*5+37, 12000)
+	frames := []flacTestFrame{
+		{size: 64, channelCode: 1, kind: "verbatim"},
+		{size: 64, channelCode: 8, kind: "fixed"},
+		{size: 64, channelCode: 9, kind: "lpc"},
+		{size: 64, channelCode: 10, kind: "fixed"},
+		{size: 64, channelCode: 10, kind: "lpc"},
+		{size: 37, channelCode: 1, kind: "fixed"},
+	}
+	data := encodeTestFLAC(ts, channels, 22050, 16, frames, true)
+
+	s, format, err := decodeFLAC(bytes.NewReader(data), true)
+	if err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	defer s.Close()
+
+	if format.SampleRate != 22050 || format.NumChannels != 2 || format.Precision != 2 {
+		ts.Fatalf("unexpected format: %+v", format)
+	}
+	if s.Len() != len(channels[0]) {
+		ts.Fatalf("expected %d frames, got %d", len(channels[0]), s.Len())
+	}
+
+	got := readFlacFrames(ts, s, len(channels[0])+10)
+	if len(got) != len(channels[0]) {
+		ts.Fatalf("expected %d decoded frames, got %d", len(channels[0]), len(got))
+	}
+	checkFlacFrames(ts, got, channels, 0, 16)
+
+	// Seeking back decodes again from the first frame
+	if err := s.Seek(150); err != nil {
+		ts.Fatalf("unexpected seek error: %v", err)
+	}
+	if s.Position() != 150 {
+		ts.Fatalf("expected position 150, got %d", s.Position())
+	}
+	checkFlacFrames(ts, readFlacFrames(ts, s, 100), channels, 150, 16)
+
+	// The last frame is shorter than the block size
+	if err := s.Seek(64*5 + 10); err != nil {
+		ts.Fatalf("unexpected seek error: %v", err)
+	}
+	checkFlacFrames(ts, readFlacFrames(ts, s, 27), channels, 64*5+10, 16)
+}
+
+func TestDecodeFLAC_Mono24BitWithoutTotal(ts *testing.T) {
+	mono := testFlacSignal(96, 3000000)[:1]
+	frames := []flacTestFrame{
+		{size: 32, channelCode: 0, kind: "lpc"},
+		{size: 32, channelCode: 0, kind: "constant"},
+		{size: 32, channelCode: 0, kind: "verbatim"},
+	}
+	for i := 32; i < 64; i++ {
+		mono[0][i] = -42
+	}
+	data := encodeTestFLAC(ts, mono, 48000, 24, frames, false)
+
+	// Local files are spooled to a temporary file, removed on Close
+	path := filepath.Join(ts.TempDir(), "mono.flac")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		ts.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		ts.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		reader   io.ReadSeeker
+		inMemory bool
+	}{
+		{"memory", bytes.NewReader(data), true},
+		{"file", file, false},
+	} {
+		s, format, err := decodeFLAC(tc.reader, tc.inMemory)
+		if err != nil {
+			ts.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+
+		if format.NumChannels != 1 || format.Precision != 3 {
+			ts.Fatalf("%s: unexpected format: %+v", tc.name, format)
+		}
+		if s.Len() != 96 {
+			ts.Fatalf("%s: expected the decoded frames to be counted as 96, got %d", tc.name, s.Len())
+		}
+
+		// Mono is duplicated to both sides
+		checkFlacFrames(ts, readFlacFrames(ts, s, 96), mono, 0, 24)
+
+		spooled, isSpooled := s.(*spooledStreamer)
+		if isSpooled != !tc.inMemory {
+			ts.Fatalf("%s: expected spooling %v, got %T", tc.name, !tc.inMemory, s)
+		}
+		if err := s.Close(); err != nil {
+			ts.Fatalf("%s: unexpected close error: %v", tc.name, err)
+		}
+		if isSpooled {
+			if _, err := os.Stat(spooled.path); !os.IsNotExist(err) {
+				ts.Fatalf("expected the spooled file to be removed on close, got %v", err)
+			}
+		}
+	}
+}
+
+func TestDecodeFLAC_CorruptFrame(ts *testing.T) {
+	channels := testFlacSignal(64, 12000)
+	data := encodeTestFLAC(ts, channels, 22050, 16, []flacTestFrame{{size: 64, channelCode: 10, kind: "fixed"}}, true)
+	data[len(data)-5] ^= 0xFF
+
+	// The first frame is decoded on open
+	_, _, err := decodeFLAC(bytes.NewReader(data), true)
+	if err == nil || !strings.Contains(err.Error(), "CRC") {
+		ts.Fatalf("expected CRC error, got %v", err)
+	}
+}
+
+func TestBackgroundAudio_FLAC(ts *testing.T) {
+	channels := testFlacSignal(128, 12000)
+	frames := []flacTestFrame{
+		{size: 64, channelCode: 10, kind: "lpc"},
+		{size: 64, channelCode: 8, kind: "fixed"},
+	}
+	path := filepath.Join(ts.TempDir(), "ambience.flac")
+	if err := os.WriteFile(path, encodeTestFLAC(ts, channels, 44100, 16, frames, true), 0o644); err != nil {
+		ts.Fatalf("write flac: %v", err)
+	}
+
+	bg, err := NewBackgroundAudio(path)
+	if err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	defer bg.Close()
+	if err := bg.conform(44100, t.BackgroundSource{Path: path}); err != nil {
+		ts.Fatalf("unexpected conform error: %v", err)
+	}
+
+	// Reading past the end loops back to the start
+	out := make([]int, 200*audioChannels)
+	if n, err := bg.ReadSamples(out, len(out)); err != nil || n != len(out) {
+		ts.Fatalf("expected %d samples, got %d (err %v)", len(out), n, err)
+	}
+	for i := range 200 {
+		frame := i % 128
+		if out[i*2] != int(channels[0][frame]) || out[i*2+1] != int(channels[1][frame]) {
+			ts.Fatalf("frame %d: got (%d, %d), expected (%d, %d)", i, out[i*2], out[i*2+1], channels[0][frame], channels[1][frame])
+		}
+	}
+}
+
+func TestBackgroundAudio_InvalidFiles(ts *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{"broken-id3.mp3", append([]byte("ID3\x04\x00\x00"), make([]byte, 32)...), "mp3"},
+		{"broken-sync.mp3", append([]byte{0xFF, 0xFB, 0x90, 0x64}, make([]byte, 32)...), "mp3"},
+		{"broken.ogg", append([]byte("OggS"), make([]byte, 32)...), "ogg/vorbis"},
+		{"broken.flac", []byte("fLaC\x00\x00"), "flac"},
+		{"notes.txt", []byte("not audio at all"), "unsupported background audio format"},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(ts.TempDir(), tt.name)
+		if err := os.WriteFile(path, tt.data, 0o644); err != nil {
+			ts.Fatalf("write %s: %v", tt.name, err)
+		}
+
+		_, err := NewBackgroundAudio(path)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			ts.Errorf("%s: expected error containing %q, got %v", tt.name, tt.expected, err)
+		}
+	}
+}
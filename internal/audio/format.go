@@ -0,0 +1,279 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/flac"
+	"github.com/gopxl/beep/v2/mp3"
+	"github.com/gopxl/beep/v2/vorbis"
+	bwav "github.com/gopxl/beep/v2/wav"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// backgroundFormat represents the detected format of a background file
+type backgroundFormat int
+
+const (
+	backgroundUnknown backgroundFormat = iota
+	backgroundWAV
+	backgroundFLAC
+	backgroundOgg
+	backgroundMP3
+)
+
+// sniffBackgroundFormat detects the format of a background file from its
+// first bytes, leaving the reader at the start of the file
+func sniffBackgroundFormat(r io.ReadSeeker) (backgroundFormat, error) {
+	var header [12]byte
+	n, err := io.ReadFull(r, header[:])
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return backgroundUnknown, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return backgroundUnknown, err
+	}
+
+	h := header[:n]
+	switch {
+	case len(h) >= 12 && bytes.Equal(h[0:4], []byte("RIFF")) && bytes.Equal(h[8:12], []byte("WAVE")):
+		return backgroundWAV, nil
+	case bytes.HasPrefix(h, []byte("fLaC")):
+		return backgroundFLAC, nil
+	case bytes.HasPrefix(h, []byte("OggS")):
+		return backgroundOgg, nil
+	case bytes.HasPrefix(h, []byte("ID3")):
+		return backgroundMP3, nil
+	case len(h) >= 2 && h[0] == 0xFF && h[1]&0xE0 == 0xE0:
+		// MPEG audio frame sync
+		return backgroundMP3, nil
+	default:
+		return backgroundUnknown, nil
+	}
+}
+
+// remoteFileFormat returns the file format a remote audio file is fetched as,
+// from the extension of its URL path. FLAC, Ogg Vorbis and MP3 files have
+// the size limit of audio files, the others the one of WAV files.
+func remoteFileFormat(rawURL string) t.FileFormat {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return t.FormatWAV
+	}
+
+	switch strings.ToLower(path.Ext(u.Path)) {
+	case ".flac", ".ogg", ".oga", ".mp3":
+		return t.FormatAudio
+	default:
+		return t.FormatWAV
+	}
+}
+
+// decodeFLAC decodes FLAC audio, closing the reader on error. Streams that
+// don't store their total length are made seekable by decoding them once:
+// into memory when the encoded data is already held there, and otherwise
+// into a temporary WAV file, so local files of any length keep memory use
+// bounded.
+func decodeFLAC(reader io.ReadSeeker, inMemory bool) (beep.StreamSeekCloser, beep.Format, error) {
+	s, f, err := flac.Decode(readSeekCloser{reader})
+	if err != nil {
+		return nil, beep.Format{}, err
+	}
+	if s.Len() > 0 {
+		return s, f, nil
+	}
+	if !inMemory {
+		return spoolFLAC(s, f)
+	}
+
+	defer s.Close()
+	buffer := beep.NewBuffer(f)
+	buffer.Append(s)
+	if err := s.Err(); err != nil {
+		return nil, beep.Format{}, err
+	}
+	if buffer.Len() == 0 {
+		return nil, beep.Format{}, fmt.Errorf("flac: stream has no samples")
+	}
+	return nopCloser{buffer.Streamer(0, buffer.Len())}, f, nil
+}
+
+// spoolFLAC decodes a FLAC stream into a temporary WAV file and streams it
+// from there, closing the FLAC stream. The file is removed on Close.
+func spoolFLAC(s beep.StreamSeekCloser, f beep.Format) (beep.StreamSeekCloser, beep.Format, error) {
+	defer s.Close()
+
+	file, err := os.CreateTemp("", "synapseq-background-*.wav")
+	if err != nil {
+		return nil, beep.Format{}, err
+	}
+	fail := func(err error) (beep.StreamSeekCloser, beep.Format, error) {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return nil, beep.Format{}, err
+	}
+
+	// WAV stores at most 24-bit integer samples
+	wavFormat := f
+	wavFormat.Precision = min(max(f.Precision, 1), 3)
+	if err := bwav.Encode(file, s, wavFormat); err != nil {
+		return fail(err)
+	}
+	if err := s.Err(); err != nil {
+		return fail(err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fail(err)
+	}
+
+	spooled, _, err := bwav.Decode(file)
+	if err != nil {
+		return fail(err)
+	}
+	if spooled.Len() == 0 {
+		_ = spooled.Close()
+		_ = os.Remove(file.Name())
+		return nil, beep.Format{}, fmt.Errorf("flac: stream has no samples")
+	}
+	return &spooledStreamer{StreamSeekCloser: spooled, path: file.Name()}, f, nil
+}
+
+// decodeMP3 decodes MP3 audio, closing the reader on error
+func decodeMP3(reader io.ReadSeeker) (beep.StreamSeekCloser, beep.Format, error) {
+	s, f, err := mp3.Decode(readSeekCloser{reader})
+	if err != nil {
+		closeReader(reader)
+		return nil, beep.Format{}, err
+	}
+	if s.Len() <= 0 {
+		_ = s.Close()
+		return nil, beep.Format{}, fmt.Errorf("mp3: stream has no samples")
+	}
+	return s, f, nil
+}
+
+// decodeOgg decodes Ogg Vorbis audio, closing the reader on error. The Ogg
+// reader panics on some malformed streams, which is reported as an error
+// both when opening and when streaming.
+func decodeOgg(reader io.ReadSeeker) (s beep.StreamSeekCloser, f beep.Format, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s, err = nil, fmt.Errorf("ogg/vorbis: malformed stream: %v", r)
+		}
+		if err != nil {
+			closeReader(reader)
+		}
+	}()
+
+	s, f, err = vorbis.Decode(readSeekCloser{reader})
+	if err != nil {
+		return nil, beep.Format{}, err
+	}
+	return &guardedStreamer{StreamSeekCloser: s}, f, nil
+}
+
+// guardedStreamer reports the panics of a decoder while streaming or
+// seeking as an error, ending the stream
+type guardedStreamer struct {
+	beep.StreamSeekCloser
+	err error
+}
+
+// Stream streams samples from the decoder, stopping at a panic
+func (g *guardedStreamer) Stream(samples [][2]float64) (n int, ok bool) {
+	if g.err != nil {
+		return 0, false
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			g.err = fmt.Errorf("ogg/vorbis: malformed stream: %v", r)
+			n, ok = 0, false
+		}
+	}()
+	return g.StreamSeekCloser.Stream(samples)
+}
+
+// Seek seeks the decoder, returning a panic as an error
+func (g *guardedStreamer) Seek(p int) (err error) {
+	if g.err != nil {
+		return g.err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			g.err = fmt.Errorf("ogg/vorbis: malformed stream: %v", r)
+			err = g.err
+		}
+	}()
+	return g.StreamSeekCloser.Seek(p)
+}
+
+// Err returns the error of a panic, or else the error of the decoder
+func (g *guardedStreamer) Err() error {
+	if g.err != nil {
+		return g.err
+	}
+	return g.StreamSeekCloser.Err()
+}
+
+// nopCloser adds a Close method that does nothing to an in-memory streamer
+type nopCloser struct {
+	beep.StreamSeeker
+}
+
+// Close does nothing, the samples are held in memory
+func (nopCloser) Close() error {
+	return nil
+}
+
+// spooledStreamer streams audio from a temporary file, removing the file
+// when closed
+type spooledStreamer struct {
+	beep.StreamSeekCloser
+	path string
+}
+
+// Close closes the decoder and removes the temporary file
+func (s *spooledStreamer) Close() error {
+	err := s.StreamSeekCloser.Close()
+	if removeErr := os.Remove(s.path); err == nil && !os.IsNotExist(removeErr) {
+		err = removeErr
+	}
+	return err
+}
+
+// readSeekCloser adds a Close method to a reader that may not have one,
+// keeping it seekable
+type readSeekCloser struct {
+	io.ReadSeeker
+}
+
+// Close closes the underlying reader, if it can be closed
+func (rsc readSeekCloser) Close() error {
+	return closeReader(rsc.ReadSeeker)
+}
+
+// closeReader closes a reader if it can be closed
+func closeReader(r io.Reader) error {
+	if closer, ok := r.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
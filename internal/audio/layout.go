@@ -0,0 +1,112 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// maxOutputChannels is the number of output channels of the largest layout (5.1)
+const maxOutputChannels = 6
+
+// speakers holds the output channel of each speaker position (-1 when absent)
+type speakers struct {
+	frontLeft, frontRight int
+	center, lfe           int
+	rearLeft, rearRight   int
+}
+
+// speakersOf returns the output channels of the speakers of a layout,
+// in the order of the WAVE_FORMAT_EXTENSIBLE channel mask
+func speakersOf(layout t.ChannelLayout) speakers {
+	switch layout {
+	case t.LayoutQuad:
+		return speakers{frontLeft: 0, frontRight: 1, center: -1, lfe: -1, rearLeft: 2, rearRight: 3}
+	case t.Layout51:
+		return speakers{frontLeft: 0, frontRight: 1, center: 2, lfe: 3, rearLeft: 4, rearRight: 5}
+	default:
+		return speakers{frontLeft: 0, frontRight: 1, center: -1, lfe: -1, rearLeft: -1, rearRight: -1}
+	}
+}
+
+// speakerRouting holds the output channels fed by the left, right and
+// mono (left and right summed) signal of a track
+type speakerRouting struct {
+	left, right, mono []int
+}
+
+// newSpeakerRouting returns the output channels of a route. Speakers the layout
+// does not have fold into the front speakers, so any route renders in stereo.
+func newSpeakerRouting(layout t.ChannelLayout, route t.RouteType) speakerRouting {
+	sp := speakersOf(layout)
+	front := speakerRouting{left: []int{sp.frontLeft}, right: []int{sp.frontRight}}
+
+	switch route {
+	case t.RouteRear:
+		if sp.rearLeft >= 0 {
+			return speakerRouting{left: []int{sp.rearLeft}, right: []int{sp.rearRight}}
+		}
+	case t.RouteAll:
+		if sp.rearLeft >= 0 {
+			return speakerRouting{left: []int{sp.frontLeft, sp.rearLeft}, right: []int{sp.frontRight, sp.rearRight}}
+		}
+	case t.RouteCenter:
+		if sp.center >= 0 {
+			return speakerRouting{mono: []int{sp.center}}
+		}
+	case t.RouteLFE:
+		if sp.lfe >= 0 {
+			return speakerRouting{mono: []int{sp.lfe}}
+		}
+	}
+
+	return front
+}
+
+// route adds the left and right signal of a track to the output bus
+func (sr *speakerRouting) route(bus *[maxOutputChannels]int, left, right int) {
+	for _, o := range sr.left {
+		bus[o] += left
+	}
+	for _, o := range sr.right {
+		bus[o] += right
+	}
+	if len(sr.mono) > 0 {
+		mono := (left + right) / 2
+		for _, o := range sr.mono {
+			bus[o] += mono
+		}
+	}
+}
+
+// layoutBalanceGains returns the ear balance gain of every output channel,
+// center and low frequency speakers are not affected
+func layoutBalanceGains(layout t.ChannelLayout, balance t.BalanceType) []float64 {
+	balanceLeft, balanceRight := calcBalanceGains(balance)
+	sp := speakersOf(layout)
+
+	gains := make([]float64, layout.Channels())
+	for o := range gains {
+		gains[o] = 1
+	}
+	for _, o := range []int{sp.frontLeft, sp.rearLeft} {
+		if o >= 0 {
+			gains[o] = balanceLeft
+		}
+	}
+	for _, o := range []int{sp.frontRight, sp.rearRight} {
+		if o >= 0 {
+			gains[o] = balanceRight
+		}
+	}
+	return gains
+}
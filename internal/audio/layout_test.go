@@ -0,0 +1,113 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+func TestSpeakerRouting(ts *testing.T) {
+	tests := []struct {
+		name   string
+		layout t.ChannelLayout
+		route  t.RouteType
+		want   [maxOutputChannels]int
+	}{
+		{"stereo front", t.LayoutStereo, t.RouteFront, [maxOutputChannels]int{100, 300}},
+		{"stereo rear folds", t.LayoutStereo, t.RouteRear, [maxOutputChannels]int{100, 300}},
+		{"quad rear", t.LayoutQuad, t.RouteRear, [maxOutputChannels]int{0, 0, 100, 300}},
+		{"quad all", t.LayoutQuad, t.RouteAll, [maxOutputChannels]int{100, 300, 100, 300}},
+		{"quad center folds", t.LayoutQuad, t.RouteCenter, [maxOutputChannels]int{100, 300}},
+		{"5.1 center", t.Layout51, t.RouteCenter, [maxOutputChannels]int{0, 0, 200}},
+		{"5.1 lfe", t.Layout51, t.RouteLFE, [maxOutputChannels]int{0, 0, 0, 200}},
+		{"5.1 rear", t.Layout51, t.RouteRear, [maxOutputChannels]int{0, 0, 0, 0, 100, 300}},
+	}
+
+	for _, tt := range tests {
+		var bus [maxOutputChannels]int
+		sr := newSpeakerRouting(tt.layout, tt.route)
+		sr.route(&bus, 100, 300)
+		if bus != tt.want {
+			ts.Errorf("%s: expected %v, got %v", tt.name, tt.want, bus)
+		}
+	}
+}
+
+func TestLayoutBalanceGains(ts *testing.T) {
+	gains := layoutBalanceGains(t.Layout51, t.BalancePercentToRaw(-100))
+	left, right := calcBalanceGains(t.BalancePercentToRaw(-100))
+
+	want := []float64{left, right, 1, 1, left, right}
+	for o := range want {
+		if gains[o] != want[o] {
+			ts.Errorf("channel %d: expected gain %f, got %f", o, want[o], gains[o])
+		}
+	}
+}
+
+func TestAudioRenderer_QuadRearRoute(ts *testing.T) {
+	var p0, pEnd t.Period
+	p0.TrackStart[0] = t.Track{
+		Type:      t.TrackPureTone,
+		Carrier:   300,
+		Amplitude: t.AmplitudePercentToRaw(30),
+		Waveform:  t.WaveformSine,
+		Route:     t.RouteRear,
+	}
+	p0.TrackEnd = p0.TrackStart
+	pEnd.Time = 1000
+
+	r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{
+		SampleRate: 44100,
+		Volume:     100,
+		Layout:     t.LayoutQuad,
+	})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	var peaks [4]int
+	frames := 0
+	err = r.Render(func(samples []int) error {
+		if len(samples)%4 != 0 {
+			ts.Fatalf("expected whole quad frames, got %d samples", len(samples))
+		}
+		for i, v := range samples {
+			peaks[i%4] = max(peaks[i%4], v, -v)
+		}
+		frames += len(samples) / 4
+		return nil
+	})
+	if err != nil {
+		ts.Fatalf("Render failed: %v", err)
+	}
+
+	if frames != 44100 {
+		ts.Errorf("expected 44100 frames, got %d", frames)
+	}
+	if peaks[0] != 0 || peaks[1] != 0 {
+		ts.Errorf("expected silent front speakers, got peaks %v", peaks)
+	}
+	if peaks[2] == 0 || peaks[3] == 0 {
+		ts.Errorf("expected sound on the rear speakers, got peaks %v", peaks)
+	}
+
+	if _, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{
+		SampleRate: 44100,
+		Volume:     100,
+		Layout:     t.ChannelLayout(99),
+	}); err == nil {
+		ts.Errorf("expected error for an invalid layout")
+	}
+}
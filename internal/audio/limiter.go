@@ -0,0 +1,87 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+const (
+	// limiterKnee is where the soft limiter starts compressing (-2 dBFS),
+	// close to the ceiling so peaks below it pass through unchanged
+	limiterKnee = 0.7943282347242815
+	// limiterCeiling is the level the soft limiter never reaches (-1 dBFS),
+	// leaving headroom for inter-sample peaks
+	limiterCeiling = 0.8912509381337456
+)
+
+// ClipStats holds the samples that exceeded full scale on the master bus
+type ClipStats struct {
+	// Number of samples (per channel) above full scale
+	Samples int64
+	// Highest peak above full scale, in dB
+	MaxOvershoot float64
+	// Peaks were softly limited instead of hard clipped
+	Limited bool
+}
+
+// Clipped checks if any sample exceeded full scale
+func (cs ClipStats) Clipped() bool {
+	return cs.Samples > 0
+}
+
+// record counts a sample that may exceed full scale
+func (cs *ClipStats) record(v int) {
+	if v <= audioMaxValue && v >= audioMinValue {
+		return
+	}
+
+	cs.Samples++
+	peak := math.Abs(float64(v)) / audioMaxValue
+	if over := 20 * math.Log10(peak); over > cs.MaxOvershoot {
+		cs.MaxOvershoot = over
+	}
+}
+
+// softLimit compresses a sample above the knee smoothly towards the ceiling,
+// so it never reaches full scale
+func softLimit(v int) int {
+	x := float64(v) / (audioMaxValue + 1)
+	mag := math.Abs(x)
+	if mag <= limiterKnee {
+		return v
+	}
+
+	// Unity slope at the knee, approaching the ceiling asymptotically
+	span := limiterCeiling - limiterKnee
+	limited := limiterKnee + span*math.Tanh((mag-limiterKnee)/span)
+	return int(math.Copysign(limited, x) * (audioMaxValue + 1))
+}
+
+// master applies the limiter and clipping of the master bus to a sample
+func (r *AudioRenderer) master(v int) int {
+	r.clipStats.record(v)
+
+	if r.Limiter == t.LimiterSoft {
+		return softLimit(v)
+	}
+
+	if v > audioMaxValue {
+		return audioMaxValue
+	}
+	if v < audioMinValue {
+		return audioMinValue
+	}
+	return v
+}
@@ -0,0 +1,152 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+func TestSoftLimit(ts *testing.T) {
+	fullScale := float64(audioMaxValue + 1)
+	knee := int(limiterKnee * fullScale)
+	ceiling := int(limiterCeiling * fullScale)
+
+	// Below the knee the signal is untouched
+	for _, v := range []int{0, 1000, -1000, knee, -knee} {
+		if got := softLimit(v); got != v {
+			ts.Errorf("softLimit(%d) = %d, expected unchanged", v, got)
+		}
+	}
+
+	// A -3 dBFS sine passes through unchanged
+	amplitude := math.Pow(10, -3.0/20) * fullScale
+	for i := range 441 {
+		v := int(amplitude * math.Sin(2*math.Pi*float64(i)/441))
+		if got := softLimit(v); got != v {
+			ts.Fatalf("softLimit(%d) = %d, expected a -3 dBFS sine unchanged", v, got)
+		}
+	}
+
+	// Above the knee it is monotonic, symmetric and stays below the ceiling
+	prev := knee
+	for v := knee + 1; v < 40*audioMaxValue; v += 97 {
+		got := softLimit(v)
+		if got < prev || got > ceiling {
+			ts.Fatalf("softLimit(%d) = %d, expected between %d and %d", v, got, prev, ceiling)
+		}
+		if softLimit(-v) != -got {
+			ts.Fatalf("softLimit(%d) = %d, expected %d", -v, softLimit(-v), -got)
+		}
+		prev = got
+	}
+
+	// Unity slope at the knee, no audible corner
+	if d := softLimit(knee+100) - knee; d < 99 || d > 100 {
+		ts.Errorf("expected unity slope at the knee, got %d for 100", d)
+	}
+}
+
+func TestClipStats_Record(ts *testing.T) {
+	var cs ClipStats
+	for _, v := range []int{0, audioMaxValue, audioMinValue, 2 * audioMaxValue, -audioMaxValue - 2, audioMaxValue + 1} {
+		cs.record(v)
+	}
+
+	if cs.Samples != 3 {
+		ts.Fatalf("expected 3 clipped samples, got %d", cs.Samples)
+	}
+	if math.Abs(cs.MaxOvershoot-20*math.Log10(2)) > 1e-9 {
+		ts.Fatalf("expected 6.02 dB overshoot, got %.4f", cs.MaxOvershoot)
+	}
+	if !cs.Clipped() || (ClipStats{}).Clipped() {
+		ts.Fatalf("unexpected Clipped result")
+	}
+}
+
+func TestAudioRenderer_Render_Limiter(ts *testing.T) {
+	render := func(amplitude float64, limiter t.LimiterType, status *bytes.Buffer) ([]int, ClipStats) {
+		var p0, pEnd t.Period
+		for ch := range 2 {
+			p0.TrackStart[ch] = t.Track{
+				Type:      t.TrackPureTone,
+				Carrier:   440,
+				Amplitude: t.AmplitudePercentToRaw(amplitude),
+				Waveform:  t.WaveformSine,
+			}
+		}
+		p0.TrackEnd = p0.TrackStart
+		pEnd.Time = 100
+
+		options := &AudioRendererOptions{SampleRate: 44100, Volume: 100, Limiter: limiter}
+		if status != nil {
+			options.StatusOutput = status
+		}
+		r, err := NewAudioRenderer([]t.Period{p0, pEnd}, options)
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+		var out []int
+		if err := r.Render(func(samples []int) error {
+			out = append(out, samples...)
+			return nil
+		}); err != nil {
+			ts.Fatalf("Render failed: %v", err)
+		}
+		return out, r.ClipStats()
+	}
+
+	peak := func(samples []int) int {
+		p := 0
+		for _, v := range samples {
+			p = max(p, v, -v)
+		}
+		return p
+	}
+
+	// Two tones at 90% sum to 180% of full scale
+	clipped, stats := render(90, t.LimiterOff, nil)
+	if stats.Samples == 0 || stats.Limited || math.Abs(stats.MaxOvershoot-20*math.Log10(1.8)) > 0.05 {
+		ts.Fatalf("unexpected hard clip stats: %+v", stats)
+	}
+	if p := peak(clipped); p < audioMaxValue || p > -audioMinValue {
+		ts.Fatalf("expected hard clipping at full scale, got peak %d", p)
+	}
+
+	var status bytes.Buffer
+	limited, stats := render(90, t.LimiterSoft, &status)
+	if stats.Samples == 0 || !stats.Limited {
+		ts.Fatalf("unexpected soft limit stats: %+v", stats)
+	}
+	if p := peak(limited); float64(p) >= limiterCeiling*(audioMaxValue+1) {
+		ts.Fatalf("expected soft limited peak below the ceiling, got %d", p)
+	}
+	if !strings.Contains(status.String(), "exceeded full scale") || !strings.Contains(status.String(), "softly limited") {
+		ts.Fatalf("expected clip warning in status output, got %q", status.String())
+	}
+
+	// Quiet sequences are untouched by the limiter and report nothing
+	quiet, stats := render(20, t.LimiterOff, nil)
+	quietLimited, statsLimited := render(20, t.LimiterSoft, nil)
+	if stats.Clipped() || statsLimited.Clipped() {
+		ts.Fatalf("expected no clipping, got %+v / %+v", stats, statsLimited)
+	}
+	for i := range quiet {
+		if quiet[i] != quietLimited[i] {
+			ts.Fatalf("sample %d: expected quiet output unchanged by the limiter, got %d vs %d", i, quietLimited[i], quiet[i])
+		}
+	}
+}
@@ -0,0 +1,194 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/gopxl/beep/v2"
+)
+
+// loopStreamer streams a seekable source forever, looping between two frames
+// with an optional equal-power crossfade at the seam
+type loopStreamer struct {
+	source    beep.StreamSeeker
+	start     int
+	end       int
+	crossfade int
+	offset    int
+	err       error
+
+	// Frames right after the loop start, faded in over the end of the loop
+	head [][2]float64
+}
+
+// newLoopStreamer creates a loop streamer positioned at the offset frame
+func newLoopStreamer(source beep.StreamSeeker, start, end, crossfade, offset int) (*loopStreamer, error) {
+	ls := &loopStreamer{
+		source:    source,
+		start:     start,
+		end:       end,
+		crossfade: crossfade,
+		offset:    offset,
+	}
+
+	if crossfade > 0 {
+		if err := source.Seek(start); err != nil {
+			return nil, err
+		}
+
+		ls.head = make([][2]float64, crossfade)
+		for n := 0; n < crossfade; {
+			m, ok := source.Stream(ls.head[n:])
+			n += m
+			if !ok && n < crossfade {
+				if err := source.Err(); err != nil {
+					return nil, err
+				}
+				return nil, fmt.Errorf("audio ended before the end of the crossfade")
+			}
+		}
+	}
+
+	if err := source.Seek(offset); err != nil {
+		return nil, err
+	}
+
+	return ls, nil
+}
+
+// Stream fills samples from the loop, jumping back to the loop start at its end
+func (ls *loopStreamer) Stream(samples [][2]float64) (int, bool) {
+	filled := 0
+	for filled < len(samples) && ls.err == nil {
+		pos := ls.source.Position()
+		if pos >= ls.end {
+			// The head of the loop was already played while crossfading
+			if err := ls.source.Seek(ls.start + ls.crossfade); err != nil {
+				ls.err = err
+			}
+			continue
+		}
+
+		want := min(len(samples)-filled, ls.end-pos)
+		n, _ := ls.source.Stream(samples[filled : filled+want])
+		ls.fade(samples[filled:filled+n], pos)
+		filled += n
+
+		if n == 0 {
+			if err := ls.source.Err(); err != nil {
+				ls.err = err
+				break
+			}
+			// The source is shorter than it claims, loop where it ended
+			if pos <= ls.start+ls.crossfade {
+				break
+			}
+			ls.end = pos
+		}
+	}
+
+	return filled, filled > 0
+}
+
+// seek positions the loop as if the given number of frames had been streamed.
+// The first pass plays from the offset to the loop end, every later pass from
+// the end of the crossfaded head to the loop end.
+func (ls *loopStreamer) seek(frame int64) error {
+	ls.err = nil
+
+	pos := int64(ls.offset) + frame
+	if end := int64(ls.end); pos >= end {
+		head := int64(ls.start + ls.crossfade)
+		pos = head + (pos-end)%(end-head)
+	}
+
+	return ls.source.Seek(int(pos))
+}
+
+// fade crossfades frames at the end of the loop into its head
+func (ls *loopStreamer) fade(frames [][2]float64, pos int) {
+	if ls.crossfade == 0 {
+		return
+	}
+
+	fadeStart := ls.end - ls.crossfade
+	for i := range frames {
+		k := pos + i - fadeStart
+		if k < 0 || k >= ls.crossfade {
+			continue
+		}
+
+		// Equal power keeps uncorrelated recordings at a constant level
+		x := (float64(k) + 0.5) / float64(ls.crossfade) * math.Pi / 2
+		out, in := math.Cos(x), math.Sin(x)
+		frames[i][0] = frames[i][0]*out + ls.head[k][0]*in
+		frames[i][1] = frames[i][1]*out + ls.head[k][1]*in
+	}
+}
+
+// Err returns the error that stopped the loop, if any
+func (ls *loopStreamer) Err() error {
+	if ls.err != nil {
+		return ls.err
+	}
+	return ls.source.Err()
+}
+
+// readSmplLoop reads the first loop of a WAV smpl chunk as a frame range with
+// an exclusive end. Files without a readable loop report no loop.
+func readSmplLoop(r io.ReadSeeker) (int, int, bool) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, 0, false
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return 0, 0, false
+	}
+
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			return 0, 0, false
+		}
+
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+		if string(chunk[0:4]) != "smpl" {
+			// Chunks are padded to an even size
+			if _, err := r.Seek(size+size&1, io.SeekCurrent); err != nil {
+				return 0, 0, false
+			}
+			continue
+		}
+
+		// 36 bytes of sampler data followed by 24 bytes per loop
+		const loopOffset = 36
+		if size < loopOffset+24 {
+			return 0, 0, false
+		}
+		body := make([]byte, loopOffset+24)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return 0, 0, false
+		}
+		if binary.LittleEndian.Uint32(body[28:32]) == 0 {
+			return 0, 0, false
+		}
+
+		// The smpl end is the last frame played, inclusive
+		start := int(binary.LittleEndian.Uint32(body[loopOffset+8:]))
+		end := int(binary.LittleEndian.Uint32(body[loopOffset+12:])) + 1
+		return start, end, true
+	}
+}
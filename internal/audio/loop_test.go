@@ -0,0 +1,208 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gopxl/beep/v2"
+	bwav "github.com/gopxl/beep/v2/wav"
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// rampSampleRate makes loop points in seconds easy to map to frames
+const rampSampleRate = 1000
+
+type rampStreamer struct {
+	pos    int
+	frames int
+}
+
+func (rs *rampStreamer) Stream(samples [][2]float64) (n int, ok bool) {
+	for n < len(samples) && rs.pos < rs.frames {
+		v := float64(rs.pos) / 32768.0
+		samples[n][0], samples[n][1] = v, v
+		rs.pos++
+		n++
+	}
+	return n, n > 0
+}
+
+func (rs *rampStreamer) Err() error { return nil }
+
+// writeRampWav writes a stereo WAV where every sample holds its frame index,
+// with an optional smpl chunk loop (inclusive end, as in the file format)
+func writeRampWav(ts *testing.T, frames int, smpl []uint32) string {
+	ts.Helper()
+	path := filepath.Join(ts.TempDir(), "ramp.wav")
+	f, err := os.Create(path)
+	if err != nil {
+		ts.Fatalf("create wav: %v", err)
+	}
+	format := beep.Format{SampleRate: rampSampleRate, NumChannels: audioChannels, Precision: 2}
+	if err := bwav.Encode(f, &rampStreamer{frames: frames}, format); err != nil {
+		ts.Fatalf("encode wav: %v", err)
+	}
+	f.Close()
+
+	if smpl == nil {
+		return path
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		ts.Fatalf("read wav: %v", err)
+	}
+
+	chunk := make([]byte, 8+36+24)
+	copy(chunk[0:4], "smpl")
+	binary.LittleEndian.PutUint32(chunk[4:8], 36+24)
+	binary.LittleEndian.PutUint32(chunk[8+28:], 1) // one loop
+	binary.LittleEndian.PutUint32(chunk[8+36+8:], smpl[0])
+	binary.LittleEndian.PutUint32(chunk[8+36+12:], smpl[1])
+
+	data = append(data, chunk...)
+	binary.LittleEndian.PutUint32(data[4:8], uint32(len(data)-8))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		ts.Fatalf("write wav: %v", err)
+	}
+	return path
+}
+
+// readLoopFrames reads the left channel of the given number of frames
+func readLoopFrames(ts *testing.T, bg *BackgroundAudio, frames int) []int {
+	ts.Helper()
+	buf := make([]int, frames*audioChannels)
+	// Read in odd sized chunks to cross the seam inside a read
+	for pos := 0; pos < len(buf); pos += 74 {
+		n := min(74, len(buf)-pos)
+		if _, err := bg.ReadSamples(buf[pos:pos+n], n); err != nil {
+			ts.Fatalf("ReadSamples: %v", err)
+		}
+	}
+	left := make([]int, frames)
+	for i := range left {
+		left[i] = buf[2*i]
+	}
+	return left
+}
+
+func newLoopBackground(ts *testing.T, path string, loop t.BackgroundLoop) *BackgroundAudio {
+	ts.Helper()
+	bg, err := NewBackgroundAudio(path)
+	if err != nil {
+		ts.Fatalf("NewBackgroundAudio: %v", err)
+	}
+	ts.Cleanup(func() { bg.Close() })
+	if err := bg.conform(rampSampleRate, t.BackgroundSource{Loop: loop}); err != nil {
+		ts.Fatalf("conform: %v", err)
+	}
+	return bg
+}
+
+func TestBackgroundAudio_LoopPoints(ts *testing.T) {
+	bg := newLoopBackground(ts, writeRampWav(ts, 300, nil), t.BackgroundLoop{Start: 0.1, End: 0.2})
+
+	// The intro plays once, then the loop repeats
+	out := readLoopFrames(ts, bg, 500)
+	for i, v := range out {
+		want := i
+		if i >= 200 {
+			want = 100 + (i-200)%100
+		}
+		if v != want {
+			ts.Fatalf("frame %d: got %d want %d", i, v, want)
+		}
+	}
+}
+
+func TestBackgroundAudio_LoopOffset(ts *testing.T) {
+	bg := newLoopBackground(ts, writeRampWav(ts, 300, nil), t.BackgroundLoop{Start: 0.1, End: 0.2, Offset: 0.15})
+
+	out := readLoopFrames(ts, bg, 100)
+	if out[0] != 150 || out[49] != 199 || out[50] != 100 {
+		ts.Fatalf("unexpected offset playback: %v", out[:60])
+	}
+}
+
+func TestBackgroundAudio_SmplChunkLoop(ts *testing.T) {
+	path := writeRampWav(ts, 300, []uint32{50, 149})
+
+	// Without conform the smpl loop already applies
+	bg, err := NewBackgroundAudio(path)
+	if err != nil {
+		ts.Fatalf("NewBackgroundAudio: %v", err)
+	}
+	defer bg.Close()
+
+	out := readLoopFrames(ts, bg, 250)
+	if out[149] != 149 || out[150] != 50 || out[249] != 149 {
+		ts.Fatalf("expected smpl loop from 50 to 149, got %d, %d, %d", out[149], out[150], out[249])
+	}
+
+	// Loop points in the settings take precedence
+	bg = newLoopBackground(ts, path, t.BackgroundLoop{Start: 0.2, End: 0.25})
+	out = readLoopFrames(ts, bg, 300)
+	if out[249] != 249 || out[250] != 200 {
+		ts.Fatalf("expected settings loop from 200 to 249, got %d, %d", out[249], out[250])
+	}
+}
+
+func TestBackgroundAudio_LoopCrossfade(ts *testing.T) {
+	bg := newLoopBackground(ts, writeRampWav(ts, 300, nil), t.BackgroundLoop{Start: 0.1, End: 0.2, Crossfade: 0.02})
+
+	out := readLoopFrames(ts, bg, 400)
+	if out[179] != 179 {
+		ts.Fatalf("expected no fade before the crossfade, got %d", out[179])
+	}
+
+	// The loop end fades out while the loop head fades in
+	for k := 0; k < 20; k++ {
+		x := (float64(k) + 0.5) / 20 * math.Pi / 2
+		want := float64(180+k)*math.Cos(x) + float64(100+k)*math.Sin(x)
+		if math.Abs(float64(out[180+k])-want) > 1 {
+			ts.Fatalf("crossfade frame %d: got %d want %.1f", k, out[180+k], want)
+		}
+	}
+
+	// Playback resumes right after the faded in head, and keeps looping
+	// every 80 frames with the head played during the crossfade
+	if out[200] != 120 || out[259] != 179 || out[280] != 120 || out[360] != 120 {
+		ts.Fatalf("unexpected playback after the seam: %d, %d, %d, %d", out[200], out[259], out[280], out[360])
+	}
+}
+
+func TestBackgroundAudio_LoopErrors(ts *testing.T) {
+	path := writeRampWav(ts, 300, nil)
+
+	tests := map[string]t.BackgroundLoop{
+		"end past the file":   {Start: 0.1, End: 0.5},
+		"start past the file": {Start: 0.4},
+		"crossfade too long":  {Crossfade: 0.2},
+		"offset past the end": {Offset: 0.3},
+	}
+
+	for name, loop := range tests {
+		bg, err := NewBackgroundAudio(path)
+		if err != nil {
+			ts.Fatalf("NewBackgroundAudio: %v", err)
+		}
+		if err := bg.conform(rampSampleRate, t.BackgroundSource{Loop: loop}); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+		bg.Close()
+	}
+}
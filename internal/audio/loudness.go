@@ -0,0 +1,237 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"context"
+	"math"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+const (
+	// loudnessBlockMs is the gating block length of ITU-R BS.1770
+	loudnessBlockMs = 400
+	// loudnessStepMs is the hop between overlapping gating blocks (75% overlap)
+	loudnessStepMs = 100
+	// loudnessAbsoluteGate discards silent blocks, in LUFS
+	loudnessAbsoluteGate = -70.0
+	// loudnessRelativeGate discards blocks quieter than the ungated mean, in LU
+	loudnessRelativeGate = -10.0
+	// loudnessOffset is the constant of the BS.1770 loudness formula
+	loudnessOffset = -0.691
+)
+
+// biquad is a direct form I second order filter
+type biquad struct {
+	b0, b1, b2 float64
+	a1, a2     float64
+	x1, x2     float64
+	y1, y2     float64
+}
+
+// process filters one sample
+func (f *biquad) process(x float64) float64 {
+	y := f.b0*x + f.b1*f.x1 + f.b2*f.x2 - f.a1*f.y1 - f.a2*f.y2
+	f.x2, f.x1 = f.x1, x
+	f.y2, f.y1 = f.y1, y
+	return y
+}
+
+// kWeighting returns the two stages of the BS.1770 K-weighting filter
+// (high shelf and RLB high pass), derived for any sample rate
+func kWeighting(sampleRate int) [2]biquad {
+	fs := float64(sampleRate)
+
+	// Stage 1: high shelf modelling the acoustic effect of the head
+	f0 := 1681.974450955533
+	gain := 3.999843853973347
+	q := 0.7071752369554196
+
+	k := math.Tan(math.Pi * f0 / fs)
+	vh := math.Pow(10, gain/20)
+	vb := math.Pow(vh, 0.4996667741545416)
+	a0 := 1 + k/q + k*k
+
+	shelf := biquad{
+		b0: (vh + vb*k/q + k*k) / a0,
+		b1: 2 * (k*k - vh) / a0,
+		b2: (vh - vb*k/q + k*k) / a0,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+
+	// Stage 2: RLB high pass
+	f0 = 38.13547087602444
+	q = 0.5003270373238773
+
+	k = math.Tan(math.Pi * f0 / fs)
+	a0 = 1 + k/q + k*k
+
+	highPass := biquad{
+		b0: 1,
+		b1: -2,
+		b2: 1,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+
+	return [2]biquad{shelf, highPass}
+}
+
+// loudnessWeights returns the BS.1770 weight of every output channel of a layout.
+// Surround channels weigh +1.5 dB and the low frequency channel is excluded.
+func loudnessWeights(layout t.ChannelLayout) []float64 {
+	sp := speakersOf(layout)
+
+	weights := make([]float64, layout.Channels())
+	for o := range weights {
+		weights[o] = 1
+	}
+	if sp.lfe >= 0 {
+		weights[sp.lfe] = 0
+	}
+	for _, o := range []int{sp.rearLeft, sp.rearRight} {
+		if o >= 0 {
+			weights[o] = 1.41
+		}
+	}
+	return weights
+}
+
+// LoudnessMeter measures the integrated loudness of a signal
+// as specified by ITU-R BS.1770-4 and EBU R128
+type LoudnessMeter struct {
+	// K-weighting filters per channel
+	filters [][2]biquad
+	// Weight of every channel
+	weights []float64
+	// Frames per 100 ms step
+	stepFrames int
+	// Frames accumulated in the current step
+	frames int
+	// Weighted energy of the current step
+	energy float64
+	// Energies of the last steps forming the current block
+	steps []float64
+	// Mean square of every complete gating block
+	blocks []float64
+}
+
+// NewLoudnessMeter creates a loudness meter for the given sample rate and layout
+func NewLoudnessMeter(sampleRate int, layout t.ChannelLayout) *LoudnessMeter {
+	m := &LoudnessMeter{
+		filters:    make([][2]biquad, layout.Channels()),
+		weights:    loudnessWeights(layout),
+		stepFrames: sampleRate * loudnessStepMs / 1000,
+	}
+	for ch := range m.filters {
+		m.filters[ch] = kWeighting(sampleRate)
+	}
+	return m
+}
+
+// Write measures a buffer of interleaved 16-bit samples
+func (m *LoudnessMeter) Write(samples []int) {
+	const stepsPerBlock = loudnessBlockMs / loudnessStepMs
+	channels := len(m.filters)
+
+	for i := 0; i+channels <= len(samples); i += channels {
+		for ch := range channels {
+			if m.weights[ch] == 0 {
+				continue
+			}
+			x := float64(samples[i+ch]) / (audioMaxValue + 1)
+			y := m.filters[ch][1].process(m.filters[ch][0].process(x))
+			m.energy += m.weights[ch] * y * y
+		}
+
+		m.frames++
+		if m.frames < m.stepFrames {
+			continue
+		}
+
+		m.steps = append(m.steps, m.energy)
+		m.energy = 0
+		m.frames = 0
+
+		if len(m.steps) < stepsPerBlock {
+			continue
+		}
+
+		var sum float64
+		for _, e := range m.steps {
+			sum += e
+		}
+		m.blocks = append(m.blocks, sum/float64(stepsPerBlock*m.stepFrames))
+		m.steps = m.steps[1:]
+	}
+}
+
+// Integrated returns the gated integrated loudness in LUFS.
+// Silence, or a signal shorter than one block, measures as negative infinity.
+func (m *LoudnessMeter) Integrated() float64 {
+	// Absolute gate
+	absolute := gatedMean(m.blocks, loudnessAbsoluteGate)
+	if absolute == 0 {
+		return math.Inf(-1)
+	}
+
+	// Relative gate, below the loudness of the blocks above the absolute gate
+	relative := blockLoudness(absolute) + loudnessRelativeGate
+	mean := gatedMean(m.blocks, math.Max(relative, loudnessAbsoluteGate))
+	if mean == 0 {
+		return math.Inf(-1)
+	}
+
+	return blockLoudness(mean)
+}
+
+// gatedMean returns the mean square of the blocks louder than the gate
+func gatedMean(blocks []float64, gate float64) float64 {
+	var sum float64
+	var n int
+	for _, z := range blocks {
+		if blockLoudness(z) > gate {
+			sum += z
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// blockLoudness converts a weighted mean square to LUFS
+func blockLoudness(z float64) float64 {
+	return loudnessOffset + 10*math.Log10(z)
+}
+
+// MeasureLoudness renders the audio without output and returns its integrated loudness in LUFS.
+// Like Render, it can only be called once per renderer.
+func (r *AudioRenderer) MeasureLoudness() (float64, error) {
+	return r.MeasureLoudnessContext(context.Background())
+}
+
+// MeasureLoudnessContext is MeasureLoudness stopped when the context is done
+func (r *AudioRenderer) MeasureLoudnessContext(ctx context.Context) (float64, error) {
+	meter := NewLoudnessMeter(r.SampleRate, r.Layout)
+	err := r.RenderContext(ctx, func(samples []int) error {
+		meter.Write(samples)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return meter.Integrated(), nil
+}
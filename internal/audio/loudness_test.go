@@ -0,0 +1,129 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// stereoSine returns interleaved stereo samples of a 1 kHz sine at a peak level in dBFS
+func stereoSine(sampleRate int, seconds float64, dBFS float64) []int {
+	frames := int(seconds * float64(sampleRate))
+	amp := math.Pow(10, dBFS/20) * (audioMaxValue + 1)
+	samples := make([]int, frames*audioChannels)
+	for i := range frames {
+		v := int(math.Round(amp * math.Sin(2*math.Pi*1000*float64(i)/float64(sampleRate))))
+		samples[i*2] = v
+		samples[i*2+1] = v
+	}
+	return samples
+}
+
+func TestLoudnessMeter_Sine(ts *testing.T) {
+	// EBU Tech 3341 case 1: a stereo 1 kHz sine at -23 dBFS reads -23 LUFS
+	for _, rate := range []int{44100, 48000, 96000} {
+		m := NewLoudnessMeter(rate, t.LayoutStereo)
+		m.Write(stereoSine(rate, 20, -23))
+		if got := m.Integrated(); math.Abs(got+23) > 0.1 {
+			ts.Errorf("at %d Hz expected -23.0 LUFS, got %.2f", rate, got)
+		}
+	}
+}
+
+func TestLoudnessMeter_Gating(ts *testing.T) {
+	const rate = 48000
+
+	// EBU Tech 3341 case 3: the quiet parts are below the relative gate
+	m := NewLoudnessMeter(rate, t.LayoutStereo)
+	m.Write(stereoSine(rate, 10, -36))
+	m.Write(stereoSine(rate, 60, -23))
+	m.Write(stereoSine(rate, 10, -36))
+	if got := m.Integrated(); math.Abs(got+23) > 0.1 {
+		ts.Errorf("expected -23.0 LUFS with relative gating, got %.2f", got)
+	}
+
+	// Silence is below the absolute gate
+	m = NewLoudnessMeter(rate, t.LayoutStereo)
+	m.Write(stereoSine(rate, 10, -20))
+	m.Write(make([]int, rate*60*audioChannels))
+	if got := m.Integrated(); math.Abs(got+20) > 0.1 {
+		ts.Errorf("expected -20.0 LUFS with absolute gating, got %.2f", got)
+	}
+
+	m = NewLoudnessMeter(rate, t.LayoutStereo)
+	m.Write(make([]int, rate*audioChannels))
+	if got := m.Integrated(); !math.IsInf(got, -1) {
+		ts.Errorf("expected silence to measure -Inf, got %.2f", got)
+	}
+}
+
+func TestAudioRenderer_NormalizeGain(ts *testing.T) {
+	var p0, pEnd t.Period
+	p0.TrackStart[0] = t.Track{
+		Type:      t.TrackBinauralBeat,
+		Carrier:   250,
+		Resonance: 10,
+		Amplitude: t.AmplitudePercentToRaw(30),
+		Waveform:  t.WaveformSine,
+	}
+	p0.TrackEnd = p0.TrackStart
+	pEnd.Time = 5000
+
+	newRenderer := func(gain float64) *AudioRenderer {
+		r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{
+			SampleRate:    44100,
+			Volume:        100,
+			NormalizeGain: gain,
+		})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+		return r
+	}
+
+	measured, err := newRenderer(0).MeasureLoudness()
+	if err != nil {
+		ts.Fatalf("MeasureLoudness failed: %v", err)
+	}
+	if math.IsInf(measured, -1) {
+		ts.Fatalf("expected a finite loudness")
+	}
+
+	// The second pass lands on the target
+	const target = -30.0
+	normalized, err := newRenderer(target - measured).MeasureLoudness()
+	if err != nil {
+		ts.Fatalf("MeasureLoudness failed: %v", err)
+	}
+	if math.Abs(normalized-target) > 0.1 {
+		ts.Fatalf("expected %.2f LUFS after normalization, got %.2f (measured %.2f)", target, normalized, measured)
+	}
+
+	if _, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{
+		SampleRate:    44100,
+		Volume:        100,
+		NormalizeGain: math.Inf(1),
+	}); err == nil {
+		ts.Fatalf("expected error for an infinite normalization gain")
+	}
+
+	var status bytes.Buffer
+	NewStatusReporter(&status).DisplayLoudness(measured, target, target-measured)
+	if !strings.Contains(status.String(), "target -30.00 LUFS") {
+		ts.Fatalf("unexpected loudness status %q", status.String())
+	}
+}
@@ -12,191 +12,223 @@
 package audio
 
 import (
-	"math"
-
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
 
-// mix generates a stereo audio sample by mixing all channels
-func (r *AudioRenderer) mix(samples []int) []int {
-	// Read background audio samples if enabled
-	var backgroundSamples []int
+// sampleScaleFactor matches 16-bit samples of backgrounds and cues to the
+// wavetable amplitude range.
+// WaveTableAmplitude (0x7FFFF = 524287) vs 16-bit samples (32768)
+// Scale: 524287 / 32768 ≈ 16
+const sampleScaleFactor = 16
+
+// mix generates a stereo audio sample by mixing all channels, starting at the given frame.
+// The samples are left at the internal resolution of the master bus, output
+// brings them to the output range.
+func (r *AudioRenderer) mix(samples []int, frame int64) []int {
+	// Read background audio samples of every source
+	for name, bg := range r.backgroundAudio {
+		bg.ReadSamples(r.backgroundSamples[name], t.BufferSize*audioChannels)
+	}
 
-	if r.backgroundAudio.IsEnabled() {
-		// Buffer for background audio
-		backgroundSamples = make([]int, t.BufferSize*audioChannels) // Stereo
-		r.backgroundAudio.ReadSamples(backgroundSamples, t.BufferSize*audioChannels)
+	// Align noise streams with the current frame
+	for _, ng := range r.noiseGenerators {
+		ng.SetPosition(frame)
 	}
 
+	// Cues and bell strikes playing in this buffer
+	var playingBuf [t.NumberOfChannels]*oneShot
+	playing := r.playingOneShots(playingBuf[:0], frame)
+
+	n := r.outputChannels
+
 	for i := range t.BufferSize {
-		var left, right int
+		// One bus per output channel
+		var bus [maxOutputChannels]int
 
 		for ch := range t.NumberOfChannels {
 			channel := &r.channels[ch]
-			waveIdx := int(channel.Track.Waveform)
+			waveIdx := channel.WaveTable
 
-			switch channel.Track.Type {
-			case t.TrackPureTone:
-				channel.Offset[0] += channel.Increment[0]
-				channel.Offset[0] &= (t.SineTableSize << 16) - 1
+			var chLeft, chRight int
 
-				left += channel.Amplitude[0] * r.waveTables[waveIdx][channel.Offset[0]>>16]
-				right += channel.Amplitude[0] * r.waveTables[waveIdx][channel.Offset[0]>>16]
-			case t.TrackBinauralBeat:
-				channel.Offset[0] += channel.Increment[0]
-				channel.Offset[0] &= (t.SineTableSize << 16) - 1
-
-				channel.Offset[1] += channel.Increment[1]
-				channel.Offset[1] &= (t.SineTableSize << 16) - 1
-
-				left += channel.Amplitude[0] * r.waveTables[waveIdx][channel.Offset[0]>>16]
-				right += channel.Amplitude[1] * r.waveTables[waveIdx][channel.Offset[1]>>16]
-			case t.TrackMonauralBeat:
-				channel.Offset[0] += channel.Increment[0]
-				channel.Offset[0] &= (t.SineTableSize << 16) - 1
-
-				channel.Offset[1] += channel.Increment[1]
-				channel.Offset[1] &= (t.SineTableSize << 16) - 1
+			if channel.Track.Partials.Kind != t.PartialsOff {
+				// Tones built from partials of their carrier
+				chLeft, chRight = r.mixPartials(channel, waveIdx)
+			} else {
+				switch channel.Track.Type {
+				case t.TrackPureTone:
+					channel.Offset[0] += channel.Increment[0]
+					channel.Offset[0] &= (t.SineTableSize << 16) - 1
 
-				freqHigh := r.waveTables[waveIdx][channel.Offset[0]>>16]
-				freqLow := r.waveTables[waveIdx][channel.Offset[1]>>16]
+					chLeft += channel.Amplitude[0] * r.waveTables[waveIdx][channel.Offset[0]>>16]
+					chRight += channel.Amplitude[0] * r.waveTables[waveIdx][channel.Offset[0]>>16]
+				case t.TrackBinauralBeat:
+					channel.Offset[0] += channel.Increment[0]
+					channel.Offset[0] &= (t.SineTableSize << 16) - 1
 
-				halfAmp := channel.Amplitude[0] / 2
-				mixedSample := halfAmp * (freqHigh + freqLow)
+					channel.Offset[1] += channel.Increment[1]
+					channel.Offset[1] &= (t.SineTableSize << 16) - 1
 
-				left += mixedSample
-				right += mixedSample
-			case t.TrackIsochronicBeat:
-				channel.Offset[0] += channel.Increment[0]
-				channel.Offset[0] &= (t.SineTableSize << 16) - 1
+					chLeft += channel.Amplitude[0] * r.waveTables[waveIdx][channel.Offset[0]>>16]
+					chRight += channel.Amplitude[1] * r.waveTables[waveIdx][channel.Offset[1]>>16]
+				case t.TrackMonauralBeat:
+					channel.Offset[0] += channel.Increment[0]
+					channel.Offset[0] &= (t.SineTableSize << 16) - 1
 
-				channel.Offset[1] += channel.Increment[1]
-				channel.Offset[1] &= (t.SineTableSize << 16) - 1
+					channel.Offset[1] += channel.Increment[1]
+					channel.Offset[1] &= (t.SineTableSize << 16) - 1
 
-				modFactor := r.calcPulseFactor(channel)
+					freqHigh := r.waveTables[waveIdx][channel.Offset[0]>>16]
+					freqLow := r.waveTables[waveIdx][channel.Offset[1]>>16]
 
-				carrier := float64(r.waveTables[waveIdx][channel.Offset[0]>>16])
-				amp := float64(channel.Amplitude[0])
+					halfAmp := channel.Amplitude[0] / 2
+					mixedSample := halfAmp * (freqHigh + freqLow)
 
-				out := int(amp * carrier * modFactor)
+					chLeft += mixedSample
+					chRight += mixedSample
+				case t.TrackIsochronicBeat:
+					channel.Offset[0] += channel.Increment[0]
+					channel.Offset[0] &= (t.SineTableSize << 16) - 1
 
-				left += out
-				right += out
-			case t.TrackWhiteNoise, t.TrackPinkNoise, t.TrackBrownNoise:
-				// Use pre-generated pink noise sample for efficiency
-				noiseVal := r.noiseGenerator.Generate(t.TrackPinkNoise)
-				if channel.Track.Type != t.TrackPinkNoise {
-					noiseVal = r.noiseGenerator.Generate(channel.Track.Type)
-				}
+					channel.Offset[1] += channel.Increment[1]
+					channel.Offset[1] &= (t.SineTableSize << 16) - 1
 
-				// Scale noise by amplitude
-				sampleVal := channel.Amplitude[0] * noiseVal
-				left += sampleVal
-				right += sampleVal
-			case t.TrackBackground:
-				// Scale factor to match wavetable amplitude range
-				// WaveTableAmplitude (0x7FFFF = 524287) vs 16-bit samples (32768)
-				// Scale: 524287 / 32768 ≈ 16
-				const bgScaleFactor = 16
-
-				bgLeft := backgroundSamples[i*2] * bgScaleFactor
-				bgRight := backgroundSamples[i*2+1] * bgScaleFactor
-
-				// Apply gain reduction if configured (default GainLevelVeryHigh = 0dB, no reduction)
-				if r.GainLevel > 0 {
-					dbValue := -float64(r.GainLevel)
-					gainFactor := math.Pow(10, dbValue/20.0)
-					bgLeft = int(float64(bgLeft) * gainFactor)
-					bgRight = int(float64(bgRight) * gainFactor)
-				}
+					modFactor := r.calcPulseFactor(channel)
 
-				backgroundAmplitude := channel.Amplitude[0]
+					carrier := float64(r.waveTables[waveIdx][channel.Offset[0]>>16])
+					amp := float64(channel.Amplitude[0])
 
-				switch channel.Track.Effect.Type {
-				case t.EffectSpin:
-					channel.Offset[0] += channel.Increment[0]
-					channel.Offset[0] &= (t.SineTableSize << 16) - 1
+					out := int(amp * carrier * modFactor)
 
-					spinPos := (channel.Increment[1] * r.waveTables[waveIdx][channel.Offset[0]>>16]) >> 24
+					chLeft += out
+					chRight += out
+				case t.TrackFMTone, t.TrackAMTone:
+					out := r.mixModulated(channel, waveIdx)
 
-					effectIntensity := float64(channel.Track.Intensity) * 0.7
-					spinGain := 0.5 + effectIntensity*3.5
+					chLeft += out
+					chRight += out
+				case t.TrackWhiteNoise, t.TrackPinkNoise, t.TrackBrownNoise:
+					noiseVal := r.noiseGenerators[ch].Generate(channel.Track.Type)
 
-					ampSpin := int(float64(spinPos) * spinGain)
-					if ampSpin > 127 {
-						ampSpin = 127
-					}
-					if ampSpin < -128 {
-						ampSpin = -128
+					// Scale noise by amplitude
+					sampleVal := channel.Amplitude[0] * noiseVal
+					chLeft, chRight = r.applyEffect(channel, waveIdx, sampleVal, sampleVal)
+				case t.TrackBackground:
+					backgroundSamples := r.backgroundSamples[channel.Track.Source]
+					if backgroundSamples == nil {
+						continue
 					}
 
-					posVal := ampSpin
-					if posVal < 0 {
-						posVal = -posVal
-					}
-					if posVal > 128 {
-						posVal = 128
-					}
+					bgLeft := backgroundSamples[i*2] * sampleScaleFactor
+					bgRight := backgroundSamples[i*2+1] * sampleScaleFactor
 
-					var spinLeft, spinRight int
-					if ampSpin >= 0 {
-						spinLeft = (bgLeft * backgroundAmplitude * (128 - posVal)) >> 7
-						spinRight = bgRight*backgroundAmplitude + ((bgLeft * backgroundAmplitude * posVal) >> 7)
-					} else {
-						spinLeft = bgLeft*backgroundAmplitude + ((bgRight * backgroundAmplitude * posVal) >> 7)
-						spinRight = (bgRight * backgroundAmplitude * (128 - posVal)) >> 7
+					// Apply the gain level and the track gain
+					if channel.Gain != 1 {
+						bgLeft = int(float64(bgLeft) * channel.Gain)
+						bgRight = int(float64(bgRight) * channel.Gain)
 					}
 
-					left += spinLeft
-					right += spinRight
-				case t.EffectPulse:
-					// LFO for pulse modulation
-					channel.Offset[1] += channel.Increment[1]
-					channel.Offset[1] &= (t.SineTableSize << 16) - 1
+					backgroundAmplitude := channel.Amplitude[0]
 
-					// 0..1
-					modFactor := r.calcPulseFactor(channel)
-
-					// Mix the effect (0..1) weighted by intensity
-					effectIntensity := float64(channel.Track.Intensity) * 0.7
-					gain := (1.0 - effectIntensity) + (effectIntensity * modFactor)
-
-					left += int(float64(bgLeft*backgroundAmplitude) * gain)
-					right += int(float64(bgRight*backgroundAmplitude) * gain)
-				default:
-					// BG without effect
-					left += bgLeft * backgroundAmplitude
-					right += bgRight * backgroundAmplitude
+					chLeft, chRight = r.applyEffect(channel, waveIdx, bgLeft*backgroundAmplitude, bgRight*backgroundAmplitude)
 				}
 			}
+
+			// Position the track in the stereo field
+			if channel.Track.Pan != 0 {
+				chLeft = int(float64(chLeft) * channel.Pan[0])
+				chRight = int(float64(chRight) * channel.Pan[1])
+			}
+
+			// Send the track to the speakers of its route
+			r.routing[channel.Track.Route].route(&bus, chLeft, chRight)
 		}
 
-		if r.Volume != 100 {
-			left = left * r.Volume / 100
-			right = right * r.Volume / 100
+		// One-shot sounds follow the frame, not the channel state
+		for _, shot := range playing {
+			r.mixOneShot(&bus, shot, frame+int64(i))
 		}
 
-		// Scale down to 24-bit range
-		left >>= audioBitShift
-		right >>= audioBitShift
+		for o := range n {
+			v := bus[o]
 
-		// Clipping to 24-bit range
-		if left > audioMaxValue {
-			left = audioMaxValue
-		}
-		if left < audioMinValue {
-			left = audioMinValue
-		}
-		if right > audioMaxValue {
-			right = audioMaxValue
+			// Compensate asymmetric hearing
+			if r.Balance != 0 {
+				v = int(float64(v) * r.balanceGains[o])
+			}
+
+			if r.Volume != 100 {
+				v = v * r.Volume / 100
+			}
+
+			// Loudness normalization
+			if r.masterGain != 1 {
+				v = int(float64(v) * r.masterGain)
+			}
+
+			samples[i*n+o] = v
 		}
-		if right < audioMinValue {
-			right = audioMinValue
+	}
+
+	return samples
+}
+
+// output scales the master bus samples of a buffer starting at the given frame
+// down to the 16-bit range and limits or clips them, in place. Buffers go
+// through output in order, which carries the noise shaping over the render.
+func (r *AudioRenderer) output(samples []int, frame int64) []int {
+	n := r.outputChannels
+	for i := range len(samples) / n {
+		for o := range n {
+			v := r.quantize(samples[i*n+o], frame+int64(i), o, &r.shaping[o])
+			samples[i*n+o] = r.master(v)
 		}
+	}
+	return samples
+}
+
+// advance moves the oscillators of every channel forward by a number of frames
+// without mixing, leaving them exactly where mix would
+func (r *AudioRenderer) advance(frames int) {
+	for ch := range t.NumberOfChannels {
+		channel := &r.channels[ch]
+
+		switch channel.Track.Type {
+		case t.TrackPureTone, t.TrackBinauralBeat, t.TrackMonauralBeat, t.TrackIsochronicBeat:
+			// Tones built from partials leave the plain phases as they are,
+			// except the pulse of isochronic tones
+			if channel.Track.Partials.Kind != t.PartialsOff {
+				advancePartials(channel, frames)
+				if channel.Track.Type == t.TrackIsochronicBeat {
+					advancePhase(&channel.Offset[1], channel.Increment[1], frames)
+				}
+				continue
+			}
 
-		samples[i*2] = int(left)
-		samples[i*2+1] = int(right)
+			advancePhase(&channel.Offset[0], channel.Increment[0], frames)
+			if channel.Track.Type != t.TrackPureTone {
+				advancePhase(&channel.Offset[1], channel.Increment[1], frames)
+			}
+		case t.TrackFMTone, t.TrackAMTone:
+			r.advanceModulated(channel, frames)
+		case t.TrackWhiteNoise, t.TrackPinkNoise, t.TrackBrownNoise, t.TrackBackground:
+			// Backgrounds without audio are skipped before their effect
+			if channel.Track.Type == t.TrackBackground && r.backgroundSamples[channel.Track.Source] == nil {
+				continue
+			}
+
+			switch channel.Track.Effect.Type {
+			case t.EffectSpin:
+				advancePhase(&channel.Offset[0], channel.Increment[0], frames)
+			case t.EffectPulse:
+				advancePhase(&channel.Offset[1], channel.Increment[1], frames)
+			}
+		}
 	}
+}
 
-	return samples
+// advancePhase adds an increment to a wave table phase a number of times
+func advancePhase(offset *int, increment, frames int) {
+	*offset += increment * frames
+	*offset &= (t.SineTableSize << 16) - 1
 }
@@ -0,0 +1,75 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// syncModulated updates the carrier and modulator of an FM or AM tone. The
+// modulator is always a sine wave, the waveform applies to the carrier.
+func (r *AudioRenderer) syncModulated(channel *t.Channel) {
+	channel.Amplitude[0] = int(channel.Track.Amplitude)
+	channel.Increment[0] = int(channel.Track.Carrier / float64(r.SampleRate) * t.SineTableSize * t.PhasePrecision)
+	channel.Increment[1] = int(channel.Track.Resonance / float64(r.SampleRate) * t.SineTableSize * t.PhasePrecision)
+
+	channel.Deviation = 0
+	if channel.Track.Type == t.TrackFMTone {
+		channel.Deviation = int(channel.Track.Depth / float64(r.SampleRate) * t.SineTableSize * t.PhasePrecision)
+	}
+}
+
+// mixModulated returns the next sample of an FM or AM tone
+func (r *AudioRenderer) mixModulated(channel *t.Channel, waveIdx int) int {
+	channel.Offset[1] += channel.Increment[1]
+	channel.Offset[1] &= (t.SineTableSize << 16) - 1
+
+	modulator := r.waveTables[t.WaveformSine][channel.Offset[1]>>16]
+
+	if channel.Track.Type == t.TrackFMTone {
+		// The carrier frequency swings by the deviation around its center
+		channel.Offset[0] += channel.Increment[0] + channel.Deviation*modulator/t.WaveTableAmplitude
+		channel.Offset[0] &= (t.SineTableSize << 16) - 1
+
+		return channel.Amplitude[0] * r.waveTables[waveIdx][channel.Offset[0]>>16]
+	}
+
+	channel.Offset[0] += channel.Increment[0]
+	channel.Offset[0] &= (t.SineTableSize << 16) - 1
+
+	// The level dips by the depth from its peak at the top of the modulator
+	depth := channel.Track.Depth / 100
+	modFactor := 1 - depth/2*(1-float64(modulator)/t.WaveTableAmplitude)
+
+	carrier := float64(r.waveTables[waveIdx][channel.Offset[0]>>16])
+	return int(float64(channel.Amplitude[0]) * carrier * modFactor)
+}
+
+// advanceModulated moves the carrier and modulator of an FM or AM tone forward
+// by a number of frames. The phase of an FM carrier follows the modulator, so
+// it is stepped one frame at a time.
+func (r *AudioRenderer) advanceModulated(channel *t.Channel, frames int) {
+	if channel.Track.Type != t.TrackFMTone {
+		advancePhase(&channel.Offset[0], channel.Increment[0], frames)
+		advancePhase(&channel.Offset[1], channel.Increment[1], frames)
+		return
+	}
+
+	sine := r.waveTables[t.WaveformSine]
+	for range frames {
+		channel.Offset[1] += channel.Increment[1]
+		channel.Offset[1] &= (t.SineTableSize << 16) - 1
+
+		channel.Offset[0] += channel.Increment[0] + channel.Deviation*sine[channel.Offset[1]>>16]/t.WaveTableAmplitude
+		channel.Offset[0] &= (t.SineTableSize << 16) - 1
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+func TestAudioRenderer_FMSidebands(ts *testing.T) {
+	// Modulation index 1, sidebands follow the Bessel functions of the first kind
+	tr := t.Track{Type: t.TrackFMTone, Carrier: 1000, Resonance: 100, Depth: 100, Amplitude: t.AmplitudePercentToRaw(50)}
+	left, right := renderEars(ts, tr)
+
+	for i := range left {
+		if left[i] != right[i] {
+			ts.Fatalf("frame %d: expected the same sample on both ears, got %d %d", i, left[i], right[i])
+		}
+	}
+
+	carrier := math.Sqrt(goertzelPower(left, 1000, 44100))
+	for _, n := range []int{-2, -1, 1, 2} {
+		freq := 1000 + float64(n)*100
+		got := math.Sqrt(goertzelPower(left, freq, 44100)) / carrier
+		want := math.Abs(math.Jn(n, 1) / math.J0(1))
+		if math.Abs(got-want) > 0.02 {
+			ts.Errorf("sideband %d (%.0f Hz): expected ratio %.3f, got %.3f", n, freq, want, got)
+		}
+	}
+}
+
+func TestAudioRenderer_AMSidebands(ts *testing.T) {
+	tr := t.Track{Type: t.TrackAMTone, Carrier: 1000, Resonance: 40, Depth: 60, Amplitude: t.AmplitudePercentToRaw(50)}
+	left, _ := renderEars(ts, tr)
+
+	// The level swings between 40% and 100%, a carrier at 0.7 with two
+	// sidebands at 0.15 and nothing further out
+	carrier := math.Sqrt(goertzelPower(left, 1000, 44100))
+	want := 0.15 / 0.7
+	for _, freq := range []float64{960, 1040} {
+		if got := math.Sqrt(goertzelPower(left, freq, 44100)) / carrier; math.Abs(got-want) > 0.01 {
+			ts.Errorf("expected a sideband at %.0f Hz with ratio %.3f, got %.3f", freq, want, got)
+		}
+	}
+	for _, freq := range []float64{920, 1080} {
+		if got := math.Sqrt(goertzelPower(left, freq, 44100)) / carrier; got > 0.01 {
+			ts.Errorf("expected no sideband at %.0f Hz, got ratio %.3f", freq, got)
+		}
+	}
+
+	// The level peaks at the amplitude of the tone
+	peak := 0
+	for _, v := range left {
+		peak = max(peak, abs(v))
+	}
+	plain, _ := renderEars(ts, t.Track{Type: t.TrackPureTone, Carrier: 1000, Amplitude: t.AmplitudePercentToRaw(50)})
+	plainPeak := 0
+	for _, v := range plain {
+		plainPeak = max(plainPeak, abs(v))
+	}
+	if peak > plainPeak || peak < plainPeak*98/100 {
+		ts.Errorf("expected the AM peak close to %d, got %d", plainPeak, peak)
+	}
+}
+
+func TestSync_InterpolatesModulation(ts *testing.T) {
+	var p0, pEnd t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackFMTone, Carrier: 200, Resonance: 4, Depth: 20, Amplitude: t.AmplitudePercentToRaw(20)}
+	p0.TrackEnd[0] = p0.TrackStart[0]
+	p0.TrackEnd[0].Resonance = 8
+	p0.TrackEnd[0].Depth = 80
+	p0.TrackStart[1] = t.Track{Type: t.TrackAMTone, Carrier: 200, Resonance: 4, Depth: 100, Amplitude: t.AmplitudePercentToRaw(20)}
+	p0.TrackEnd[1] = p0.TrackStart[1]
+	p0.TrackEnd[1].Depth = 0
+	pEnd.Time = 1000
+
+	r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{SampleRate: 44100, Volume: 100})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	r.sync(500, 0)
+	fm := &r.channels[0]
+	if math.Abs(fm.Track.Depth-50) > 1e-9 || math.Abs(fm.Track.Resonance-6) > 1e-9 {
+		ts.Fatalf("unexpected interpolated modulation: %+v", fm.Track)
+	}
+	depth := 50.0
+	if want := int(depth / 44100 * t.SineTableSize * t.PhasePrecision); fm.Deviation != want {
+		ts.Errorf("expected deviation %d, got %d", want, fm.Deviation)
+	}
+	if am := &r.channels[1]; math.Abs(am.Track.Depth-50) > 1e-9 || am.Deviation != 0 {
+		ts.Errorf("unexpected AM state: depth %.2f deviation %d", am.Track.Depth, am.Deviation)
+	}
+}
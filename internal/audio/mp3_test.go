@@ -0,0 +1,141 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// mp3FrameSamples is the number of samples of an MPEG-1 Layer III frame
+const mp3FrameSamples = 1152
+
+// encodeTestMP3 returns a stream of silent MPEG-1 Layer III stereo frames at
+// 44100 Hz and 128 kbps. The side information of each frame is zero, so the
+// frames carry no main data and decode to silence.
+func encodeTestMP3(frames int) []byte {
+	header := []byte{0xFF, 0xFB, 0x90, 0x00}
+	frameSize := 144 * 128000 / 44100
+
+	var out []byte
+	for range frames {
+		out = append(out, header...)
+		out = append(out, make([]byte, frameSize-len(header))...)
+	}
+	return out
+}
+
+func TestDecodeMP3(ts *testing.T) {
+	s, format, err := decodeMP3(bytes.NewReader(encodeTestMP3(20)))
+	if err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	defer s.Close()
+
+	if format.SampleRate != 44100 || format.NumChannels != 2 {
+		ts.Fatalf("unexpected format: %+v", format)
+	}
+	if s.Len() != 20*mp3FrameSamples {
+		ts.Fatalf("expected %d frames, got %d", 20*mp3FrameSamples, s.Len())
+	}
+
+	got := make([][2]float64, s.Len()+10)
+	n, _ := s.Stream(got)
+	if err := s.Err(); err != nil {
+		ts.Fatalf("unexpected stream error: %v", err)
+	}
+	if n != s.Len() {
+		ts.Fatalf("expected %d decoded frames, got %d", s.Len(), n)
+	}
+	for i, frame := range got[:n] {
+		if frame != [2]float64{} {
+			ts.Fatalf("frame %d: expected silence, got %v", i, frame)
+		}
+	}
+
+	// Seeking back decodes again from the seek position
+	if err := s.Seek(3000); err != nil {
+		ts.Fatalf("unexpected seek error: %v", err)
+	}
+	if s.Position() != 3000 {
+		ts.Fatalf("expected position 3000, got %d", s.Position())
+	}
+}
+
+func TestBackgroundAudio_MP3(ts *testing.T) {
+	path := filepath.Join(ts.TempDir(), "ambience.mp3")
+	if err := os.WriteFile(path, encodeTestMP3(4), 0o644); err != nil {
+		ts.Fatalf("write mp3: %v", err)
+	}
+
+	bg, err := NewBackgroundAudio(path)
+	if err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	defer bg.Close()
+	if err := bg.conform(48000, t.BackgroundSource{Path: path}); err != nil {
+		ts.Fatalf("unexpected conform error: %v", err)
+	}
+
+	// The file is resampled and loops past its end
+	out := make([]int, 3*4*mp3FrameSamples*audioChannels)
+	if n, err := bg.ReadSamples(out, len(out)); err != nil || n != len(out) {
+		ts.Fatalf("expected %d samples, got %d (err %v)", len(out), n, err)
+	}
+	for i, v := range out {
+		if v != 0 {
+			ts.Fatalf("sample %d: expected silence, got %d", i, v)
+		}
+	}
+}
+
+func TestBackgroundAudio_RemoteMP3(ts *testing.T) {
+	// Larger than the size limit of WAV files
+	data := encodeTestMP3(t.MaxBackgroundFileSize/417 + 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	bg, err := NewBackgroundAudio(server.URL + "/ambience.mp3?v=1")
+	if err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	defer bg.Close()
+
+	if len(bg.cachedData) != len(data) {
+		ts.Fatalf("expected the whole file with the audio size limit, got %d of %d bytes", len(bg.cachedData), len(data))
+	}
+}
+
+func TestRemoteFileFormat(ts *testing.T) {
+	tests := map[string]t.FileFormat{
+		"https://example.com/rain.flac":      t.FormatAudio,
+		"https://example.com/rain.OGG?dl=1":  t.FormatAudio,
+		"https://example.com/rain.mp3#start": t.FormatAudio,
+		"https://example.com/rain.wav":       t.FormatWAV,
+		"https://example.com/rain":           t.FormatWAV,
+	}
+
+	for url, want := range tests {
+		if got := remoteFileFormat(url); got != want {
+			ts.Errorf("%s: expected %s, got %s", url, want, got)
+		}
+	}
+}
@@ -12,6 +12,8 @@
 package audio
 
 import (
+	"math"
+
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
 
@@ -20,122 +22,154 @@ const (
 	noiseShift = 12
 	// NoiseAmplitude is the amplitude for noise generation
 	noiseAmplitude = t.WaveTableAmplitude << noiseShift
-	// NoiseBands is the number of bands for noise generation
+	// NoiseBands is the number of octave bands for pink noise generation
 	noiseBands = 9
-	// Random multiplier for noise generation
-	randMult = 75
+	// BrownBands is the number of octave bands for brown noise generation
+	brownBands = 12
+	// NoiseRange is the peak value of a raw random sample
+	noiseRange = 65535
+	// NoiseGamma is the SplitMix64 stream increment (golden ratio)
+	noiseGamma = 0x9E3779B97F4A7C15
 )
 
-// NoiseGenerator handles all noise generation
-type NoiseGenerator struct {
-	// Pink noise state
-	noiseTables    [noiseBands]pinkNoise
-	noiseOffset    int
-	noiseBuffer    [256]int
-	noiseBufferOff uint8
-
-	// Random seed (shared across all noise types)
-	seed int
+// brownWeights holds the per-band weights for brown noise (+3dB per octave band)
+var brownWeights, brownWeightSum = initBrownWeights()
 
-	// Brown noise state
-	brownLast int
+// NoiseGenerator handles noise generation for a single channel.
+// Every sample is derived from the seed, the channel and the frame
+// position, so the output is reproducible and can be positioned at any frame.
+type NoiseGenerator struct {
+	// Random streams (index 0 is the base stream, 1.. are octave bands)
+	streams [brownBands + 1]uint64
+	// Current frame position
+	pos int64
+	// Octave band state (shared by pink and brown noise)
+	bands [brownBands]noiseBand
 }
 
-// pinkNoise represents a pink noise generator state
-type pinkNoise struct {
-	// Current output value
-	value int
-	// Increment
-	increment int
+// noiseBand represents an octave band interpolating between random points
+type noiseBand struct {
+	// Segment index the cached points belong to
+	index int64
+	// Random points at the start and end of the segment
+	from, to int
 }
 
-// NewNoiseGenerator creates a new noise generator with initial seed
-func NewNoiseGenerator() *NoiseGenerator {
-	return &NoiseGenerator{
-		// Initial seed
-		seed:      2,
-		brownLast: 0,
+// NewNoiseGenerator creates a new noise generator for the given seed and channel
+func NewNoiseGenerator(seed int64, channel int) *NoiseGenerator {
+	ng := &NoiseGenerator{}
+
+	base := splitmix64(uint64(seed) ^ splitmix64(uint64(channel)))
+	for i := range ng.streams {
+		ng.streams[i] = splitmix64(base + uint64(i)*noiseGamma)
+	}
+	for i := range ng.bands {
+		ng.bands[i].index = math.MinInt64
 	}
+
+	return ng
 }
 
-// Generate generates a noise sample based on the track type
+// Generate generates a noise sample based on the track type and advances one frame
 func (ng *NoiseGenerator) Generate(tr t.TrackType) int {
+	var val int
+
 	switch tr {
 	case t.TrackWhiteNoise:
-		return ng.generateWhiteNoise()
+		val = ng.generateWhiteNoise()
 	case t.TrackPinkNoise:
-		return ng.generatePinkNoise()
+		val = ng.generatePinkNoise()
 	case t.TrackBrownNoise:
-		return ng.generateBrownNoise()
-	default:
-		return 0
+		val = ng.generateBrownNoise()
 	}
+
+	ng.pos++
+	return val
+}
+
+// SetPosition moves the generator to the given frame position
+func (ng *NoiseGenerator) SetPosition(frame int64) {
+	ng.pos = frame
+}
+
+// Position returns the current frame position
+func (ng *NoiseGenerator) Position() int64 {
+	return ng.pos
 }
 
 // generateWhiteNoise generates white noise sample
 func (ng *NoiseGenerator) generateWhiteNoise() int {
 	// White noise is simply a random value without filtering
-	// return ((seed = seed * RAND_MULT % 131074) - 65535) * (ST_AMP / 65535);
-	ng.seed = (ng.seed * randMult) % 131074
-	randomValue := ng.seed - 65535
-	return randomValue * (t.WaveTableAmplitude / 65535)
+	return ng.random(0, ng.pos) * (t.WaveTableAmplitude / noiseRange)
 }
 
-// generateBrownNoise generates brown noise sample
-func (ng *NoiseGenerator) generateBrownNoise() int {
-	// Generate a random value
-	ng.seed = (ng.seed * randMult) % 131074
-	random := ng.seed - 65535
-
-	// Integrate the random value with a decay factor to avoid overflow
-	ng.brownLast = int(float64(ng.brownLast+random/16) * 0.9)
+// generatePinkNoise generates pink noise sample
+func (ng *NoiseGenerator) generatePinkNoise() int {
+	const scale = noiseAmplitude / noiseRange / (noiseBands + 1)
 
-	// Limit the value to avoid overflow
-	if ng.brownLast > 65535 {
-		ng.brownLast = 65535
+	// Base random value plus octave bands of equal weight (-3dB per octave)
+	tot := ng.random(0, ng.pos) * scale
+	for b := range noiseBands {
+		tot += ng.band(b) * scale
 	}
-	if ng.brownLast < -65535 {
-		ng.brownLast = -65535
+
+	return tot >> noiseShift
+}
+
+// generateBrownNoise generates brown noise sample
+func (ng *NoiseGenerator) generateBrownNoise() int {
+	// Octave bands weighted +3dB per octave towards low frequencies (-6dB per octave)
+	var tot int64
+	for b := range brownBands {
+		tot += brownWeights[b] * int64(ng.band(b))
 	}
 
 	// Scale to the same level as the wave table
-	return ng.brownLast * (t.WaveTableAmplitude / 65535)
+	return int(tot/brownWeightSum) * (t.WaveTableAmplitude / noiseRange)
 }
 
-// generatePinkNoise generates pink noise sample
-func (ng *NoiseGenerator) generatePinkNoise() int {
-	var tot int
-	off := ng.noiseOffset
-	ng.noiseOffset++
-	cnt := 1
-	ns := 0 // index into noiseTables
-
-	// Generate base random value
-	ng.seed = (ng.seed * randMult) % 131074
-	tot = (ng.seed - 65535) * (noiseAmplitude / 65535 / (noiseBands + 1))
-
-	// Process noise bands
-	for (cnt&off) != 0 && ns < noiseBands {
-		ng.seed = (ng.seed * randMult) % 131074
-		val := (ng.seed - 65535) * (noiseAmplitude / 65535 / (noiseBands + 1))
-
-		cnt += cnt
-		ng.noiseTables[ns].increment = (val - ng.noiseTables[ns].value) / cnt
-		ng.noiseTables[ns].value += ng.noiseTables[ns].increment
-		tot += ng.noiseTables[ns].value
-		ns++
+// band returns the value of an octave band at the current position.
+// Band b linearly interpolates between random points 2^(b+1) frames apart.
+func (ng *NoiseGenerator) band(b int) int {
+	shift := uint(b + 1)
+	index := ng.pos >> shift
+
+	nb := &ng.bands[b]
+	if nb.index != index {
+		if nb.index+1 == index {
+			nb.from = nb.to
+		} else {
+			nb.from = ng.random(b+1, index)
+		}
+		nb.to = ng.random(b+1, index+1)
+		nb.index = index
 	}
 
-	// Add remaining noise bands
-	for ns < noiseBands {
-		ng.noiseTables[ns].value += ng.noiseTables[ns].increment
-		tot += ng.noiseTables[ns].value
-		ns++
-	}
+	frac := ng.pos & (1<<shift - 1)
+	return nb.from + int(int64(nb.to-nb.from)*frac>>shift)
+}
 
-	// Store in buffer and return scaled value
-	ng.noiseBuffer[ng.noiseBufferOff] = tot >> noiseShift
-	ng.noiseBufferOff++
+// random returns the raw random value (-65535..65535) of a stream at the given index
+func (ng *NoiseGenerator) random(stream int, index int64) int {
+	h := splitmix64(ng.streams[stream] + uint64(index)*noiseGamma)
+	return int(h%(2*noiseRange+1)) - noiseRange
+}
 
-	return ng.noiseBuffer[ng.noiseBufferOff-1]
+// splitmix64 is the SplitMix64 output function
+func splitmix64(x uint64) uint64 {
+	x += noiseGamma
+	x = (x ^ (x >> 30)) * 0xBF58476D1CE4E5B9
+	x = (x ^ (x >> 27)) * 0x94D049BB133111EB
+	return x ^ (x >> 31)
+}
+
+// initBrownWeights calculates the integer band weights for brown noise
+func initBrownWeights() ([brownBands]int64, int64) {
+	var weights [brownBands]int64
+	var sum int64
+	for b := range brownBands {
+		weights[b] = int64(math.Round(math.Exp2(float64(b)/2) * 256))
+		sum += weights[b]
+	}
+	return weights, sum
 }
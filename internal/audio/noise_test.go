@@ -18,16 +18,52 @@ import (
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
 
-func genWhiteExpected(n int) []int {
-	// Replicates the LCG and scaling used in generateWhiteNoise
-	s := 2
-	scale := int(t.WaveTableAmplitude / 65535)
-	out := make([]int, n)
-	for i := 0; i < n; i++ {
-		s = (s * randMult) % 131074
-		out[i] = (s - 65535) * scale
-	}
-	return out
+// octaveSlope estimates the spectral slope (dB per octave) of a noise type
+// between minFreq and maxFreq using an averaged Hann-windowed periodogram
+func octaveSlope(ng *NoiseGenerator, typ t.TrackType, sampleRate, minFreq, maxFreq float64) float64 {
+	const segment = 8192
+	const segments = 32
+
+	psd := make([]float64, segment/2)
+	buf := make([]complex128, segment)
+	for range segments {
+		for i := range buf {
+			w := 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/segment)
+			buf[i] = complex(float64(ng.Generate(typ))*w, 0)
+		}
+		fft(buf)
+		for k := range psd {
+			psd[k] += real(buf[k])*real(buf[k]) + imag(buf[k])*imag(buf[k])
+		}
+	}
+
+	// Least-squares fit of band power (dB) against octave number
+	var xs, ys []float64
+	for f := minFreq; f*2 <= maxFreq; f *= 2 {
+		lo := int(f / sampleRate * segment)
+		hi := int(2 * f / sampleRate * segment)
+		var p float64
+		for k := lo; k < hi; k++ {
+			p += psd[k]
+		}
+		xs = append(xs, math.Log2(f))
+		ys = append(ys, 10*math.Log10(p/float64(hi-lo)))
+	}
+
+	var mx, my float64
+	for i := range xs {
+		mx += xs[i]
+		my += ys[i]
+	}
+	mx /= float64(len(xs))
+	my /= float64(len(ys))
+
+	var num, den float64
+	for i := range xs {
+		num += (xs[i] - mx) * (ys[i] - my)
+		den += (xs[i] - mx) * (xs[i] - mx)
+	}
+	return num / den
 }
 
 func meanAbsDelta(vals []int) float64 {
@@ -45,22 +81,79 @@ func meanAbsDelta(vals []int) float64 {
 	return sum / float64(len(vals)-1)
 }
 
-func TestNoise_White_DeterministicFirstValues(ts *testing.T) {
-	ng := NewNoiseGenerator()
-	exp := genWhiteExpected(8)
-	got := make([]int, len(exp))
-	for i := range got {
-		got[i] = ng.Generate(t.TrackWhiteNoise)
+func TestNoise_SpectralSlope(ts *testing.T) {
+	tests := []struct {
+		typ  t.TrackType
+		want float64 // dB per octave
+	}{
+		{t.TrackWhiteNoise, 0},
+		{t.TrackPinkNoise, -3},
+		{t.TrackBrownNoise, -6},
+	}
+
+	for _, test := range tests {
+		slope := octaveSlope(NewNoiseGenerator(1, 0), test.typ, 44100, 100, 6400)
+		if math.Abs(slope-test.want) > 1 {
+			ts.Errorf("%v: spectral slope %.2f dB/octave, want %.2f +/- 1", test.typ, slope, test.want)
+		}
+	}
+}
+
+func TestNoise_SeekMatchesSequential(ts *testing.T) {
+	types := []t.TrackType{t.TrackWhiteNoise, t.TrackPinkNoise, t.TrackBrownNoise}
+	for _, typ := range types {
+		ng := NewNoiseGenerator(42, 3)
+		seq := make([]int, 20000)
+		for i := range seq {
+			seq[i] = ng.Generate(typ)
+		}
+
+		for _, frame := range []int64{0, 1, 255, 4096, 12345, 19000} {
+			sk := NewNoiseGenerator(42, 3)
+			sk.SetPosition(frame)
+			for i := frame; i < frame+500 && i < int64(len(seq)); i++ {
+				if v := sk.Generate(typ); v != seq[i] {
+					ts.Fatalf("%v: seek to %d differs at frame %d: got %d want %d", typ, frame, i, v, seq[i])
+				}
+			}
+			if sk.Position() != min(frame+500, int64(len(seq))) {
+				ts.Fatalf("%v: unexpected position %d after seek to %d", typ, sk.Position(), frame)
+			}
+		}
+	}
+}
+
+func TestNoise_SeedAndChannelIndependence(ts *testing.T) {
+	const N = 1024
+	gen := func(seed int64, channel int) []int {
+		ng := NewNoiseGenerator(seed, channel)
+		out := make([]int, N)
+		for i := range out {
+			out[i] = ng.Generate(t.TrackWhiteNoise)
+		}
+		return out
+	}
+
+	correlation := func(a, b []int) float64 {
+		var ab, aa, bb float64
+		for i := range a {
+			ab += float64(a[i]) * float64(b[i])
+			aa += float64(a[i]) * float64(a[i])
+			bb += float64(b[i]) * float64(b[i])
+		}
+		return ab / math.Sqrt(aa*bb)
 	}
-	for i := range exp {
-		if got[i] != exp[i] {
-			ts.Fatalf("white[%d]: got %d want %d", i, got[i], exp[i])
+
+	base := gen(7, 0)
+	for _, other := range [][]int{gen(8, 0), gen(7, 1), gen(-7, 0)} {
+		if c := correlation(base, other); math.Abs(c) > 0.1 {
+			ts.Fatalf("expected independent streams, got correlation %.3f", c)
 		}
 	}
 }
 
 func TestNoise_BoundsAndSign(ts *testing.T) {
-	ng := NewNoiseGenerator()
+	ng := NewNoiseGenerator(0, 0)
 	N := 16384
 	types := []t.TrackType{t.TrackWhiteNoise, t.TrackPinkNoise, t.TrackBrownNoise}
 	for _, typ := range types {
@@ -87,21 +180,21 @@ func TestNoise_SmoothnessComparison(ts *testing.T) {
 	N := 16384
 
 	// White
-	ngW := NewNoiseGenerator()
+	ngW := NewNoiseGenerator(0, 0)
 	white := make([]int, N)
 	for i := 0; i < N; i++ {
 		white[i] = ngW.Generate(t.TrackWhiteNoise)
 	}
 
 	// Pink
-	ngP := NewNoiseGenerator()
+	ngP := NewNoiseGenerator(0, 0)
 	pink := make([]int, N)
 	for i := 0; i < N; i++ {
 		pink[i] = ngP.Generate(t.TrackPinkNoise)
 	}
 
 	// Brown
-	ngB := NewNoiseGenerator()
+	ngB := NewNoiseGenerator(0, 0)
 	brown := make([]int, N)
 	for i := 0; i < N; i++ {
 		brown[i] = ngB.Generate(t.TrackBrownNoise)
@@ -126,8 +219,8 @@ func TestNoise_DeterminismAcrossInstances(ts *testing.T) {
 	N := 2048
 	types := []t.TrackType{t.TrackWhiteNoise, t.TrackPinkNoise, t.TrackBrownNoise}
 	for _, typ := range types {
-		ng1 := NewNoiseGenerator()
-		ng2 := NewNoiseGenerator()
+		ng1 := NewNoiseGenerator(1234, 2)
+		ng2 := NewNoiseGenerator(1234, 2)
 		for i := 0; i < N; i++ {
 			v1 := ng1.Generate(typ)
 			v2 := ng2.Generate(typ)
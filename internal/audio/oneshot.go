@@ -0,0 +1,80 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// oneShotDampMs is the fade of a cue or a ringing bell cut short by the next
+// one of its channel
+const oneShotDampMs = 20
+
+// oneShot is a cue or a bell strike played once from a frame of the output
+type oneShot struct {
+	// Output frames where the sound starts and stops
+	start, end int64
+	// Output frame where the sound starts fading to its end when cut short
+	damp int64
+	// Stereo 16-bit samples of a cue at the output sample rate
+	pcm []int16
+	// Mono samples of a bell strike in the wave table range
+	strike []int32
+	// Amplitude, pan gains and route of the track that triggered the sound
+	amplitude int
+	pan       [2]float64
+	panned    bool
+	route     t.RouteType
+}
+
+// playingOneShots appends the one-shot sounds playing in the buffer starting at a frame
+func (r *AudioRenderer) playingOneShots(playing []*oneShot, frame int64) []*oneShot {
+	for i := range r.oneShots {
+		shot := &r.oneShots[i]
+		if shot.start < frame+t.BufferSize && shot.end > frame {
+			playing = append(playing, shot)
+		}
+	}
+	return playing
+}
+
+// mixOneShot adds the sample of a one-shot sound at a frame to the output bus
+func (r *AudioRenderer) mixOneShot(bus *[maxOutputChannels]int, shot *oneShot, frame int64) {
+	if frame < shot.start || frame >= shot.end {
+		return
+	}
+
+	var left, right int
+	pos := frame - shot.start
+	if shot.strike != nil {
+		left = int(shot.strike[pos]) * shot.amplitude
+		right = left
+	} else {
+		left = int(shot.pcm[2*pos]) * sampleScaleFactor * shot.amplitude
+		right = int(shot.pcm[2*pos+1]) * sampleScaleFactor * shot.amplitude
+	}
+
+	// Fade out a sound cut short by the next one of its channel
+	if frame >= shot.damp {
+		fade := float64(shot.end-frame) / float64(shot.end-shot.damp)
+		left = int(float64(left) * fade)
+		right = int(float64(right) * fade)
+	}
+
+	// Position the sound in the stereo field
+	if shot.panned {
+		left = int(float64(left) * shot.pan[0])
+		right = int(float64(right) * shot.pan[1])
+	}
+
+	r.routing[shot.route].route(bus, left, right)
+}
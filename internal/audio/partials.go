@@ -0,0 +1,100 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// syncPartials updates the amplitude and increments of every partial of a
+// tone. The beat is applied across all partials, each partial is offset from
+// its frequency by the same beat as the carrier. Partials at or above the
+// Nyquist frequency are silent.
+func (r *AudioRenderer) syncPartials(channel *t.Channel) {
+	partials := &channel.Track.Partials
+	gains := partials.Gains()
+	nyquist := float64(r.SampleRate) / 2
+
+	for k := range partials.Count {
+		freq := channel.Track.Carrier * partials.Ratios[k]
+
+		var freq1, freq2 float64
+		switch channel.Track.Type {
+		case t.TrackBinauralBeat, t.TrackMonauralBeat:
+			freq1 = freq + channel.Track.Resonance/2
+			freq2 = freq - channel.Track.Resonance/2
+		default:
+			freq1 = freq
+		}
+
+		channel.PartialAmplitude[k] = int(float64(channel.Track.Amplitude) * gains[k])
+		if max(freq1, freq2) >= nyquist {
+			channel.PartialAmplitude[k] = 0
+		}
+		channel.PartialIncrement[k][0] = int(freq1 / float64(r.SampleRate) * t.SineTableSize * t.PhasePrecision)
+		channel.PartialIncrement[k][1] = int(freq2 / float64(r.SampleRate) * t.SineTableSize * t.PhasePrecision)
+	}
+}
+
+// mixPartials returns the left and right samples of a tone built from partials
+func (r *AudioRenderer) mixPartials(channel *t.Channel, waveIdx int) (int, int) {
+	table := r.waveTables[waveIdx]
+
+	var left, right int
+	for k := range channel.Track.Partials.Count {
+		offset := &channel.PartialOffset[k]
+		increment := &channel.PartialIncrement[k]
+		amp := channel.PartialAmplitude[k]
+
+		offset[0] += increment[0]
+		offset[0] &= (t.SineTableSize << 16) - 1
+
+		switch channel.Track.Type {
+		case t.TrackBinauralBeat:
+			offset[1] += increment[1]
+			offset[1] &= (t.SineTableSize << 16) - 1
+
+			left += amp * table[offset[0]>>16]
+			right += amp * table[offset[1]>>16]
+		case t.TrackMonauralBeat:
+			offset[1] += increment[1]
+			offset[1] &= (t.SineTableSize << 16) - 1
+
+			mixed := amp / 2 * (table[offset[0]>>16] + table[offset[1]>>16])
+			left += mixed
+			right += mixed
+		default:
+			left += amp * table[offset[0]>>16]
+			right += amp * table[offset[0]>>16]
+		}
+	}
+
+	// Isochronic tones pulse all partials together
+	if channel.Track.Type == t.TrackIsochronicBeat {
+		channel.Offset[1] += channel.Increment[1]
+		channel.Offset[1] &= (t.SineTableSize << 16) - 1
+
+		modFactor := r.calcPulseFactor(channel)
+		left = int(float64(left) * modFactor)
+		right = left
+	}
+
+	return left, right
+}
+
+// advancePartials moves the partials of a tone forward by a number of frames
+func advancePartials(channel *t.Channel, frames int) {
+	for k := range channel.Track.Partials.Count {
+		advancePhase(&channel.PartialOffset[k][0], channel.PartialIncrement[k][0], frames)
+		advancePhase(&channel.PartialOffset[k][1], channel.PartialIncrement[k][1], frames)
+	}
+}
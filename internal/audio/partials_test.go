@@ -0,0 +1,133 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// renderEars renders a single track for a second and returns the left and right samples
+func renderEars(ts *testing.T, tr t.Track) ([]int, []int) {
+	ts.Helper()
+
+	var p0, p1 t.Period
+	p0.TrackStart[0] = tr
+	p0.TrackEnd[0] = tr
+	p1.Time = 1000
+
+	r, err := NewAudioRenderer([]t.Period{p0, p1}, &AudioRendererOptions{SampleRate: 44100, Volume: 100})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	var left, right []int
+	if err := r.Render(func(samples []int) error {
+		for i := 0; i < len(samples); i += audioChannels {
+			left = append(left, samples[i])
+			right = append(right, samples[i+1])
+		}
+		return nil
+	}); err != nil {
+		ts.Fatalf("Render failed: %v", err)
+	}
+	return left, right
+}
+
+func TestAudioRenderer_BinauralHarmonics(ts *testing.T) {
+	tr := t.Track{Type: t.TrackBinauralBeat, Carrier: 200, Resonance: 10, Amplitude: t.AmplitudePercentToRaw(50), Partials: t.HarmonicPartials(3)}
+	left, right := renderEars(ts, tr)
+
+	// Every partial carries the beat, split across the ears
+	for _, ear := range []struct {
+		name    string
+		samples []int
+		offset  float64
+	}{{"left", left, 5}, {"right", right, -5}} {
+		fundamental := goertzelPower(ear.samples, 200+ear.offset, 44100)
+		for k := 2; k <= 3; k++ {
+			freq := float64(k)*200 + ear.offset
+			p := goertzelPower(ear.samples, freq, 44100)
+			if ratio := p / fundamental; ratio < 0.8 || ratio > 1.25 {
+				ts.Errorf("%s: expected partial at %.0f Hz at the level of the fundamental, power ratio %.3f", ear.name, freq, ratio)
+			}
+		}
+		if p := goertzelPower(ear.samples, float64(2*200)-ear.offset, 44100); p > fundamental/1000 {
+			ts.Errorf("%s: unexpected energy on the other ear partial, power ratio %.3g", ear.name, p/fundamental)
+		}
+	}
+}
+
+func TestAudioRenderer_PartialsRolloff(ts *testing.T) {
+	partials := t.HarmonicPartials(2)
+	partials.Rolloff = 6
+	tr := t.Track{Type: t.TrackPureTone, Carrier: 300, Amplitude: t.AmplitudePercentToRaw(50), Partials: partials}
+	left, _ := renderEars(ts, tr)
+
+	// The octave is 6 dB down
+	drop := 10 * math.Log10(goertzelPower(left, 300, 44100)/goertzelPower(left, 600, 44100))
+	if math.Abs(drop-6) > 0.3 {
+		ts.Errorf("expected the octave 6 dB down, got %.2f dB", drop)
+	}
+
+	// The partials together peak at the tone amplitude
+	peak := 0
+	for _, v := range left {
+		peak = max(peak, abs(v))
+	}
+	full := int(tr.Amplitude) * t.WaveTableAmplitude >> audioBitShift
+	if peak > full || peak < full*8/10 {
+		ts.Errorf("expected a peak close to %d, got %d", full, peak)
+	}
+}
+
+func TestAudioRenderer_MonauralChord(ts *testing.T) {
+	tr := t.Track{Type: t.TrackMonauralBeat, Carrier: 240, Resonance: 8, Amplitude: t.AmplitudePercentToRaw(50), Partials: t.ChordPartials(t.ChordMajor)}
+	left, right := renderEars(ts, tr)
+
+	for i := range left {
+		if left[i] != right[i] {
+			ts.Fatalf("frame %d: expected the same sample on both ears, got %d %d", i, left[i], right[i])
+		}
+	}
+
+	// Both beating tones of every note are present (4:5:6 on 240 Hz)
+	reference := goertzelPower(left, 244, 44100)
+	for _, freq := range []float64{236, 296, 304, 356, 364} {
+		if p := goertzelPower(left, freq, 44100); p < reference/2 {
+			ts.Errorf("expected a tone at %.0f Hz, power ratio %.3f", freq, p/reference)
+		}
+	}
+}
+
+func TestSyncPartials_Nyquist(ts *testing.T) {
+	r := &AudioRenderer{AudioRendererOptions: &AudioRendererOptions{SampleRate: 8000}}
+
+	channel := t.Channel{Track: t.Track{Type: t.TrackPureTone, Carrier: 1500, Amplitude: 4096, Partials: t.HarmonicPartials(4)}}
+	r.syncPartials(&channel)
+
+	// 4500 and 6000 Hz would alias at 8 kHz
+	for k, audible := range []bool{true, true, false, false} {
+		if (channel.PartialAmplitude[k] != 0) != audible {
+			ts.Errorf("partial %d: unexpected amplitude %d", k+1, channel.PartialAmplitude[k])
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
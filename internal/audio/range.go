@@ -0,0 +1,142 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"fmt"
+	"math"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// checkRange checks a time range in ms against the length of the sequence,
+// an end of 0 being the end of the sequence
+func checkRange(startMs, endMs, sequenceMs int) error {
+	seconds := func(ms int) float64 {
+		return float64(ms) / 1000
+	}
+
+	if startMs < 0 || endMs < 0 {
+		return fmt.Errorf("range times cannot be negative")
+	}
+	if startMs > 0 && startMs >= sequenceMs {
+		return fmt.Errorf("range start (%.3fs) must be before the end of the sequence (%.3fs)", seconds(startMs), seconds(sequenceMs))
+	}
+	if endMs > sequenceMs {
+		return fmt.Errorf("range end (%.3fs) is past the end of the sequence (%.3fs)", seconds(endMs), seconds(sequenceMs))
+	}
+	if endMs != 0 && endMs <= startMs {
+		return fmt.Errorf("range end (%.3fs) must be after its start (%.3fs)", seconds(endMs), seconds(startMs))
+	}
+
+	return nil
+}
+
+// frameAt returns the frame of a time in ms
+func (r *AudioRenderer) frameAt(ms int) int64 {
+	return int64(math.Round(float64(ms) * float64(r.SampleRate) / 1000.0))
+}
+
+// position moves the renderer to a buffer aligned frame without mixing and
+// returns the period of the frame. The oscillators are advanced as in a
+// render, noise follows the frame and backgrounds are positioned directly.
+func (r *AudioRenderer) position(frame int64) (int, error) {
+	periodIdx := r.skip(0, frame, 0)
+
+	for _, bg := range r.backgroundAudio {
+		if err := bg.seek(frame); err != nil {
+			return 0, err
+		}
+	}
+
+	return periodIdx, nil
+}
+
+// skip advances the oscillators over the buffers between two frames without
+// mixing, and returns the period reached.
+//
+// The phase increments are held over each buffer, so a phase is the sum of
+// the increments of every buffer. In a period whose tracks hold steady they
+// are the same in every buffer and the period is skipped in one step. In a
+// transition they follow the curve and are rounded buffer by buffer, which
+// has no closed form: the buffers are walked to stay bit-identical with a
+// full render.
+func (r *AudioRenderer) skip(from, to int64, periodIdx int) int {
+	// The last buffer is skipped whole, as it is mixed
+	to = (to + t.BufferSize - 1) / t.BufferSize * t.BufferSize
+
+	for frame := from; frame < to; {
+		var currentTimeMs int
+		currentTimeMs, periodIdx = r.periodAt(frame, periodIdx)
+		r.sync(currentTimeMs, periodIdx)
+
+		next := frame + t.BufferSize
+		if r.steady(periodIdx) {
+			next = min(to, r.periodEnd(periodIdx))
+		}
+		r.advance(int(next - frame))
+		frame = next
+	}
+	return periodIdx
+}
+
+// steady reports whether every track of a period holds the same state from
+// its start to its end
+func (r *AudioRenderer) steady(periodIdx int) bool {
+	period := &r.periods[periodIdx]
+	return period.TrackStart == period.TrackEnd
+}
+
+// periodEnd returns the first buffer aligned frame after a period
+func (r *AudioRenderer) periodEnd(periodIdx int) int64 {
+	if periodIdx+1 >= len(r.periods) {
+		return math.MaxInt64
+	}
+
+	next := r.periods[periodIdx+1].Time
+	frame := r.frameAt(next) / t.BufferSize * t.BufferSize
+	for frame > 0 {
+		if ms, _ := r.periodAt(frame-t.BufferSize, periodIdx); ms < next {
+			break
+		}
+		frame -= t.BufferSize
+	}
+	for {
+		if ms, _ := r.periodAt(frame, periodIdx); ms >= next {
+			return frame
+		}
+		frame += t.BufferSize
+	}
+}
+
+// fadeEdges fades in and out the frames of a buffer starting at the given
+// frame that are within the fade length of the range edges
+func (r *AudioRenderer) fadeEdges(data []int, frame, start, end, fade int64) {
+	n := int64(r.outputChannels)
+	frames := int64(len(data)) / n
+	if frame >= start+fade && frame+frames <= end-fade {
+		return
+	}
+
+	for i := range frames {
+		edge := min(frame+i-start, end-1-(frame+i))
+		if edge >= fade {
+			continue
+		}
+
+		// Raised cosine, silent at the edge
+		g := 0.5 - 0.5*math.Cos(math.Pi*(float64(edge)+0.5)/float64(fade))
+		for o := range n {
+			data[i*n+o] = int(float64(data[i*n+o]) * g)
+		}
+	}
+}
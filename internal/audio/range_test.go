@@ -0,0 +1,231 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// renderRange renders a time range of the segment test periods
+func renderRange(ts *testing.T, opts AudioRendererOptions, startMs, endMs int) []int {
+	ts.Helper()
+
+	r, err := NewAudioRenderer(segmentTestPeriods(), &opts)
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+	r.segmentBuffers = 5
+
+	var out []int
+	if err := r.RenderRange(startMs, endMs, func(samples []int) error {
+		out = append(out, samples...)
+		return nil
+	}); err != nil {
+		ts.Fatalf("RenderRange(%d, %d) failed: %v", startMs, endMs, err)
+	}
+	return out
+}
+
+func TestAudioRenderer_RenderRange_MatchesFullRender(ts *testing.T) {
+	opts := AudioRendererOptions{
+		SampleRate:     44100,
+		Volume:         100,
+		Seed:           3,
+		Dither:         t.DitherTPDF,
+		BackgroundPath: filepath.Join("testdata", "noise.wav"),
+		BackgroundLoop: t.BackgroundLoop{Start: 0.5, Crossfade: 0.25, Offset: 1},
+		Backgrounds:    []t.BackgroundSource{{Name: "rain", Path: writeSineWav(ts, 22050, 1, 441)}},
+		Cues:           []t.CueSource{{Name: "bell", Path: writeSineWav(ts, 22050, 1, 441)}},
+	}
+
+	full, _ := renderAll(ts, opts, 1, renderSegmentBuffers)
+	n := audioChannels
+
+	ranges := [][2]int{
+		{0, 0},
+		{0, 1000},
+		{1234, 1500},
+		{3999, 8123},
+		{6500, 0},
+		{8999, 9000},
+	}
+	for _, workers := range []int{1, 3} {
+		opts.Workers = workers
+		for _, rg := range ranges {
+			got := renderRange(ts, opts, rg[0], rg[1])
+
+			end := rg[1]
+			if end == 0 {
+				end = 9000
+			}
+			from := int(math.Round(float64(rg[0])*44.1)) * n
+			to := int(math.Round(float64(end)*44.1)) * n
+			if !slices.Equal(got, full[from:to]) {
+				ts.Fatalf("workers %d: range %v does not match the full render (%d samples, expected %d)",
+					workers, rg, len(got), to-from)
+			}
+		}
+	}
+}
+
+func TestAudioRenderer_RenderRange_ShapedDitherWarmUp(ts *testing.T) {
+	opts := AudioRendererOptions{SampleRate: 44100, Volume: 100, Seed: 3, Dither: t.DitherShaped}
+	full, _ := renderAll(ts, opts, 1, renderSegmentBuffers)
+	n := audioChannels
+
+	// From the start, the shaping matches the full render
+	if got := renderRange(ts, opts, 0, 1000); !slices.Equal(got, full[:44100*n]) {
+		ts.Fatalf("range from the start does not match the full render")
+	}
+
+	// Later ranges warm the shaping up over the buffers before them, they only
+	// differ from the full render within the shaped noise
+	for _, workers := range []int{1, 3} {
+		opts.Workers = workers
+		got := renderRange(ts, opts, 3999, 8123)
+		from := int(math.Round(3999*44.1)) * n
+		to := int(math.Round(8123*44.1)) * n
+		if len(got) != to-from {
+			ts.Fatalf("workers %d: expected %d samples, got %d", workers, to-from, len(got))
+		}
+
+		maxDiff := 0
+		for i, v := range got {
+			maxDiff = max(maxDiff, abs(v-full[from+i]))
+		}
+		if maxDiff > 6 {
+			ts.Fatalf("workers %d: expected the range within the shaped noise of the full render, got a difference of %d", workers, maxDiff)
+		}
+	}
+}
+
+func TestAudioRenderer_Skip_MatchesBufferWalk(ts *testing.T) {
+	// Steady periods ending inside a buffer, then a slide and an empty period
+	var p0, p1, p2, p3, pEnd t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 200, Resonance: 10, Amplitude: t.AmplitudePercentToRaw(10)}
+	p0.TrackStart[1] = t.Track{Type: t.TrackFMTone, Carrier: 330, Resonance: 3, Depth: 40, Amplitude: t.AmplitudePercentToRaw(10)}
+	p0.TrackStart[2] = t.Track{Type: t.TrackPinkNoise, Amplitude: t.AmplitudePercentToRaw(10), Resonance: 0.3, Carrier: 200, Effect: t.Effect{Type: t.EffectSpin, Intensity: 0.6}}
+	p0.TrackStart[3] = t.Track{Type: t.TrackIsochronicBeat, Carrier: 220, Resonance: 7, Amplitude: t.AmplitudePercentToRaw(10), Partials: t.HarmonicPartials(3)}
+	p0.TrackEnd = p0.TrackStart
+	p1.Time = 1234
+	p1.TrackStart = p0.TrackEnd
+	p1.TrackStart[0].Carrier = 250
+	p1.TrackStart[1].Resonance = 5
+	p1.TrackStart[2].Resonance = 0.5
+	p1.TrackStart[3].Carrier = 180
+	p1.TrackEnd = p1.TrackStart
+	p2.Time = 2500
+	p2.TrackStart = p1.TrackEnd
+	p2.TrackEnd = p2.TrackStart
+	p2.TrackEnd[0].Carrier = 150
+	p2.Transition = t.TransitionSmooth
+	p3.Time = 4000
+	pEnd.Time = 5000
+	periods := []t.Period{p0, p1, p2, p3, pEnd}
+
+	newRenderer := func() *AudioRenderer {
+		r, err := NewAudioRenderer(periods, &AudioRendererOptions{SampleRate: 44100, Volume: 100})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+		return r
+	}
+
+	// Within and past each period, past the end of the sequence
+	for _, to := range []int64{30720, 54272, 55296, 100000, 140288, 180000, 200704, 1 << 20} {
+		skipped := newRenderer()
+		skippedIdx := skipped.skip(0, to, 0)
+
+		walked := newRenderer()
+		walkedIdx := 0
+		for frame := int64(0); frame < to; frame += t.BufferSize {
+			var ms int
+			ms, walkedIdx = walked.periodAt(frame, walkedIdx)
+			walked.sync(ms, walkedIdx)
+			walked.advance(t.BufferSize)
+		}
+
+		if skippedIdx != walkedIdx || !reflect.DeepEqual(skipped.channels, walked.channels) {
+			ts.Fatalf("skipping to frame %d differs from walking the buffers (period %d, expected %d)", to, skippedIdx, walkedIdx)
+		}
+	}
+}
+
+func TestAudioRenderer_RenderRange_EdgeFade(ts *testing.T) {
+	opts := AudioRendererOptions{SampleRate: 44100, Volume: 100, Seed: 3}
+	plain := renderRange(ts, opts, 2000, 3000)
+
+	opts.EdgeFade = 100
+	faded := renderRange(ts, opts, 2000, 3000)
+
+	if len(plain) != len(faded) {
+		ts.Fatalf("expected %d samples, got %d", len(plain), len(faded))
+	}
+
+	fade := 4410 * audioChannels
+	if !slices.Equal(plain[fade:len(plain)-fade], faded[fade:len(faded)-fade]) {
+		ts.Fatalf("expected the middle of the range to be untouched")
+	}
+
+	peak := func(samples []int) int {
+		p := 0
+		for _, v := range samples {
+			p = max(p, v, -v)
+		}
+		return p
+	}
+	edge := 20 * audioChannels
+	if p := peak(faded[:edge]); p > peak(plain[:edge])/50 {
+		ts.Errorf("expected the fade in to start from silence, got peak %d", p)
+	}
+	if p := peak(faded[len(faded)-edge:]); p > peak(plain[len(plain)-edge:])/50 {
+		ts.Errorf("expected the fade out to end in silence, got peak %d", p)
+	}
+}
+
+func TestAudioRenderer_RenderRange_Errors(ts *testing.T) {
+	tests := map[string][2]int{
+		"negative start":   {-1, 0},
+		"start past end":   {9000, 0},
+		"end past end":     {0, 9001},
+		"end before start": {3000, 2000},
+	}
+
+	for name, rg := range tests {
+		if _, err := NewAudioRenderer(segmentTestPeriods(), &AudioRendererOptions{
+			SampleRate: 44100,
+			Volume:     100,
+			Start:      rg[0],
+			End:        rg[1],
+		}); err == nil {
+			ts.Errorf("%s: expected error from the options", name)
+		}
+
+		r, err := NewAudioRenderer(segmentTestPeriods(), &AudioRendererOptions{SampleRate: 44100, Volume: 100})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+		if err := r.RenderRange(rg[0], rg[1], nil); err == nil {
+			ts.Errorf("%s: expected error from RenderRange", name)
+		}
+	}
+
+	if _, err := NewAudioRenderer(segmentTestPeriods(), &AudioRendererOptions{SampleRate: 44100, Volume: 100, EdgeFade: -5}); err == nil {
+		ts.Errorf("expected error for a negative edge fade")
+	}
+}
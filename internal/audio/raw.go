@@ -13,18 +13,25 @@ package audio
 
 import (
 	"bufio"
+	"context"
 	"io"
 
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
 
-// RenderRaw renders the audio to a raw PCM stream (16-bit little-endian)
+// RenderRaw renders the audio to a raw PCM stream (16-bit little-endian),
+// with the channels of the layout interleaved in WAVE_FORMAT_EXTENSIBLE order
 func (r *AudioRenderer) RenderRaw(w io.Writer) error {
+	return r.RenderRawContext(context.Background(), w)
+}
+
+// RenderRawContext is RenderRaw stopped when the context is done
+func (r *AudioRenderer) RenderRawContext(ctx context.Context, w io.Writer) error {
 	bw := bufio.NewWriter(w)
 	// 2 bytes per sample (16-bit)
-	out := make([]byte, t.BufferSize*audioChannels*2)
+	out := make([]byte, t.BufferSize*r.outputChannels*2)
 
-	err := r.Render(func(samples []int) error {
+	err := r.RenderContext(ctx, func(samples []int) error {
 		need := len(samples) * 2
 		if cap(out) < need {
 			out = make([]byte, need)
@@ -12,9 +12,11 @@
 package audio
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"math"
+	"runtime"
 
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
@@ -29,11 +31,34 @@ const (
 
 // AudioRenderer handle audio generation
 type AudioRenderer struct {
-	channels        [t.NumberOfChannels]t.Channel
-	periods         []t.Period
-	waveTables      [4][]int
-	noiseGenerator  *NoiseGenerator
-	backgroundAudio *BackgroundAudio
+	channels   [t.NumberOfChannels]t.Channel
+	periods    []t.Period
+	waveTables [][]int
+	// Index into the wave tables of every user-defined waveform name
+	customWaves     map[string]int
+	noiseGenerators [t.NumberOfChannels]*NoiseGenerator
+	// Background audio per source name (empty name is the default background)
+	backgroundAudio map[string]*BackgroundAudio
+	// Background samples per source name for the current buffer
+	backgroundSamples map[string][]int
+	// Cues and bell strikes triggered by the timeline
+	oneShots []oneShot
+	// Samples that exceeded full scale on the master bus
+	clipStats ClipStats
+	// Linear factor of the normalization gain
+	masterGain float64
+	// Number of interleaved output channels of the layout
+	outputChannels int
+	// Output channels of every track route
+	routing [t.RouteAll + 1]speakerRouting
+	// Ear balance gain of every output channel
+	balanceGains []float64
+	// Buffers per segment of a parallel render
+	segmentBuffers int
+	// Random stream of the output dither
+	ditherStream uint64
+	// Requantization error of every output channel for noise shaping
+	shaping [maxOutputChannels]shapingState
 
 	// Embedding options
 	*AudioRendererOptions
@@ -45,7 +70,33 @@ type AudioRendererOptions struct {
 	Volume         int
 	GainLevel      t.GainLevel
 	BackgroundPath string
-	StatusOutput   io.Writer
+	// Loop settings of the background audio
+	BackgroundLoop t.BackgroundLoop
+	// Named background sources, decoded and looped independently
+	Backgrounds []t.BackgroundSource
+	// Named one-shot cues, decoded whole and played from the timeline
+	Cues []t.CueSource
+	// User-defined waveforms, built into extra wave tables
+	Waveforms    []t.WaveformSource
+	StatusOutput io.Writer
+	// Seed for the noise generators (same seed, same output)
+	Seed int64
+	// Ear balance (attenuates the opposite ear, 0 is centered)
+	Balance t.BalanceType
+	// Limiter of the master bus (hard clipping when off)
+	Limiter t.LimiterType
+	// Dither of the reduction to 16 bits, derived from the seed (truncation when off)
+	Dither t.DitherType
+	// Gain of the master bus in dB, applied before the limiter (loudness normalization)
+	NormalizeGain float64
+	// Speaker layout of the output (stereo by default)
+	Layout t.ChannelLayout
+	// Segments of the timeline rendered concurrently (0 uses every CPU, 1 renders serially)
+	Workers int
+	// Time range of the output in ms (an end of 0 is the end of the sequence)
+	Start, End int
+	// Fade at the edges of the time range in ms, for previews
+	EdgeFade int
 }
 
 // NewAudioRenderer creates a new AudioRenderer instance
@@ -62,53 +113,168 @@ func NewAudioRenderer(p []t.Period, ar *AudioRendererOptions) (*AudioRenderer, e
 		return nil, fmt.Errorf("volume must be between 0 and 100, got %d", ar.Volume)
 	}
 
+	if ar.Balance < -1.0 || ar.Balance > 1.0 {
+		return nil, fmt.Errorf("balance must be between -100 and 100, got %.2f", ar.Balance.ToPercent())
+	}
+
+	if math.IsNaN(ar.NormalizeGain) || math.IsInf(ar.NormalizeGain, 0) {
+		return nil, fmt.Errorf("invalid normalization gain: %f", ar.NormalizeGain)
+	}
+
+	if ar.Layout < t.LayoutStereo || ar.Layout > t.Layout51 {
+		return nil, fmt.Errorf("invalid layout: %d", ar.Layout)
+	}
+
+	if ar.Dither < t.DitherOff || ar.Dither > t.DitherShaped {
+		return nil, fmt.Errorf("invalid dither: %d", ar.Dither)
+	}
+
+	if ar.Workers < 0 {
+		return nil, fmt.Errorf("workers cannot be negative, got %d", ar.Workers)
+	}
+
 	if len(p) == 0 {
 		return nil, fmt.Errorf("no periods defined in the sequence")
 	}
 
-	// Initialize background audio
-	backgroundAudio, err := NewBackgroundAudio(ar.BackgroundPath)
-	if err != nil {
+	if err := checkRange(ar.Start, ar.End, p[len(p)-1].Time); err != nil {
 		return nil, err
 	}
 
-	// Validate background audio parameters
-	if backgroundAudio.isEnabled {
-		bgSampleRate := backgroundAudio.sampleRate
-		if bgSampleRate != ar.SampleRate {
-			return nil, fmt.Errorf("background audio sample rate (%d Hz) does not match output sample rate (%d Hz)",
-				bgSampleRate, ar.SampleRate)
+	if ar.EdgeFade < 0 {
+		return nil, fmt.Errorf("edge fade cannot be negative, got %d ms", ar.EdgeFade)
+	}
+
+	// Initialize background audio sources
+	sources := []t.BackgroundSource{{Path: ar.BackgroundPath, Loop: ar.BackgroundLoop}}
+	sources = append(sources, ar.Backgrounds...)
+
+	backgroundAudio := make(map[string]*BackgroundAudio)
+	backgroundSamples := make(map[string][]int)
+	for _, src := range sources {
+		if src.Path == "" {
+			continue
+		}
+
+		bg, err := NewBackgroundAudio(src.Path)
+		if err != nil {
+			closeBackgrounds(backgroundAudio)
+			return nil, err
+		}
+		backgroundAudio[src.Name] = bg
+
+		// Resample and upmix background audio to the output format
+		if err := bg.conform(ar.SampleRate, src); err != nil {
+			closeBackgrounds(backgroundAudio)
+			return nil, err
+		}
+
+		backgroundSamples[src.Name] = make([]int, t.BufferSize*audioChannels) // Stereo
+	}
+
+	// Decode every cue once, cues are short
+	cues := make(map[string][]int16, len(ar.Cues))
+	for _, src := range ar.Cues {
+		pcm, err := loadCue(src, ar.SampleRate)
+		if err != nil {
+			closeBackgrounds(backgroundAudio)
+			return nil, err
 		}
-		bgChannels := backgroundAudio.channels
-		if bgChannels != audioChannels {
-			return nil, fmt.Errorf("background audio must be stereo (%d channels detected)", bgChannels)
+		cues[src.Name] = pcm
+	}
+
+	// User-defined waveforms follow the built-in tables
+	waveTables := InitWaveformTables()
+	customWaves := make(map[string]int, len(ar.Waveforms))
+	for _, src := range ar.Waveforms {
+		table, err := loadWaveform(src)
+		if err != nil {
+			closeBackgrounds(backgroundAudio)
+			return nil, err
 		}
+		customWaves[src.Name] = len(waveTables)
+		waveTables = append(waveTables, table)
 	}
 
 	renderer := &AudioRenderer{
 		periods:              p,
-		waveTables:           InitWaveformTables(),
-		noiseGenerator:       NewNoiseGenerator(),
+		waveTables:           waveTables,
+		customWaves:          customWaves,
 		backgroundAudio:      backgroundAudio,
+		backgroundSamples:    backgroundSamples,
+		masterGain:           math.Pow(10, ar.NormalizeGain/20),
+		outputChannels:       ar.Layout.Channels(),
+		balanceGains:         layoutBalanceGains(ar.Layout, ar.Balance),
+		segmentBuffers:       renderSegmentBuffers,
+		ditherStream:         newDitherStream(ar.Seed),
 		AudioRendererOptions: ar,
 	}
 
+	renderer.oneShots = append(renderer.scheduleCues(cues), renderer.scheduleBells()...)
+
+	// Each route feeds the speakers of the layout
+	for route := range renderer.routing {
+		renderer.routing[route] = newSpeakerRouting(ar.Layout, t.RouteType(route))
+	}
+
+	// Each channel has its own noise stream
+	for ch := range renderer.noiseGenerators {
+		renderer.noiseGenerators[ch] = NewNoiseGenerator(ar.Seed, ch)
+	}
+
 	return renderer, nil
 }
 
-// Render generates the audio and passes buffers to the consume function
+// Render generates the audio of the time range of the options and passes
+// buffers to the consume function
 func (r *AudioRenderer) Render(consume func(samples []int) error) error {
+	return r.RenderRangeContext(context.Background(), r.Start, r.End, consume)
+}
+
+// RenderContext is Render stopped when the context is done, returning the
+// error of the context
+func (r *AudioRenderer) RenderContext(ctx context.Context, consume func(samples []int) error) error {
+	return r.RenderRangeContext(ctx, r.Start, r.End, consume)
+}
+
+// RenderRange generates the audio between two times in ms (an end of 0 is the
+// end of the sequence) and passes buffers to the consume function. The excerpt
+// matches the same span of a full render, faded at its edges when EdgeFade is set.
+// Long ranges are split into segments rendered concurrently, the buffers are
+// passed in order and are identical to a serial render.
+func (r *AudioRenderer) RenderRange(startMs, endMs int, consume func(samples []int) error) error {
+	return r.RenderRangeContext(context.Background(), startMs, endMs, consume)
+}
+
+// RenderRangeContext is RenderRange stopped when the context is done. The
+// context is checked before each buffer, the background decoders are closed
+// and the error of the context is returned.
+func (r *AudioRenderer) RenderRangeContext(ctx context.Context, startMs, endMs int, consume func(samples []int) error) error {
 	// Ensure background audio file is closed if opened
-	defer func() {
-		if r.backgroundAudio != nil {
-			r.backgroundAudio.Close()
-		}
-	}()
+	defer closeBackgrounds(r.backgroundAudio)
+
+	sequenceMs := r.periods[len(r.periods)-1].Time
+	if err := checkRange(startMs, endMs, sequenceMs); err != nil {
+		return err
+	}
+	if endMs == 0 {
+		endMs = sequenceMs
+	}
+
+	startFrame := r.frameAt(startMs)
+	endFrame := r.frameAt(endMs)
+	fadeFrames := min(r.frameAt(r.EdgeFade), (endFrame-startFrame)/2)
 
-	endMs := r.periods[len(r.periods)-1].Time
-	totalFrames := int64(math.Round(float64(endMs) * float64(r.SampleRate) / 1000.0))
-	chunkFrames := int64(t.BufferSize)
-	framesWritten := int64(0)
+	r.clipStats = ClipStats{Limited: r.Limiter == t.LimiterSoft}
+
+	// Buffers start at multiples of the buffer size, as in a full render,
+	// and the noise shaping is warmed up over the buffers before the range
+	firstFrame := startFrame / t.BufferSize * t.BufferSize
+	periodIdx, err := r.position(r.shapingStart(firstFrame))
+	if err != nil {
+		return err
+	}
+	periodIdx = r.warmUp(r.shapingStart(firstFrame), firstFrame, periodIdx)
 
 	var statusReporter *StatusReporter
 	if r.StatusOutput != nil {
@@ -116,29 +282,35 @@ func (r *AudioRenderer) Render(consume func(samples []int) error) error {
 		defer statusReporter.FinalStatus()
 	}
 
-	// Stereo: left + right
-	samples := make([]int, t.BufferSize*audioChannels)
-	periodIdx := 0
-
-	for framesWritten < totalFrames {
-		currentTimeMs := int((float64(framesWritten) * 1000.0) / float64(r.SampleRate))
-		// Find the correct period for the current time
-		for periodIdx+1 < len(r.periods) && currentTimeMs >= r.periods[periodIdx+1].Time {
-			periodIdx++
+	// deliver passes a buffer starting at the given frame, the channels
+	// already synchronized with its time when rendering serially
+	deliver := func(frame int64, data []int, synced bool) error {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
-		r.sync(currentTimeMs, periodIdx)
+		var currentTimeMs int
+		currentTimeMs, periodIdx = r.periodAt(frame, periodIdx)
 		if statusReporter != nil {
+			if !synced {
+				r.sync(currentTimeMs, periodIdx)
+			}
 			statusReporter.CheckPeriodChange(r, periodIdx)
 		}
 
-		data := r.mix(samples)
+		// The first and last buffers are only partly in the range
+		// (interleaved output channels)
+		n := int64(r.outputChannels)
+		if remain := endFrame - frame; remain < t.BufferSize {
+			data = data[:remain*n]
+		}
+		if frame < startFrame {
+			data = data[(startFrame-frame)*n:]
+			frame = startFrame
+		}
 
-		framesToWrite := chunkFrames
-		if remain := totalFrames - framesWritten; remain < chunkFrames {
-			framesToWrite = remain
-			// stereo interleaved
-			data = data[:remain*audioChannels]
+		if fadeFrames > 0 {
+			r.fadeEdges(data, frame, startFrame, endFrame, fadeFrames)
 		}
 
 		if consume != nil {
@@ -147,12 +319,54 @@ func (r *AudioRenderer) Render(consume func(samples []int) error) error {
 			}
 		}
 
-		framesWritten += framesToWrite
-
 		if statusReporter != nil && statusReporter.ShouldUpdateStatus() {
 			statusReporter.DisplayStatus(r, currentTimeMs)
 		}
+		return nil
+	}
+
+	workers := r.Workers
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if workers > 1 && endFrame-firstFrame > int64(r.segmentBuffers*t.BufferSize) {
+		if err := r.renderSegments(firstFrame, endFrame, periodIdx, workers, deliver); err != nil {
+			return err
+		}
+	} else {
+		// Interleaved output channels (stereo: left + right)
+		samples := make([]int, t.BufferSize*r.outputChannels)
+
+		for frame := firstFrame; frame < endFrame; frame += t.BufferSize {
+			currentTimeMs, idx := r.periodAt(frame, periodIdx)
+			r.sync(currentTimeMs, idx)
+
+			if err := deliver(frame, r.output(r.mix(samples, frame), frame), true); err != nil {
+				return err
+			}
+		}
+	}
+
+	if statusReporter != nil {
+		statusReporter.DisplayClipStats(r.clipStats)
 	}
 
 	return nil
 }
+
+// periodAt returns the time in ms of a frame and the index of its period,
+// searching forward from the given period
+func (r *AudioRenderer) periodAt(frame int64, periodIdx int) (int, int) {
+	currentTimeMs := int((float64(frame) * 1000.0) / float64(r.SampleRate))
+	// Find the correct period for the current time
+	for periodIdx+1 < len(r.periods) && currentTimeMs >= r.periods[periodIdx+1].Time {
+		periodIdx++
+	}
+	return currentTimeMs, periodIdx
+}
+
+// ClipStats returns the samples that exceeded full scale in the last render
+func (r *AudioRenderer) ClipStats() ClipStats {
+	return r.clipStats
+}
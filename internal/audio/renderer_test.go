@@ -419,3 +419,346 @@ func TestRenderRaw_PropagatesWriteError(ts *testing.T) {
 		ts.Fatalf("expected error from writer, got nil")
 	}
 }
+
+func TestAudioRenderer_Render_NoiseSeed(ts *testing.T) {
+	var p0, pEnd t.Period
+	p0.Time = 0
+	p0.TrackStart[0] = t.Track{
+		Type:      t.TrackPinkNoise,
+		Amplitude: t.AmplitudePercentToRaw(30),
+	}
+	p0.TrackStart[1] = t.Track{
+		Type:      t.TrackWhiteNoise,
+		Amplitude: t.AmplitudePercentToRaw(10),
+	}
+	p0.TrackEnd = p0.TrackStart
+	pEnd.Time = 200
+	periods := []t.Period{p0, pEnd}
+
+	render := func(seed int64) []int {
+		r, err := NewAudioRenderer(periods, &AudioRendererOptions{SampleRate: 44100, Volume: 100, Seed: seed})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+		var out []int
+		if err := r.Render(func(samples []int) error {
+			out = append(out, samples...)
+			return nil
+		}); err != nil {
+			ts.Fatalf("Render failed: %v", err)
+		}
+		return out
+	}
+
+	a, b, c := render(99), render(99), render(100)
+	if len(a) != len(b) || len(a) != len(c) {
+		ts.Fatalf("length mismatch: %d, %d, %d", len(a), len(b), len(c))
+	}
+
+	same := true
+	for i := range a {
+		if a[i] != b[i] {
+			ts.Fatalf("same seed rendered different output at sample %d: %d vs %d", i, a[i], b[i])
+		}
+		if a[i] != c[i] {
+			same = false
+		}
+	}
+	if same {
+		ts.Fatalf("different seeds rendered identical output")
+	}
+}
+
+func TestAudioRenderer_Render_PanAndBalance(ts *testing.T) {
+	render := func(pan, balance float64) []int {
+		var p0, pEnd t.Period
+		p0.Time = 0
+		p0.TrackStart[0] = t.Track{
+			Type:      t.TrackPureTone,
+			Carrier:   440,
+			Amplitude: t.AmplitudePercentToRaw(20),
+			Waveform:  t.WaveformSine,
+			Pan:       t.PanPercentToRaw(pan),
+		}
+		p0.TrackEnd = p0.TrackStart
+		pEnd.Time = 100
+
+		r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{
+			SampleRate: 44100,
+			Volume:     100,
+			Balance:    t.BalancePercentToRaw(balance),
+		})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+		var out []int
+		if err := r.Render(func(samples []int) error {
+			out = append(out, samples...)
+			return nil
+		}); err != nil {
+			ts.Fatalf("Render failed: %v", err)
+		}
+		return out
+	}
+
+	power := func(samples []int) (float64, float64) {
+		var left, right float64
+		for i := 0; i+1 < len(samples); i += 2 {
+			left += float64(samples[i]) * float64(samples[i])
+			right += float64(samples[i+1]) * float64(samples[i+1])
+		}
+		return left, right
+	}
+
+	// Centered output is unchanged and symmetric
+	cl, cr := power(render(0, 0))
+	if cl == 0 || cl != cr {
+		ts.Fatalf("expected symmetric centered output, got %f / %f", cl, cr)
+	}
+
+	// Hard left silences the right channel
+	ll, lr := power(render(-100, 0))
+	if lr != 0 || ll <= cl {
+		ts.Fatalf("expected hard left pan, got %f / %f", ll, lr)
+	}
+
+	// Constant power: total power is preserved when panning
+	hl, hr := power(render(50, 0))
+	if hr <= hl || math.Abs((hl+hr)-(cl+cr))/(cl+cr) > 0.01 {
+		ts.Fatalf("expected constant power pan, got %f + %f vs %f", hl, hr, cl+cr)
+	}
+
+	// Balance to the right attenuates the left ear only
+	bl, br := power(render(0, 50))
+	if br != cr || math.Abs(bl/cl-0.25) > 0.01 {
+		ts.Fatalf("expected left ear at -6 dB, got %f / %f", bl, br)
+	}
+}
+
+func TestNewAudioRenderer_InvalidBalance(ts *testing.T) {
+	var p0 t.Period
+	if _, err := NewAudioRenderer([]t.Period{p0}, &AudioRendererOptions{SampleRate: 44100, Volume: 100, Balance: 1.5}); err == nil {
+		ts.Fatalf("expected error for out of range balance")
+	}
+}
+
+func TestAudioRenderer_Render_NoiseEffects(ts *testing.T) {
+	render := func(tr t.Track) []int {
+		var p0, pEnd t.Period
+		p0.Time = 0
+		p0.TrackStart[0] = tr
+		p0.TrackEnd = p0.TrackStart
+		pEnd.Time = 2000
+
+		r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{SampleRate: 44100, Volume: 100})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+		var out []int
+		if err := r.Render(func(samples []int) error {
+			out = append(out, samples...)
+			return nil
+		}); err != nil {
+			ts.Fatalf("Render failed: %v", err)
+		}
+		return out
+	}
+
+	// Power of the left and right channels over consecutive windows
+	windows := func(samples []int, size int) ([]float64, []float64) {
+		var left, right []float64
+		for start := 0; start+size*2 <= len(samples); start += size * 2 {
+			var l, r float64
+			for i := start; i < start+size*2; i += 2 {
+				l += float64(samples[i]) * float64(samples[i])
+				r += float64(samples[i+1]) * float64(samples[i+1])
+			}
+			left = append(left, l)
+			right = append(right, r)
+		}
+		return left, right
+	}
+
+	// Spinning noise moves between the ears
+	spin := render(t.Track{
+		Type:      t.TrackPinkNoise,
+		Carrier:   400,
+		Resonance: 1,
+		Amplitude: t.AmplitudePercentToRaw(30),
+		Effect:    t.Effect{Type: t.EffectSpin, Intensity: t.IntensityPercentToRaw(100)},
+	})
+	left, right := windows(spin, 2205)
+	leftLouder, rightLouder := false, false
+	for i := range left {
+		if left[i] > 2*right[i] {
+			leftLouder = true
+		}
+		if right[i] > 2*left[i] {
+			rightLouder = true
+		}
+	}
+	if !leftLouder || !rightLouder {
+		ts.Fatalf("expected spinning noise to move between ears")
+	}
+
+	// Pulsed noise is amplitude modulated in both ears alike
+	pulse := render(t.Track{
+		Type:      t.TrackWhiteNoise,
+		Resonance: 2,
+		Amplitude: t.AmplitudePercentToRaw(30),
+		Effect:    t.Effect{Type: t.EffectPulse, Intensity: t.IntensityPercentToRaw(100)},
+		Envelope:  t.Envelope{Shape: t.EnvelopeSquare, Duty: t.DutyPercentToRaw(50)},
+	})
+	left, right = windows(pulse, 441)
+	minPower, maxPower := math.Inf(1), 0.0
+	for i := range left {
+		if left[i] != right[i] {
+			ts.Fatalf("expected identical ears for pulsed noise at window %d", i)
+		}
+		minPower = math.Min(minPower, left[i])
+		maxPower = math.Max(maxPower, left[i])
+	}
+	if maxPower < 10*minPower {
+		ts.Fatalf("expected deep pulse modulation, got min %f max %f", minPower, maxPower)
+	}
+}
+
+func TestAudioRenderer_Render_NamedBackgrounds(ts *testing.T) {
+	tempDir := ts.TempDir()
+	writeBackground := func(name string, sr int, level float64) string {
+		path := filepath.Join(tempDir, name+".wav")
+		f, err := os.Create(path)
+		if err != nil {
+			ts.Fatalf("Failed to create background file: %v", err)
+		}
+		defer f.Close()
+
+		format := beep.Format{SampleRate: beep.SampleRate(sr), NumChannels: audioChannels, Precision: audioBitDepth / 8}
+		if err := bwav.Encode(f, &constStreamer{framesLeft: sr, val: level / 32768.0}, format); err != nil {
+			ts.Fatalf("Failed to write background: %v", err)
+		}
+		return path
+	}
+
+	defaultPath := writeBackground("default", 44100, 1000)
+	// The rain source is resampled to the output rate at load time
+	rainPath := writeBackground("rain", 22050, 2000)
+
+	render := func(withDefault, withRain bool) float64 {
+		var p0, pEnd t.Period
+		ch := 0
+		if withDefault {
+			p0.TrackStart[ch] = t.Track{Type: t.TrackBackground, Amplitude: t.AmplitudePercentToRaw(50)}
+			ch++
+		}
+		if withRain {
+			p0.TrackStart[ch] = t.Track{Type: t.TrackBackground, Amplitude: t.AmplitudePercentToRaw(50), Source: "rain"}
+		}
+		p0.TrackEnd = p0.TrackStart
+		pEnd.Time = 100
+
+		r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{
+			SampleRate:     44100,
+			Volume:         100,
+			GainLevel:      t.GainLevelOff,
+			BackgroundPath: defaultPath,
+			Backgrounds:    []t.BackgroundSource{{Name: "rain", Path: rainPath}},
+		})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+
+		var sum, n float64
+		if err := r.Render(func(samples []int) error {
+			for _, v := range samples {
+				sum += float64(v)
+				n++
+			}
+			return nil
+		}); err != nil {
+			ts.Fatalf("Render failed: %v", err)
+		}
+		return sum / n
+	}
+
+	def, rain, both := render(true, false), render(false, true), render(true, true)
+	if def <= 0 || math.Abs(rain/def-2) > 0.05 {
+		ts.Fatalf("expected rain background at twice the default level, got %f vs %f", rain, def)
+	}
+	if math.Abs(both-(def+rain))/both > 0.01 {
+		ts.Fatalf("expected both backgrounds to mix independently, got %f vs %f + %f", both, def, rain)
+	}
+}
+
+func TestAudioRenderer_Render_BackgroundGain(ts *testing.T) {
+	path := filepath.Join(ts.TempDir(), "background.wav")
+	f, err := os.Create(path)
+	if err != nil {
+		ts.Fatalf("Failed to create background file: %v", err)
+	}
+	format := beep.Format{SampleRate: 44100, NumChannels: audioChannels, Precision: audioBitDepth / 8}
+	if err := bwav.Encode(f, &constStreamer{framesLeft: 44100, val: 2000 / 32768.0}, format); err != nil {
+		ts.Fatalf("Failed to write background: %v", err)
+	}
+	f.Close()
+
+	newRenderer := func(gainLevel t.GainLevel, gain0, gain1 float64) *AudioRenderer {
+		var p0, pEnd t.Period
+		p0.TrackStart[0] = t.Track{Type: t.TrackBackground, Amplitude: t.AmplitudePercentToRaw(50), Gain: gain0}
+		p0.TrackEnd[0] = p0.TrackStart[0]
+		p0.TrackEnd[0].Gain = gain1
+		pEnd.Time = 1000
+
+		r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{
+			SampleRate:     44100,
+			Volume:         100,
+			GainLevel:      gainLevel,
+			BackgroundPath: path,
+		})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+		ts.Cleanup(func() { closeBackgrounds(r.backgroundAudio) })
+		return r
+	}
+
+	level := func(r *AudioRenderer) float64 {
+		var sum, n float64
+		if err := r.Render(func(samples []int) error {
+			for _, v := range samples {
+				sum += float64(v)
+				n++
+			}
+			return nil
+		}); err != nil {
+			ts.Fatalf("Render failed: %v", err)
+		}
+		return sum / n
+	}
+
+	// The gain is interpolated in dB and applied as a factor
+	r := newRenderer(t.GainLevelDBToRaw(-3), -12, 0)
+	r.sync(500, 0)
+	if got, want := r.channels[0].Gain, math.Pow(10, (-6-3)/20.0); math.Abs(got-want) > 1e-12 {
+		ts.Fatalf("expected gain factor %f halfway, got %f", want, got)
+	}
+
+	unity := level(newRenderer(t.GainLevelOff, 0, 0))
+	if unity <= 0 {
+		ts.Fatalf("expected a positive background level, got %f", unity)
+	}
+	for _, tc := range []struct {
+		gainLevel t.GainLevel
+		gain      float64
+	}{
+		{t.GainLevelDBToRaw(-6), 0},
+		{t.GainLevelOff, -6},
+		{t.GainLevelDBToRaw(-9), 3},
+		{t.GainLevelMedium, 3},
+	} {
+		want := math.Pow(10, (tc.gainLevel.ToDB()+tc.gain)/20)
+		if got := level(newRenderer(tc.gainLevel, tc.gain, tc.gain)) / unity; math.Abs(got-want) > 0.01 {
+			ts.Errorf("gain level %.2f dB, gain %.2f dB: expected level ratio %f, got %f", tc.gainLevel.ToDB(), tc.gain, want, got)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"sync"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// renderSegmentBuffers is the length of a segment of a parallel render
+// (128 buffers, about 3 seconds at 44.1 kHz)
+const renderSegmentBuffers = 128
+
+// renderSegment is a part of the timeline rendered by a worker
+type renderSegment struct {
+	// Frame range of the segment
+	start, end int64
+	// Channel state and period right before its first buffer
+	channels  [t.NumberOfChannels]t.Channel
+	periodIdx int
+	// Mixed buffers, with room for the whole segment
+	buffers chan []int
+	// Set before buffers is closed
+	err error
+}
+
+// renderSegments mixes the timeline in segments on concurrent workers and
+// delivers the buffers in order. The oscillator phases at the start of every
+// segment are computed ahead without mixing and noise is derived from the frame
+// position. Backgrounds are positioned at the segment start. The buffers are
+// brought to the output range in order, carrying the noise shaping and the
+// clip statistics over the segments as in a serial render.
+func (r *AudioRenderer) renderSegments(firstFrame, endFrame int64, periodIdx int, workers int, deliver func(frame int64, data []int, synced bool) error) error {
+	segmentFrames := int64(r.segmentBuffers * t.BufferSize)
+
+	done := make(chan struct{})
+	jobs := make(chan *renderSegment)
+	// Bounds the segments waiting to be delivered
+	pending := make(chan *renderSegment, workers)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer close(done)
+
+	// Plan the segments in order
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		defer close(pending)
+
+		// The planner starts where r was positioned
+		planner := r.fork()
+		planner.channels = r.channels
+		for start := firstFrame; start < endFrame; {
+			end := min((start/segmentFrames+1)*segmentFrames, endFrame)
+			seg := &renderSegment{
+				start:     start,
+				end:       end,
+				channels:  planner.channels,
+				periodIdx: periodIdx,
+				buffers:   make(chan []int, r.segmentBuffers),
+			}
+
+			select {
+			case pending <- seg:
+			case <-done:
+				return
+			}
+			select {
+			case jobs <- seg:
+			case <-done:
+				return
+			}
+
+			// Move the oscillators to the start of the next segment
+			periodIdx = planner.skip(seg.start, seg.end, periodIdx)
+			start = end
+		}
+	}()
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			w, err := r.segmentRenderer()
+			if err == nil {
+				defer closeBackgrounds(w.backgroundAudio)
+			}
+
+			for seg := range jobs {
+				if seg.err == nil {
+					seg.err = err
+				}
+				if seg.err == nil {
+					seg.err = w.renderSegment(seg, done)
+				}
+				close(seg.buffers)
+			}
+		}()
+	}
+
+	for seg := range pending {
+		frame := seg.start
+		for data := range seg.buffers {
+			if err := deliver(frame, r.output(data, frame), false); err != nil {
+				return err
+			}
+			frame += t.BufferSize
+		}
+		if seg.err != nil {
+			return seg.err
+		}
+	}
+
+	return nil
+}
+
+// renderSegment mixes the buffers of a segment, stopping early once done is closed
+func (r *AudioRenderer) renderSegment(seg *renderSegment, done <-chan struct{}) error {
+	r.channels = seg.channels
+
+	for _, bg := range r.backgroundAudio {
+		if err := bg.seek(seg.start); err != nil {
+			return err
+		}
+	}
+
+	periodIdx := seg.periodIdx
+	for frame := seg.start; frame < seg.end; frame += t.BufferSize {
+		select {
+		case <-done:
+			return nil
+		default:
+		}
+
+		var currentTimeMs int
+		currentTimeMs, periodIdx = r.periodAt(frame, periodIdx)
+		r.sync(currentTimeMs, periodIdx)
+
+		// Buffers are handed over, each one needs its own memory
+		seg.buffers <- r.mix(make([]int, t.BufferSize*r.outputChannels), frame)
+	}
+
+	return nil
+}
+
+// fork returns a renderer sharing the options and sources of r, with its own
+// channel and noise state
+func (r *AudioRenderer) fork() *AudioRenderer {
+	f := &AudioRenderer{
+		periods:              r.periods,
+		waveTables:           r.waveTables,
+		customWaves:          r.customWaves,
+		backgroundAudio:      r.backgroundAudio,
+		backgroundSamples:    r.backgroundSamples,
+		oneShots:             r.oneShots,
+		masterGain:           r.masterGain,
+		outputChannels:       r.outputChannels,
+		routing:              r.routing,
+		balanceGains:         r.balanceGains,
+		segmentBuffers:       r.segmentBuffers,
+		ditherStream:         r.ditherStream,
+		AudioRendererOptions: r.AudioRendererOptions,
+	}
+
+	for ch := range f.noiseGenerators {
+		f.noiseGenerators[ch] = NewNoiseGenerator(r.Seed, ch)
+	}
+
+	return f
+}
+
+// segmentRenderer returns a fork of r with its own copy of every background
+func (r *AudioRenderer) segmentRenderer() (*AudioRenderer, error) {
+	w := r.fork()
+	w.backgroundAudio = make(map[string]*BackgroundAudio, len(r.backgroundAudio))
+	w.backgroundSamples = make(map[string][]int, len(r.backgroundSamples))
+
+	for name, bg := range r.backgroundAudio {
+		w.backgroundSamples[name] = make([]int, t.BufferSize*audioChannels)
+		c, err := bg.clone()
+		if err != nil {
+			closeBackgrounds(w.backgroundAudio)
+			return nil, err
+		}
+		w.backgroundAudio[name] = c
+	}
+
+	return w, nil
+}
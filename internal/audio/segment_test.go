@@ -0,0 +1,219 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// segmentTestPeriods uses every kind of track, with transitions crossing segments
+func segmentTestPeriods() []t.Period {
+	var p0, p1, pEnd t.Period
+
+	p0.TrackStart[0] = t.Track{Type: t.TrackPureTone, Carrier: 180, Amplitude: t.AmplitudePercentToRaw(10), Waveform: t.WaveformTriangle, Pan: t.PanPercentToRaw(30)}
+	p0.TrackStart[1] = t.Track{Type: t.TrackBinauralBeat, Carrier: 250, Resonance: 8, Amplitude: t.AmplitudePercentToRaw(15), Waveform: t.WaveformSine}
+	p0.TrackStart[2] = t.Track{Type: t.TrackMonauralBeat, Carrier: 300, Resonance: 12, Amplitude: t.AmplitudePercentToRaw(10), Waveform: t.WaveformSquare}
+	p0.TrackStart[3] = t.Track{
+		Type:      t.TrackIsochronicBeat,
+		Carrier:   400,
+		Resonance: 6,
+		Amplitude: t.AmplitudePercentToRaw(10),
+		Waveform:  t.WaveformSine,
+		Envelope:  t.Envelope{Shape: t.EnvelopeRaisedCosine, Duty: 0.5, Attack: 20, Release: 30},
+	}
+	p0.TrackStart[4] = t.Track{Type: t.TrackPinkNoise, Amplitude: t.AmplitudePercentToRaw(10), Resonance: 0.3, Carrier: 200, Effect: t.Effect{Type: t.EffectSpin, Intensity: 0.6}}
+	p0.TrackStart[5] = t.Track{Type: t.TrackBrownNoise, Amplitude: t.AmplitudePercentToRaw(10), Resonance: 2, Effect: t.Effect{Type: t.EffectPulse, Intensity: 0.8}}
+	p0.TrackStart[6] = t.Track{Type: t.TrackBackground, Amplitude: t.AmplitudePercentToRaw(20), Resonance: 4, Effect: t.Effect{Type: t.EffectPulse, Intensity: 0.5}}
+	p0.TrackStart[7] = t.Track{Type: t.TrackBackground, Source: "rain", Amplitude: t.AmplitudePercentToRaw(20), Gain: -6}
+	// Background without audio, its effect never runs
+	p0.TrackStart[8] = t.Track{Type: t.TrackBackground, Source: "missing", Amplitude: t.AmplitudePercentToRaw(20), Resonance: 3, Effect: t.Effect{Type: t.EffectPulse, Intensity: 0.5}}
+	// Cue held over both periods, it plays once
+	p0.TrackStart[9] = t.Track{Type: t.TrackCue, Source: "bell", Amplitude: t.AmplitudePercentToRaw(40), Pan: t.PanPercentToRaw(-50)}
+	// Bell struck again while ringing, strikes crossing segments
+	p0.TrackStart[11] = t.Track{Type: t.TrackBell, Carrier: 523, Decay: 2, Interval: 1.3, Amplitude: t.AmplitudePercentToRaw(20), Pan: t.PanPercentToRaw(40)}
+	// Tones built from partials, the rolloff slides
+	p0.TrackStart[12] = t.Track{Type: t.TrackBinauralBeat, Carrier: 110, Resonance: 5, Amplitude: t.AmplitudePercentToRaw(15), Partials: t.HarmonicPartials(5)}
+	p0.TrackStart[13] = t.Track{Type: t.TrackIsochronicBeat, Carrier: 220, Resonance: 7, Amplitude: t.AmplitudePercentToRaw(10), Waveform: t.WaveformTriangle, Partials: t.ChordPartials(t.ChordMinor7)}
+	// Modulated tones, the FM depth slides
+	p0.TrackStart[14] = t.Track{Type: t.TrackFMTone, Carrier: 330, Resonance: 3, Depth: 40, Amplitude: t.AmplitudePercentToRaw(10)}
+	p0.TrackStart[15] = t.Track{Type: t.TrackAMTone, Carrier: 260, Resonance: 9, Depth: 70, Amplitude: t.AmplitudePercentToRaw(10), Waveform: t.WaveformSawtooth}
+	p0.TrackEnd = p0.TrackStart
+	p0.TrackEnd[14].Depth = 120
+	p0.TrackEnd[1].Carrier = 150
+	p0.TrackEnd[12].Partials.Rolloff = 12
+	p0.TrackEnd[1].Resonance = 4
+	p0.TrackEnd[4].Amplitude = t.AmplitudePercentToRaw(30)
+	p0.Transition = t.TransitionSmooth
+
+	p1.Time = 4300
+	p1.TrackStart = p0.TrackEnd
+	p1.TrackStart[0] = t.Track{Type: t.TrackWhiteNoise, Amplitude: t.AmplitudePercentToRaw(5)}
+	// Loud enough to clip
+	p1.TrackStart[2].Amplitude = t.AmplitudePercentToRaw(90)
+	// Cue triggered inside a buffer, crossing segments
+	p1.TrackStart[10] = t.Track{Type: t.TrackCue, Source: "bell", Amplitude: t.AmplitudePercentToRaw(30)}
+	// Same tones without their partials, the plain phases carry on
+	p1.TrackStart[12].Partials = t.Partials{}
+	p1.TrackStart[13].Partials = t.Partials{}
+	p1.TrackEnd = p1.TrackStart
+	p1.TrackEnd[3].Resonance = 12
+
+	// Steady tracks, skipped in one step
+	var p2 t.Period
+	p2.Time = 6200
+	p2.TrackStart = p1.TrackEnd
+	p2.TrackEnd = p2.TrackStart
+
+	pEnd.Time = 9000
+
+	return []t.Period{p0, p1, p2, pEnd}
+}
+
+// renderAll renders every sample with the given number of workers
+func renderAll(ts *testing.T, opts AudioRendererOptions, workers, segmentBuffers int) ([]int, ClipStats) {
+	ts.Helper()
+
+	opts.Workers = workers
+	r, err := NewAudioRenderer(segmentTestPeriods(), &opts)
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+	r.segmentBuffers = segmentBuffers
+
+	var out []int
+	if err := r.Render(func(samples []int) error {
+		out = append(out, samples...)
+		return nil
+	}); err != nil {
+		ts.Fatalf("Render failed: %v", err)
+	}
+	return out, r.ClipStats()
+}
+
+func TestAudioRenderer_Render_SegmentsMatchSerial(ts *testing.T) {
+	tests := map[string]AudioRendererOptions{
+		"stereo": {
+			SampleRate: 44100,
+			Volume:     90,
+			Seed:       7,
+			Dither:     t.DitherShaped,
+			// Looped at the output rate, the loop is positioned directly
+			BackgroundPath: filepath.Join("testdata", "noise.wav"),
+			BackgroundLoop: t.BackgroundLoop{Start: 0.5, End: 1.7, Crossfade: 0.2, Offset: 0.3},
+		},
+		"5.1 limited": {
+			SampleRate:     44100,
+			Volume:         100,
+			Layout:         t.Layout51,
+			Limiter:        t.LimiterSoft,
+			Dither:         t.DitherTPDF,
+			BackgroundPath: filepath.Join("testdata", "noise.wav"),
+		},
+	}
+
+	for name, opts := range tests {
+		// Resampled once at load time, the resampled file is positioned
+		opts.Backgrounds = []t.BackgroundSource{{Name: "rain", Path: writeSineWav(ts, 22050, 1, 441)}}
+		opts.Cues = []t.CueSource{{Name: "bell", Path: writeSineWav(ts, 22050, 1, 441)}}
+
+		serial, serialStats := renderAll(ts, opts, 1, renderSegmentBuffers)
+		for _, segmentBuffers := range []int{1, 7, 64} {
+			parallel, parallelStats := renderAll(ts, opts, 4, segmentBuffers)
+			if !slices.Equal(serial, parallel) {
+				i := 0
+				for i < min(len(serial), len(parallel)) && serial[i] == parallel[i] {
+					i++
+				}
+				ts.Fatalf("%s: segments of %d buffers differ from the serial render at sample %d of %d (%d samples)",
+					name, segmentBuffers, i, len(serial), len(parallel))
+			}
+			if serialStats != parallelStats {
+				ts.Fatalf("%s: clip stats differ, serial %+v, parallel %+v", name, serialStats, parallelStats)
+			}
+		}
+		if !serialStats.Clipped() {
+			ts.Fatalf("%s: expected clipped samples to compare", name)
+		}
+	}
+}
+
+func TestAudioRenderer_Render_SegmentsStopOnError(ts *testing.T) {
+	r, err := NewAudioRenderer(segmentTestPeriods(), &AudioRendererOptions{SampleRate: 44100, Volume: 100, Workers: 3})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+	r.segmentBuffers = 4
+
+	targetErr := errors.New("sink failure")
+	calls := 0
+	err = r.Render(func(_ []int) error {
+		calls++
+		if calls == 10 {
+			return targetErr
+		}
+		return nil
+	})
+	if !errors.Is(err, targetErr) {
+		ts.Fatalf("expected wrapped target error, got: %v", err)
+	}
+	if calls != 10 {
+		ts.Fatalf("expected rendering to stop after the error, got %d calls", calls)
+	}
+
+	if _, err := NewAudioRenderer(segmentTestPeriods(), &AudioRendererOptions{SampleRate: 44100, Volume: 100, Workers: -1}); err == nil {
+		ts.Fatalf("expected error for negative workers")
+	}
+}
+
+func TestAudioRenderer_RenderContext_Cancel(ts *testing.T) {
+	for _, workers := range []int{1, 3} {
+		r, err := NewAudioRenderer(segmentTestPeriods(), &AudioRendererOptions{
+			SampleRate:     44100,
+			Volume:         100,
+			Workers:        workers,
+			BackgroundPath: filepath.Join("testdata", "noise.wav"),
+		})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+		r.segmentBuffers = 4
+
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err = r.RenderContext(ctx, func(_ []int) error {
+			calls++
+			if calls == 10 {
+				cancel()
+			}
+			return nil
+		})
+		cancel()
+
+		if !errors.Is(err, context.Canceled) {
+			ts.Fatalf("%d workers: expected the context error, got: %v", workers, err)
+		}
+		if calls != 10 {
+			ts.Fatalf("%d workers: expected rendering to stop when cancelled, got %d calls", workers, calls)
+		}
+		for name, bg := range r.backgroundAudio {
+			if bg.IsEnabled() {
+				ts.Errorf("%d workers: expected background %q closed after cancellation", workers, name)
+			}
+		}
+	}
+}
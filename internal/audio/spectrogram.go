@@ -0,0 +1,148 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+const (
+	// spectrogramWindow is the length of the analysis window, in seconds
+	spectrogramWindow = 0.35
+	// spectrogramFloor is the lowest level of a spectrogram, in dBFS
+	spectrogramFloor = -120.0
+)
+
+// Spectrogram holds the spectrum of each ear of a render over time
+type Spectrogram struct {
+	// Levels of the left and right ears in dBFS, by column and frequency bin
+	Levels [2][][]float64
+	// Width of a frequency bin, in Hz
+	BinWidth float64
+	// Time range of the columns, in milliseconds
+	Start, End int
+}
+
+// Spectrogram renders the time range of the options and measures the
+// spectrum of the front left and right channels in evenly spaced columns.
+// Each column is a Hann-windowed FFT of about a third of a second centered on
+// its time, with the bins up to a frequency.
+func (r *AudioRenderer) Spectrogram(columns int, maxFreq float64) (*Spectrogram, error) {
+	if columns < 1 {
+		return nil, fmt.Errorf("spectrogram needs at least one column, got %d", columns)
+	}
+	if maxFreq <= 0 || maxFreq > float64(r.SampleRate)/2 {
+		return nil, fmt.Errorf("spectrogram frequency must be between 0 and %d Hz, got %.2f", r.SampleRate/2, maxFreq)
+	}
+
+	size := 1
+	for float64(size) < spectrogramWindow*float64(r.SampleRate) {
+		size <<= 1
+	}
+
+	start, end := r.Start, r.End
+	if end == 0 {
+		end = r.periods[len(r.periods)-1].Time
+	}
+
+	sp := &Spectrogram{
+		BinWidth: float64(r.SampleRate) / float64(size),
+		Start:    start,
+		End:      end,
+	}
+	bins := min(size/2, int(math.Ceil(maxFreq/sp.BinWidth))+1)
+
+	hann := make([]float64, size)
+	var hannSum float64
+	for i := range hann {
+		hann[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(size))
+		hannSum += hann[i]
+	}
+
+	// The last frames of each ear, the window ends at the newest frame
+	var history [2][]float64
+	for e := range history {
+		history[e] = make([]float64, size)
+	}
+	var (
+		pos    int   // Next slot of the history
+		frames int64 // Frames written to the history
+	)
+	total := r.frameAt(end) - r.frameAt(start)
+	buf := make([]complex128, size)
+
+	// column measures the spectrum once the frames after its center are read
+	column := func() {
+		for e := range history {
+			for i := range size {
+				buf[i] = complex(history[e][(pos+i)%size]*hann[i], 0)
+			}
+			fft(buf)
+
+			levels := make([]float64, bins)
+			for k := range levels {
+				amplitude := 2 * cmplx.Abs(buf[k]) / hannSum / 32768
+				levels[k] = math.Max(spectrogramFloor, 20*math.Log10(amplitude))
+			}
+			sp.Levels[e] = append(sp.Levels[e], levels)
+		}
+	}
+
+	// due returns the frame count at which the next column is complete
+	due := func() int64 {
+		c := int64(len(sp.Levels[0]))
+		return (2*c+1)*total/int64(2*columns) + int64(size/2)
+	}
+
+	write := func(left, right float64) {
+		history[0][pos], history[1][pos] = left, right
+		pos = (pos + 1) % size
+		frames++
+		for len(sp.Levels[0]) < columns && frames == due() {
+			column()
+		}
+	}
+
+	n := r.outputChannels
+	err := r.Render(func(samples []int) error {
+		for i := 0; i < len(samples); i += n {
+			write(float64(samples[i]), float64(samples[i+1]))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The last columns are padded with silence
+	for len(sp.Levels[0]) < columns {
+		write(0, 0)
+	}
+
+	return sp, nil
+}
+
+// Time returns the time of the center of a column, in milliseconds
+func (sp *Spectrogram) Time(column int) float64 {
+	columns := len(sp.Levels[0])
+	return float64(sp.Start) + (float64(column)+0.5)*float64(sp.End-sp.Start)/float64(columns)
+}
+
+// Bins returns the number of frequency bins of a column
+func (sp *Spectrogram) Bins() int {
+	if len(sp.Levels[0]) == 0 {
+		return 0
+	}
+	return len(sp.Levels[0][0])
+}
@@ -0,0 +1,74 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+func TestAudioRenderer_Spectrogram(ts *testing.T) {
+	var p0, p1, p2 t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 200, Resonance: 40, Amplitude: t.AmplitudePercentToRaw(50)}
+	p0.TrackEnd = p0.TrackStart
+	p1.Time = 2000
+	p1.TrackStart[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 400, Resonance: 40, Amplitude: t.AmplitudePercentToRaw(50)}
+	p1.TrackEnd = p1.TrackStart
+	p2.Time = 4000
+
+	r, err := NewAudioRenderer([]t.Period{p0, p1, p2}, &AudioRendererOptions{SampleRate: 44100, Volume: 100})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	sp, err := r.Spectrogram(8, 1000)
+	if err != nil {
+		ts.Fatalf("Spectrogram failed: %v", err)
+	}
+	if len(sp.Levels[0]) != 8 || len(sp.Levels[1]) != 8 {
+		ts.Fatalf("expected 8 columns per ear, got %d and %d", len(sp.Levels[0]), len(sp.Levels[1]))
+	}
+	if sp.Start != 0 || sp.End != 4000 || sp.Time(0) != 250 {
+		ts.Errorf("expected columns over 0-4000ms centered at 250ms, got %d-%d at %.1f", sp.Start, sp.End, sp.Time(0))
+	}
+
+	// peak returns the frequency of the strongest bin of a column
+	peak := func(levels []float64) (float64, float64) {
+		best := 0
+		for k := range levels {
+			if levels[k] > levels[best] {
+				best = k
+			}
+		}
+		return float64(best) * sp.BinWidth, levels[best]
+	}
+
+	// Each ear follows its side of the beat, a 50% tone is about -6 dBFS
+	for _, tc := range []struct {
+		column, ear int
+		freq        float64
+	}{{1, 0, 220}, {1, 1, 180}, {6, 0, 420}, {6, 1, 380}} {
+		freq, level := peak(sp.Levels[tc.ear][tc.column])
+		if math.Abs(freq-tc.freq) > sp.BinWidth {
+			ts.Errorf("column %d ear %d: expected a peak at %.0f Hz, got %.1f Hz", tc.column, tc.ear, tc.freq, freq)
+		}
+		if math.Abs(level+6) > 1.5 {
+			ts.Errorf("column %d ear %d: expected a peak near -6 dBFS, got %.2f", tc.column, tc.ear, level)
+		}
+	}
+
+	if _, err := r.Spectrogram(8, 30000); err == nil {
+		ts.Errorf("expected an error for a frequency above Nyquist")
+	}
+}
@@ -14,6 +14,7 @@ package audio
 import (
 	"fmt"
 	"io"
+	"math"
 	"strings"
 
 	s "github.com/synapseq-foundation/synapseq/v3/internal/shared"
@@ -62,10 +63,14 @@ func (sr *StatusReporter) DisplayPeriodChange(r *AudioRenderer, periodIdx int) {
 	}
 
 	// Line 1: Current period (start)
+	transition := period.Transition.String()
+	if period.Glide != t.GlideLinear {
+		transition += " " + period.Glide.String()
+	}
 	line1 := fmt.Sprintf("- %s -> %s (%s)",
 		period.TimeString(),
 		nextPeriod.TimeString(),
-		period.Transition.String())
+		transition)
 
 	// Line 2: Start tracks (indented)
 	line2 := ""
@@ -79,8 +84,8 @@ func (sr *StatusReporter) DisplayPeriodChange(r *AudioRenderer, periodIdx int) {
 			line2 += fmt.Sprintf("\n%s %s", strings.Repeat(" ", 6), startTrack.String())
 		}
 
-		// End Track (only if different)
-		if !s.IsTrackEqual(&startTrack, &endTrack) {
+		// End Track (only if different, cues and bells do not slide)
+		if !startTrack.IsOneShot() && !s.IsTrackEqual(&startTrack, &endTrack) {
 			line2 += fmt.Sprintf("\n   ->  %s", endTrack.String())
 		}
 	}
@@ -134,6 +139,40 @@ func (sr *StatusReporter) ShouldUpdateStatus() bool {
 	return sr.updateCounter%44 == 0
 }
 
+// DisplayClipStats warns when samples exceeded full scale on the master bus
+func (sr *StatusReporter) DisplayClipStats(stats ClipStats) {
+	if sr.out == nil || !stats.Clipped() {
+		return
+	}
+
+	// Clear the status line
+	if sr.lastStatusWidth > 0 {
+		fmt.Fprintf(sr.out, "%s\r", strings.Repeat(" ", sr.lastStatusWidth))
+		sr.lastStatusWidth = 0
+	}
+
+	action := "hard clipped"
+	if stats.Limited {
+		action = "softly limited"
+	}
+	fmt.Fprintf(sr.out, "warning: %d samples exceeded full scale by up to %.2f dB and were %s, lower the amplitudes or volume\n",
+		stats.Samples, stats.MaxOvershoot, action)
+}
+
+// DisplayLoudness shows the measured loudness and the gain applied to reach the target
+func (sr *StatusReporter) DisplayLoudness(measured, target, gain float64) {
+	if sr.out == nil {
+		return
+	}
+
+	if math.IsInf(measured, -1) {
+		fmt.Fprintf(sr.out, "warning: the output is silent, loudness cannot be normalized to %.2f LUFS\n", target)
+		return
+	}
+
+	fmt.Fprintf(sr.out, "Loudness: measured %.2f LUFS, target %.2f LUFS, gain %+.2f dB\n", measured, target, gain)
+}
+
 // FinalStatus clears the status line at the end
 func (sr *StatusReporter) FinalStatus() {
 	if sr.out == nil {
@@ -11,6 +11,8 @@
 
 package audio
 
+import "context"
+
 // rendererStreamer streams rendered audio samples
 type rendererStreamer struct {
 	ch       chan []int
@@ -19,23 +21,35 @@ type rendererStreamer struct {
 	err      error
 }
 
-func newRendererStreamer(r *AudioRenderer) *rendererStreamer {
+// newRendererStreamer renders in the background until the samples are read
+// or the context is done
+func newRendererStreamer(ctx context.Context, r *AudioRenderer) *rendererStreamer {
 	rs := &rendererStreamer{
 		ch: make(chan []int, 2),
 	}
 	go func() {
 		defer close(rs.ch)
 		// Run the renderer and stream samples
-		rs.err = r.Render(func(samples []int) error {
+		rs.err = r.RenderContext(ctx, func(samples []int) error {
 			cpy := make([]int, len(samples))
 			copy(cpy, samples)
-			rs.ch <- cpy
-			return nil
+			select {
+			case rs.ch <- cpy:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
 		})
 	}()
 	return rs
 }
 
+// wait discards the samples left unread until the render ends
+func (rs *rendererStreamer) wait() {
+	for range rs.ch {
+	}
+}
+
 // Stream streams audio samples in the range [-1.0, 1.0]
 func (rs *rendererStreamer) Stream(samples [][2]float64) (n int, ok bool) {
 	if rs.done && len(rs.leftover) == 0 {
@@ -66,16 +66,35 @@ func (r *AudioRenderer) sync(timeMs int, periodIdx int) {
 
 		channel.Track.Type = tr0.Type
 		channel.Track.Effect.Type = tr0.Effect.Type
-		channel.Track.Amplitude = t.AmplitudeType(float64(tr0.Amplitude)*(1-alpha) + float64(tr1.Amplitude)*alpha)
-		channel.Track.Carrier = tr0.Carrier*(1-alpha) + tr1.Carrier*alpha
-		channel.Track.Resonance = tr0.Resonance*(1-alpha) + tr1.Resonance*alpha
+		channel.Track.Amplitude = t.AmplitudeType(lerp(float64(tr0.Amplitude), float64(tr1.Amplitude), alpha))
+		// Spin widths are not frequencies, they always slide linearly
+		logCarrier := period.Glide.LogCarrier() && (tr0.IsTone() || tr0.IsModulated())
+		channel.Track.Carrier = glideFrequency(tr0.Carrier, tr1.Carrier, alpha, logCarrier)
+		channel.Track.Resonance = glideFrequency(tr0.Resonance, tr1.Resonance, alpha, period.Glide.LogBeat())
 		channel.Track.Waveform = tr0.Waveform
-		channel.Track.Intensity = t.IntensityType(float64(tr0.Intensity)*(1-alpha) + float64(tr1.Intensity)*alpha)
+		channel.Track.WaveformName = tr0.WaveformName
+		channel.WaveTable = r.waveTableIndex(&tr0)
+		channel.Track.Source = tr0.Source
+		channel.Track.Gain = lerp(tr0.Gain, tr1.Gain, alpha)
+		channel.Track.Intensity = t.IntensityType(lerp(float64(tr0.Intensity), float64(tr1.Intensity), alpha))
+		channel.Track.Envelope.Shape = tr0.Envelope.Shape
+		channel.Track.Envelope.Duty = t.DutyType(lerp(float64(tr0.Envelope.Duty), float64(tr1.Envelope.Duty), alpha))
+		channel.Track.Envelope.Attack = lerp(tr0.Envelope.Attack, tr1.Envelope.Attack, alpha)
+		channel.Track.Envelope.Release = lerp(tr0.Envelope.Release, tr1.Envelope.Release, alpha)
+		channel.Track.Pan = t.PanType(lerp(float64(tr0.Pan), float64(tr1.Pan), alpha))
+		channel.Track.Route = tr0.Route
+		channel.Track.Decay = tr0.Decay
+		channel.Track.Interval = tr0.Interval
+		channel.Track.Partials = tr0.Partials
+		channel.Track.Partials.Rolloff = lerp(tr0.Partials.Rolloff, tr1.Partials.Rolloff, alpha)
+		channel.Track.Depth = lerp(tr0.Depth, tr1.Depth, alpha)
+		channel.Pan[0], channel.Pan[1] = calcPanGains(channel.Track.Pan)
 		// Reset offsets if track type has changed
 		if channel.Type != channel.Track.Type {
 			channel.Type = channel.Track.Type
 			channel.Offset[0] = 0
 			channel.Offset[1] = 0
+			channel.PartialOffset = [t.MaxPartials][2]int{}
 		}
 
 		switch channel.Track.Type {
@@ -99,11 +118,21 @@ func (r *AudioRenderer) sync(timeMs int, periodIdx int) {
 			channel.Amplitude[0] = int(channel.Track.Amplitude)
 			channel.Increment[0] = int(channel.Track.Carrier / float64(r.SampleRate) * t.SineTableSize * t.PhasePrecision)
 			channel.Increment[1] = int(channel.Track.Resonance / float64(r.SampleRate) * t.SineTableSize * t.PhasePrecision)
-		case t.TrackWhiteNoise, t.TrackPinkNoise, t.TrackBrownNoise:
-			channel.Amplitude[0] = int(channel.Track.Amplitude)
-		case t.TrackBackground:
+		case t.TrackFMTone, t.TrackAMTone:
+			r.syncModulated(channel)
+		case t.TrackCue, t.TrackBell:
+			// Cues and bells play at the level of the period that triggers them
+			channel.Track.Amplitude = tr0.Amplitude
+			channel.Track.Carrier = tr0.Carrier
+			channel.Track.Pan = tr0.Pan
+		case t.TrackWhiteNoise, t.TrackPinkNoise, t.TrackBrownNoise, t.TrackBackground:
 			channel.Amplitude[0] = int(channel.Track.Amplitude)
 
+			// Gain in dB is interpolated, the factor is computed once per update
+			if channel.Track.Type == t.TrackBackground {
+				channel.Gain = math.Pow(10, (channel.Track.Gain+r.GainLevel.ToDB())/20)
+			}
+
 			switch channel.Track.Effect.Type {
 			case t.EffectSpin:
 				channel.Increment[0] = int(channel.Track.Resonance / float64(r.SampleRate) * t.SineTableSize * t.PhasePrecision)
@@ -122,5 +151,44 @@ func (r *AudioRenderer) sync(timeMs int, periodIdx int) {
 				channel.Increment[1] = int(channel.Track.Resonance / float64(r.SampleRate) * t.SineTableSize * t.PhasePrecision)
 			}
 		}
+
+		// Tones built from partials of their carrier
+		if channel.Track.Partials.Kind != t.PartialsOff {
+			r.syncPartials(channel)
+		}
+	}
+}
+
+// glideFrequency interpolates between two frequencies, linearly in Hz or by
+// equal ratios. Frequencies that are not both positive slide linearly.
+func glideFrequency(f0, f1, alpha float64, log bool) float64 {
+	if log && f0 > 0 && f1 > 0 {
+		return f0 * math.Pow(f1/f0, alpha)
+	}
+	return lerp(f0, f1, alpha)
+}
+
+// lerp interpolates linearly between two values, exactly one of them when
+// they are equal so that steady tracks hold the same state over a period
+func lerp(v0, v1, alpha float64) float64 {
+	if v0 == v1 {
+		return v0
+	}
+	return v0*(1-alpha) + v1*alpha
+}
+
+// calcPanGains returns the constant-power left and right gains for a pan position.
+// Gains are normalized to 1 at the center so unpanned tracks keep their level.
+func calcPanGains(pan t.PanType) (float64, float64) {
+	theta := (float64(pan) + 1) * math.Pi / 4
+	return math.Sqrt2 * math.Cos(theta), math.Sqrt2 * math.Sin(theta)
+}
+
+// calcBalanceGains returns the left and right gains for an ear balance.
+// The opposite ear is attenuated so the output never gets louder.
+func calcBalanceGains(balance t.BalanceType) (float64, float64) {
+	if balance > 0 {
+		return 1 - float64(balance), 1
 	}
+	return 1, 1 + float64(balance)
 }
@@ -0,0 +1,119 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// glideAt returns the carrier and beat of the first channel a third of the way
+// through a sweep from 100 Hz with a 40 Hz beat to 800 Hz with a 1 Hz beat
+func glideAt(ts *testing.T, glide t.GlideType, transition t.TransitionType) (float64, float64) {
+	ts.Helper()
+
+	var p0, pEnd t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 100, Resonance: 40, Amplitude: t.AmplitudePercentToRaw(20)}
+	p0.TrackEnd[0] = p0.TrackStart[0]
+	p0.TrackEnd[0].Carrier = 800
+	p0.TrackEnd[0].Resonance = 1
+	// Spin widths always slide linearly
+	p0.TrackStart[1] = t.Track{Type: t.TrackPinkNoise, Carrier: 300, Resonance: 2, Amplitude: t.AmplitudePercentToRaw(20), Effect: t.Effect{Type: t.EffectSpin, Intensity: 0.5}}
+	p0.TrackEnd[1] = p0.TrackStart[1]
+	p0.TrackEnd[1].Carrier = 0
+	p0.Transition = transition
+	p0.Glide = glide
+	pEnd.Time = 3000
+
+	r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{SampleRate: 44100, Volume: 100})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	r.sync(1000, 0)
+	if width := r.channels[1].Track.Carrier; transition == t.TransitionSteady && math.Abs(width-200) > 1e-9 {
+		ts.Errorf("glide %s: expected the spin width to slide linearly to 200, got %.4f", glide, width)
+	}
+	return r.channels[0].Track.Carrier, r.channels[0].Track.Resonance
+}
+
+func TestSync_Glide(ts *testing.T) {
+	// A third of the way is one octave up the carrier and a third of the
+	// way from 40 Hz to 1 Hz by ratio
+	logCarrier := 200.0
+	logBeat := 40 * math.Pow(1.0/40, 1.0/3)
+	linCarrier := 100 + 700.0/3
+	linBeat := 40 - 39.0/3
+
+	tests := []struct {
+		glide         t.GlideType
+		carrier, beat float64
+	}{
+		{t.GlideLinear, linCarrier, linBeat},
+		{t.GlideLog, logCarrier, logBeat},
+		{t.GlideLogCarrier, logCarrier, linBeat},
+		{t.GlideLogBeat, linCarrier, logBeat},
+	}
+	for _, tt := range tests {
+		carrier, beat := glideAt(ts, tt.glide, t.TransitionSteady)
+		if math.Abs(carrier-tt.carrier) > 1e-9 || math.Abs(beat-tt.beat) > 1e-9 {
+			ts.Errorf("glide %s: expected carrier %.4f beat %.4f, got %.4f %.4f", tt.glide, tt.carrier, tt.beat, carrier, beat)
+		}
+	}
+
+	// The glide follows the transition curve
+	alpha := math.Expm1(t.TransitionCurveK/3) / math.Expm1(t.TransitionCurveK)
+	carrier, _ := glideAt(ts, t.GlideLog, t.TransitionEaseIn)
+	if want := 100 * math.Pow(8, alpha); math.Abs(carrier-want) > 1e-9 {
+		ts.Errorf("expected an eased carrier of %.4f, got %.4f", want, carrier)
+	}
+}
+
+func TestGlideFrequency_Fallback(ts *testing.T) {
+	// Frequencies that are not both positive slide linearly
+	for _, f := range [][2]float64{{0, 100}, {100, 0}, {-10, 10}} {
+		if got, want := glideFrequency(f[0], f[1], 0.25, true), f[0]*0.75+f[1]*0.25; got != want {
+			ts.Errorf("glide from %.0f to %.0f: expected %.4f, got %.4f", f[0], f[1], want, got)
+		}
+	}
+	if got := glideFrequency(100, 100, 0.4, true); got != 100 {
+		ts.Errorf("expected a steady frequency to stay at 100, got %.6f", got)
+	}
+}
+
+func TestCalcPanGains(ts *testing.T) {
+	tests := []struct {
+		pan         t.PanType
+		left, right float64
+	}{
+		{-1, math.Sqrt2, 0},
+		{0, 1, 1},
+		{1, 0, math.Sqrt2},
+	}
+
+	for _, tt := range tests {
+		left, right := calcPanGains(tt.pan)
+		if math.Abs(left-tt.left) > 1e-12 || math.Abs(right-tt.right) > 1e-12 {
+			ts.Errorf("pan %.0f: expected gains %.4f / %.4f, got %.4f / %.4f", tt.pan, tt.left, tt.right, left, right)
+		}
+	}
+
+	// The power of a centered track is kept across the field
+	for pan := t.PanType(-1); pan <= 1; pan += 0.125 {
+		left, right := calcPanGains(pan)
+		if power := left*left + right*right; math.Abs(power-2) > 1e-12 {
+			ts.Errorf("pan %.3f: expected the centered power, got gains %.4f / %.4f", pan, left, right)
+		}
+	}
+}
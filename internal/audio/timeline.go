@@ -0,0 +1,55 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// TimelinePoint holds the tracks of a sequence at a time
+type TimelinePoint struct {
+	// Time in milliseconds
+	Time int
+	// Period index of the time
+	Period int
+	// Tracks of every channel, interpolated as the renderer plays them
+	Tracks [t.NumberOfChannels]t.Track
+}
+
+// Timeline samples the tracks of a sequence, with a number of steps across
+// every period. Each period is sampled from its start to its end, so the
+// points follow the transition curves and the glides of the periods.
+func Timeline(periods []t.Period, sampleRate, steps int) []TimelinePoint {
+	if len(periods) < 2 || steps < 1 {
+		return nil
+	}
+
+	// The renderer only interpolates the tracks, nothing is mixed
+	r := &AudioRenderer{periods: periods, AudioRendererOptions: &AudioRendererOptions{SampleRate: sampleRate}}
+
+	points := make([]TimelinePoint, 0, (len(periods)-1)*(steps+1))
+	for p := 0; p+1 < len(periods); p++ {
+		start, end := periods[p].Time, periods[p+1].Time
+		for i := range steps + 1 {
+			ms := start + (end-start)*i/steps
+			r.sync(ms, p)
+
+			point := TimelinePoint{Time: ms, Period: p}
+			for ch := range point.Tracks {
+				point.Tracks[ch] = r.channels[ch].Track
+			}
+			points = append(points, point)
+		}
+	}
+
+	return points
+}
@@ -0,0 +1,52 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+func TestTimeline(ts *testing.T) {
+	var p0, p1, p2 t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 100, Resonance: 10, Amplitude: t.AmplitudePercentToRaw(50)}
+	p0.TrackEnd[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 400, Resonance: 4, Amplitude: t.AmplitudePercentToRaw(50)}
+	p0.Glide = t.GlideLog
+	p1.Time = 10000
+	p1.TrackStart[0] = p0.TrackEnd[0]
+	p1.TrackEnd[0] = p0.TrackEnd[0]
+	p2.Time = 20000
+
+	points := Timeline([]t.Period{p0, p1, p2}, 44100, 4)
+	if len(points) != 10 {
+		ts.Fatalf("expected 10 points, got %d", len(points))
+	}
+
+	// Both edges of every period are sampled
+	if points[4].Time != 10000 || points[4].Period != 0 || points[5].Time != 10000 || points[5].Period != 1 {
+		ts.Errorf("expected the boundary sampled in both periods, got %+v and %+v", points[4].Time, points[5].Time)
+	}
+
+	// The log glide passes the geometric mean halfway
+	if got := points[2].Tracks[0].Carrier; math.Abs(got-200) > 1e-9 {
+		ts.Errorf("expected the carrier at 200 Hz halfway, got %.3f", got)
+	}
+	if got := points[9].Tracks[0].Resonance; got != 4 {
+		ts.Errorf("expected the beat at 4 Hz at the end, got %.3f", got)
+	}
+
+	if points := Timeline([]t.Period{p0}, 44100, 4); points != nil {
+		ts.Errorf("expected no points without an end, got %d", len(points))
+	}
+}
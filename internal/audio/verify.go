@@ -0,0 +1,448 @@
+//go:build !wasm
+
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"fmt"
+	"math"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+const (
+	// verifyMaxWindow is the longest analysis window, in milliseconds
+	verifyMaxWindow = 4000
+	// verifyMinWindow is the shortest analysis window, periods too short
+	// for it are not verified
+	verifyMinWindow = 500
+	// verifySearchSpan is how far from the expected frequency a peak is searched, in Hz
+	verifySearchSpan = 5.0
+	// verifyRefineSteps is the number of golden-section steps refining a peak
+	verifyRefineSteps = 24
+	// verifyMinAmplitude is the lowest tone amplitude that is verified, in percent
+	verifyMinAmplitude = 0.5
+	// verifyFullScale is the 16-bit peak of a tone at 100% amplitude
+	verifyFullScale = float64(t.WaveTableAmplitude) * 4096 / (1 << audioBitShift)
+)
+
+// VerifyTolerance holds the largest accepted differences between the
+// measured and the expected tones
+type VerifyTolerance struct {
+	Carrier   float64 // Hz
+	Beat      float64 // Hz
+	Amplitude float64 // dB
+}
+
+// DefaultVerifyTolerance returns the default tolerances of a verification
+func DefaultVerifyTolerance() VerifyTolerance {
+	return VerifyTolerance{Carrier: 0.5, Beat: 0.2, Amplitude: 1}
+}
+
+// ToneMeasure holds the carrier and beat of a tone, in Hz, and its amplitude
+// in each ear, in percent
+type ToneMeasure struct {
+	Carrier   float64
+	Beat      float64
+	Amplitude [2]float64
+}
+
+// VerifyResult is the verification of a tone channel in a period
+type VerifyResult struct {
+	// Period index and channel of the tone
+	Period, Channel int
+	// Center of the analysis window, in milliseconds
+	Time int
+	// Track type of the tone
+	Type t.TrackType
+	// Expected and measured tone
+	Expected, Measured ToneMeasure
+	// The beat and the amplitude are not verified for every tone
+	BeatChecked, AmplitudeChecked bool
+	// Each measure is within its tolerance (true when not checked)
+	CarrierOK, BeatOK, AmplitudeOK bool
+}
+
+// Passed reports whether every checked measure is within its tolerance
+func (vr *VerifyResult) Passed() bool {
+	return vr.CarrierOK && vr.BeatOK && vr.AmplitudeOK
+}
+
+// toneComponent is a sine component of a tone heard in one ear
+type toneComponent struct {
+	// Frequency in Hz
+	freq float64
+	// Share of the tone amplitude carried by the component
+	share float64
+}
+
+// verifyWindow holds the Hann-windowed samples of each ear around a time and
+// the tracks the renderer holds over each buffer of the window
+type verifyWindow struct {
+	ears [2][]float64
+	// Hann window and its sum, the gain of a sine at its frequency
+	hann []float64
+	sum  float64
+	// Frames of the first buffer before the window
+	offset int
+	// Tracks of every buffer overlapping the window
+	tracks [][t.NumberOfChannels]t.Track
+}
+
+// VerifyWAV measures the tones of a sequence in a stereo WAV file rendered
+// from it. The middle of every period is analyzed with a Hann-windowed
+// Goertzel peak search in each ear, and the carrier, beat and amplitude found
+// are compared with the tones at that time. Noise, backgrounds, one-shot
+// sounds and modulated tones are not verified, nor are tones built from
+// partials. The amplitude is only verified for plain tones with a built-in
+// waveform, as isochronic pulses and custom waveforms spread it over other
+// frequencies.
+//
+// A file rendered from the time range of the options holds that range only:
+// the periods are analyzed where they overlap it, past its faded edges.
+func VerifyWAV(path string, periods []t.Period, ar *AudioRendererOptions, tol VerifyTolerance) ([]VerifyResult, error) {
+	if ar.Layout != t.LayoutStereo {
+		return nil, fmt.Errorf("verification supports stereo output only, the sequence layout is %s", ar.Layout)
+	}
+	if len(periods) == 0 {
+		return nil, fmt.Errorf("no periods to verify")
+	}
+
+	bg, err := openSourceAudio(path, path)
+	if err != nil {
+		return nil, err
+	}
+	defer bg.Close()
+
+	if bg.channels != audioChannels {
+		return nil, fmt.Errorf("%s must be stereo (%d channels detected)", path, bg.channels)
+	}
+	if bg.sampleRate != ar.SampleRate {
+		return nil, fmt.Errorf("%s sample rate (%d Hz) does not match the sequence (%d Hz)", path, bg.sampleRate, ar.SampleRate)
+	}
+
+	// The renderer only interpolates the tracks, nothing is mixed
+	r := &AudioRenderer{periods: periods, AudioRendererOptions: ar}
+
+	sequenceMs := periods[len(periods)-1].Time
+	if err := checkRange(ar.Start, ar.End, sequenceMs); err != nil {
+		return nil, err
+	}
+	rangeStart, rangeEnd := ar.Start, ar.End
+	if rangeEnd == 0 {
+		rangeEnd = sequenceMs
+	}
+
+	// Frame 0 of the file is the start of the range
+	startFrame := r.frameAt(rangeStart)
+	if frames := bg.decoder.Len(); int64(frames) < r.frameAt(rangeEnd)-startFrame {
+		what := "the sequence"
+		if rangeStart != 0 || rangeEnd != sequenceMs {
+			what = "the time range"
+		}
+		return nil, fmt.Errorf("%s is shorter than %s (%.1fs, expected %.1fs)",
+			path, what, float64(frames)/float64(ar.SampleRate), float64(rangeEnd-rangeStart)/1000)
+	}
+
+	// The faded edges are not measured
+	fade := min(ar.EdgeFade, (rangeEnd-rangeStart)/2)
+	rangeStart, rangeEnd = rangeStart+fade, rangeEnd-fade
+
+	level := float64(ar.Volume) / 100 * math.Pow(10, ar.NormalizeGain/20)
+	balance := layoutBalanceGains(t.LayoutStereo, ar.Balance)
+
+	var results []VerifyResult
+	for p := 0; p+1 < len(periods); p++ {
+		start, end := max(periods[p].Time, rangeStart), min(periods[p+1].Time, rangeEnd)
+		window := min(verifyMaxWindow, (end-start)/2)
+		if window < verifyMinWindow {
+			continue
+		}
+		center := start + (end-start)/2
+
+		first := r.frameAt(center - window/2)
+		win, err := readVerifyWindow(bg, first-startFrame, int(r.frameAt(window)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		// The renderer holds the tracks over each buffer, sliding tones
+		// change by steps of a buffer
+		win.offset = int(first % t.BufferSize)
+		for frame := first - int64(win.offset); frame < first+int64(len(win.hann)); frame += t.BufferSize {
+			ms, idx := r.periodAt(frame, p)
+			r.sync(ms, idx)
+
+			var tracks [t.NumberOfChannels]t.Track
+			for ch := range tracks {
+				tracks[ch] = r.channels[ch].Track
+			}
+			win.tracks = append(win.tracks, tracks)
+		}
+
+		// The tones are expected as rendered in the middle of the window
+		ms, idx := r.periodAt((first+int64(len(win.hann)/2))/t.BufferSize*t.BufferSize, p)
+		r.sync(ms, idx)
+		for ch := range t.NumberOfChannels {
+			channel := &r.channels[ch]
+			if !channel.Track.IsTone() || channel.Track.Partials.Kind != t.PartialsOff ||
+				channel.Track.Amplitude.ToPercent() < verifyMinAmplitude {
+				continue
+			}
+
+			res := VerifyResult{Period: p, Channel: ch, Time: center, Type: channel.Track.Type}
+			var gains [2]float64
+			for e := range gains {
+				gains[e] = level * balance[e] * channel.Pan[e]
+			}
+			r.verifyTone(&res, channel, win, ch, gains, tol)
+			results = append(results, res)
+		}
+	}
+
+	return results, nil
+}
+
+// readVerifyWindow reads frames of a stereo file into Hann-windowed left and
+// right samples in the 16-bit range
+func readVerifyWindow(bg *BackgroundAudio, frame int64, frames int) (*verifyWindow, error) {
+	if err := bg.decoder.Seek(int(frame)); err != nil {
+		return nil, err
+	}
+
+	buf := make([][2]float64, frames)
+	for read := 0; read < frames; {
+		n, ok := bg.decoder.Stream(buf[read:])
+		read += n
+		if !ok {
+			if err := bg.decoder.Err(); err != nil {
+				return nil, err
+			}
+			if read < frames {
+				return nil, fmt.Errorf("unexpected end of audio at frame %d", frame+int64(read))
+			}
+		}
+	}
+
+	win := &verifyWindow{hann: make([]float64, frames)}
+	for e := range win.ears {
+		win.ears[e] = make([]float64, frames)
+	}
+	for i := range frames {
+		w := 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(frames-1))
+		win.hann[i] = w
+		win.sum += w
+		for e := range win.ears {
+			win.ears[e][i] = buf[i][e] * 32768 * w
+		}
+	}
+
+	return win, nil
+}
+
+// verifyTone measures a tone in both ears and compares it with the channel
+// state. The gains hold the level of each ear besides the tone amplitude.
+func (r *AudioRenderer) verifyTone(res *VerifyResult, channel *t.Channel, win *verifyWindow, ch int, gains [2]float64, tol VerifyTolerance) {
+	tr := &channel.Track
+	window := float64(len(win.hann)) / float64(r.SampleRate)
+	// Components closer than the main lobe of the window cannot be told apart
+	resolvable := math.Abs(tr.Resonance) >= 4/window
+
+	res.Expected = ToneMeasure{Carrier: tr.Carrier, Beat: tr.Resonance}
+	res.BeatChecked = tr.Type != t.TrackPureTone && resolvable
+	res.AmplitudeChecked = tr.Type != t.TrackIsochronicBeat && tr.Waveform != t.WaveformCustom
+
+	var found [2][]float64
+	for e := range win.ears {
+		components := toneComponents(tr, e, resolvable)
+
+		span := verifySearchSpan
+		if len(components) > 1 {
+			span = min(span, math.Abs(components[0].freq-components[1].freq)/2)
+		}
+
+		var amplitude float64
+		for i, c := range components {
+			slide := r.slidePhase(win, ch, e, i, resolvable, c.freq)
+			freq, peak := r.findPeak(win, e, c.freq, slide, span)
+			found[e] = append(found[e], freq)
+			if c.share > 0 {
+				amplitude += peak / c.share
+			}
+		}
+
+		// Isochronic pulses are only measured for their frequencies
+		if res.AmplitudeChecked && gains[e] > 0 {
+			level := gains[e] * waveformFundamental(tr.Waveform) * verifyFullScale / 100
+			res.Measured.Amplitude[e] = amplitude / float64(len(components)) / level
+			res.Expected.Amplitude[e] = win.amplitude(ch)
+		}
+	}
+
+	switch {
+	case tr.Type == t.TrackBinauralBeat:
+		res.Measured.Carrier = (found[0][0] + found[1][0]) / 2
+		res.Measured.Beat = found[0][0] - found[1][0]
+	case tr.Type == t.TrackIsochronicBeat && resolvable:
+		res.Measured.Carrier = (found[0][0] + found[1][0]) / 2
+		res.Measured.Beat = (found[0][1]+found[1][1])/2 - res.Measured.Carrier
+	case tr.Type == t.TrackMonauralBeat && resolvable:
+		high := (found[0][0] + found[1][0]) / 2
+		low := (found[0][1] + found[1][1]) / 2
+		res.Measured.Carrier = (high + low) / 2
+		res.Measured.Beat = high - low
+	default:
+		res.Measured.Carrier = (found[0][0] + found[1][0]) / 2
+		res.Measured.Beat = res.Expected.Beat
+	}
+
+	res.CarrierOK = math.Abs(res.Measured.Carrier-res.Expected.Carrier) <= tol.Carrier
+	res.BeatOK = !res.BeatChecked || math.Abs(res.Measured.Beat-res.Expected.Beat) <= tol.Beat
+	res.AmplitudeOK = true
+	if res.AmplitudeChecked {
+		for e := range gains {
+			if gains[e] <= 0 {
+				continue
+			}
+			diff := 20 * math.Log10(res.Measured.Amplitude[e]/res.Expected.Amplitude[e])
+			if math.IsNaN(diff) || math.Abs(diff) > tol.Amplitude {
+				res.AmplitudeOK = false
+			}
+		}
+	}
+}
+
+// slidePhase returns the phase of a component as rendered over a window, less
+// the phase of its frequency in the middle of the window. It is nil when the
+// component does not slide.
+func (r *AudioRenderer) slidePhase(win *verifyWindow, ch, ear, i int, resolvable bool, freq float64) []float64 {
+	freqs := make([]float64, len(win.tracks))
+	steady := true
+	for k := range win.tracks {
+		freqs[k] = toneComponents(&win.tracks[k][ch], ear, resolvable)[i].freq
+		steady = steady && freqs[k] == freq
+	}
+	if steady {
+		return nil
+	}
+
+	phase := make([]float64, len(win.hann))
+	var acc float64
+	for n := range phase {
+		phase[n] = acc
+		acc += 2 * math.Pi * (freqs[(n+win.offset)/t.BufferSize] - freq) / float64(r.SampleRate)
+	}
+	return phase
+}
+
+// amplitude returns the amplitude of a channel over a window in percent,
+// weighted as the window weighs the samples
+func (win *verifyWindow) amplitude(ch int) float64 {
+	var sum float64
+	for n, w := range win.hann {
+		sum += w * win.tracks[(n+win.offset)/t.BufferSize][ch].Amplitude.ToPercent()
+	}
+	return sum / win.sum
+}
+
+// toneComponents returns the sine components of a tone heard in an ear, the
+// ear is 0 for the left and 1 for the right. Components that cannot be told
+// apart are measured as one.
+func toneComponents(tr *t.Track, ear int, resolvable bool) []toneComponent {
+	c, b := tr.Carrier, tr.Resonance
+	switch tr.Type {
+	case t.TrackBinauralBeat:
+		if ear == 0 {
+			return []toneComponent{{c + b/2, 1}}
+		}
+		return []toneComponent{{c - b/2, 1}}
+	case t.TrackMonauralBeat:
+		if resolvable {
+			return []toneComponent{{c + b/2, 0.5}, {c - b/2, 0.5}}
+		}
+	case t.TrackIsochronicBeat:
+		// The pulses add sidebands at the beat from the carrier
+		if resolvable {
+			return []toneComponent{{c, 0}, {c + math.Abs(b), 0}}
+		}
+		return []toneComponent{{c, 0}}
+	}
+	return []toneComponent{{c, 1}}
+}
+
+// waveformFundamental returns the amplitude of the fundamental of a built-in
+// waveform relative to its peak
+func waveformFundamental(waveform t.WaveformType) float64 {
+	switch waveform {
+	case t.WaveformSquare:
+		return 4 / math.Pi
+	case t.WaveformTriangle:
+		return 8 / (math.Pi * math.Pi)
+	case t.WaveformSawtooth:
+		return 2 / math.Pi
+	}
+	return 1
+}
+
+// findPeak returns the frequency and the 16-bit amplitude of the strongest
+// sine within a span of a frequency in an ear of a window. The phase of a
+// sliding component is first removed from the samples, so the component
+// stands as a steady peak at its frequency in the middle of the window. The
+// span is scanned in half bins, then the peak is refined by a golden-section
+// search.
+func (r *AudioRenderer) findPeak(win *verifyWindow, ear int, freq float64, slide []float64, span float64) (float64, float64) {
+	sampleRate := float64(r.SampleRate)
+	samples := win.ears[ear]
+
+	z := make([]complex128, len(samples))
+	for n, x := range samples {
+		if slide != nil {
+			z[n] = complex(x*math.Cos(slide[n]), -x*math.Sin(slide[n]))
+		} else {
+			z[n] = complex(x, 0)
+		}
+	}
+	magnitude := func(f float64) float64 {
+		return goertzel(z, f, sampleRate)
+	}
+
+	step := sampleRate / float64(len(samples)) / 2
+	best, bestMag := freq, magnitude(freq)
+	for f := math.Max(freq-span, step); f <= freq+span; f += step {
+		if m := magnitude(f); m > bestMag {
+			best, bestMag = f, m
+		}
+	}
+
+	// Golden-section search of the peak around the best half bin
+	const ratio = 0.6180339887498949
+	lo, hi := best-step, best+step
+	x1, x2 := hi-ratio*(hi-lo), lo+ratio*(hi-lo)
+	m1, m2 := magnitude(x1), magnitude(x2)
+	for range verifyRefineSteps {
+		if m1 > m2 {
+			hi, x2, m2 = x2, x1, m1
+			x1 = hi - ratio*(hi-lo)
+			m1 = magnitude(x1)
+		} else {
+			lo, x1, m1 = x1, x2, m2
+			x2 = lo + ratio*(hi-lo)
+			m2 = magnitude(x2)
+		}
+	}
+	if m := math.Max(m1, m2); m > bestMag {
+		best, bestMag = (lo+hi)/2, m
+	}
+
+	return best, 2 * bestMag / win.sum
+}
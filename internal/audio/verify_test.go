@@ -0,0 +1,191 @@
+//go:build !wasm
+
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// verifyPeriods returns a binaural period followed by a pure, a monaural
+// square and an isochronic tone
+func verifyPeriods() []t.Period {
+	var p0, p1, p2 t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 200, Resonance: 10, Amplitude: t.AmplitudePercentToRaw(40)}
+	p0.TrackEnd = p0.TrackStart
+	p1.Time = 3000
+	p1.TrackStart[0] = t.Track{Type: t.TrackPureTone, Carrier: 300, Amplitude: t.AmplitudePercentToRaw(20), Pan: 0.5}
+	p1.TrackStart[1] = t.Track{Type: t.TrackMonauralBeat, Carrier: 500, Resonance: 8, Amplitude: t.AmplitudePercentToRaw(20), Waveform: t.WaveformSquare}
+	p1.TrackStart[2] = t.Track{Type: t.TrackIsochronicBeat, Carrier: 150, Resonance: 6, Amplitude: t.AmplitudePercentToRaw(15)}
+	p1.TrackEnd = p1.TrackStart
+	p2.Time = 6000
+	return []t.Period{p0, p1, p2}
+}
+
+// renderVerifyWav renders periods to a WAV file
+func renderVerifyWav(ts *testing.T, periods []t.Period, options *AudioRendererOptions) string {
+	r, err := NewAudioRenderer(periods, options)
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	path := filepath.Join(ts.TempDir(), "verify.wav")
+	if err := r.RenderWav(path); err != nil {
+		ts.Fatalf("RenderWav failed: %v", err)
+	}
+	return path
+}
+
+func TestVerifyWAV(ts *testing.T) {
+	options := &AudioRendererOptions{SampleRate: 44100, Volume: 80, Balance: 0.2, NormalizeGain: -3}
+	path := renderVerifyWav(ts, verifyPeriods(), options)
+
+	results, err := VerifyWAV(path, verifyPeriods(), options, DefaultVerifyTolerance())
+	if err != nil {
+		ts.Fatalf("VerifyWAV failed: %v", err)
+	}
+	if len(results) != 4 {
+		ts.Fatalf("expected 4 verified tones, got %d", len(results))
+	}
+
+	for _, res := range results {
+		if !res.Passed() {
+			ts.Errorf("period %d channel %d: expected a pass, got %+v", res.Period, res.Channel, res)
+		}
+	}
+
+	if res := results[0]; !res.BeatChecked || !res.AmplitudeChecked || res.Time != 1500 {
+		ts.Errorf("expected the binaural beat and amplitude checked at 1500ms, got %+v", res)
+	}
+	if res := results[3]; !res.BeatChecked || res.AmplitudeChecked {
+		ts.Errorf("expected the isochronic beat checked without its amplitude, got %+v", res)
+	}
+}
+
+func TestVerifyWAV_Slide(ts *testing.T) {
+	var p0, p1 t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 200, Resonance: 4, Amplitude: t.AmplitudePercentToRaw(20)}
+	p0.TrackEnd[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 300, Resonance: 10, Amplitude: t.AmplitudePercentToRaw(40)}
+	p0.Transition = t.TransitionEaseOut
+	p1.Time = 8000
+	periods := []t.Period{p0, p1}
+
+	options := &AudioRendererOptions{SampleRate: 44100, Volume: 100}
+	results, err := VerifyWAV(renderVerifyWav(ts, periods, options), periods, options, DefaultVerifyTolerance())
+	if err != nil {
+		ts.Fatalf("VerifyWAV failed: %v", err)
+	}
+
+	// The tone slides by 50 Hz across the window, it is measured at its center
+	res := results[0]
+	if !res.Passed() || math.Abs(res.Measured.Carrier-res.Expected.Carrier) > 0.05 {
+		ts.Errorf("expected the sliding tone to pass at its center, got %+v", res)
+	}
+}
+
+func TestVerifyWAV_Range(ts *testing.T) {
+	options := &AudioRendererOptions{SampleRate: 44100, Volume: 100, Start: 1000, End: 5000, EdgeFade: 200}
+	path := renderVerifyWav(ts, verifyPeriods(), options)
+
+	results, err := VerifyWAV(path, verifyPeriods(), options, DefaultVerifyTolerance())
+	if err != nil {
+		ts.Fatalf("VerifyWAV failed: %v", err)
+	}
+	if len(results) != 4 {
+		ts.Fatalf("expected 4 verified tones, got %d", len(results))
+	}
+
+	// Each period is analyzed in the middle of its part of the range,
+	// past the faded edges
+	for i, want := range []int{2100, 3900, 3900, 3900} {
+		res := results[i]
+		if !res.Passed() {
+			ts.Errorf("period %d channel %d: expected a pass, got %+v", res.Period, res.Channel, res)
+		}
+		if res.Time != want {
+			ts.Errorf("result %d: expected the analysis at %dms, got %dms", i, want, res.Time)
+		}
+	}
+
+	// The range file is shorter than the sequence
+	full := &AudioRendererOptions{SampleRate: 44100, Volume: 100}
+	if _, err := VerifyWAV(path, verifyPeriods(), full, DefaultVerifyTolerance()); err == nil || !strings.Contains(err.Error(), "shorter than the sequence") {
+		ts.Errorf("expected the range file to be shorter than the sequence, got %v", err)
+	}
+	longer := &AudioRendererOptions{SampleRate: 44100, Volume: 100, Start: 1000}
+	if _, err := VerifyWAV(path, verifyPeriods(), longer, DefaultVerifyTolerance()); err == nil || !strings.Contains(err.Error(), "shorter than the time range") {
+		ts.Errorf("expected the range file to be shorter than the time range, got %v", err)
+	}
+}
+
+func TestVerifyWAV_Mismatch(ts *testing.T) {
+	options := &AudioRendererOptions{SampleRate: 44100, Volume: 100}
+	path := renderVerifyWav(ts, verifyPeriods(), options)
+
+	expected := verifyPeriods()
+	expected[0].TrackStart[0].Carrier = 202
+	expected[0].TrackEnd[0].Carrier = 202
+	expected[1].TrackStart[1].Resonance = 7
+	expected[1].TrackEnd[1].Resonance = 7
+	expected[1].TrackStart[0].Amplitude = t.AmplitudePercentToRaw(30)
+	expected[1].TrackEnd[0].Amplitude = t.AmplitudePercentToRaw(30)
+
+	results, err := VerifyWAV(path, expected, options, DefaultVerifyTolerance())
+	if err != nil {
+		ts.Fatalf("VerifyWAV failed: %v", err)
+	}
+
+	want := []struct{ carrier, beat, amplitude bool }{
+		{false, true, true},
+		{true, true, false},
+		{true, false, true},
+		{true, true, true},
+	}
+	for i, w := range want {
+		res := results[i]
+		if res.CarrierOK != w.carrier || res.BeatOK != w.beat || res.AmplitudeOK != w.amplitude {
+			ts.Errorf("result %d: expected carrier/beat/amplitude %v/%v/%v, got %v/%v/%v (%+v)",
+				i, w.carrier, w.beat, w.amplitude, res.CarrierOK, res.BeatOK, res.AmplitudeOK, res)
+		}
+	}
+}
+
+func TestVerifyWAV_Errors(ts *testing.T) {
+	options := &AudioRendererOptions{SampleRate: 44100, Volume: 100}
+	path := renderVerifyWav(ts, verifyPeriods(), options)
+
+	longer := verifyPeriods()
+	longer[2].Time = 8000
+
+	tests := []struct {
+		name    string
+		periods []t.Period
+		options *AudioRendererOptions
+		want    string
+	}{
+		{"sample rate", verifyPeriods(), &AudioRendererOptions{SampleRate: 48000, Volume: 100}, "sample rate"},
+		{"shorter", longer, options, "shorter than the sequence"},
+		{"layout", verifyPeriods(), &AudioRendererOptions{SampleRate: 44100, Volume: 100, Layout: t.Layout51}, "stereo output only"},
+	}
+
+	for _, tt := range tests {
+		if _, err := VerifyWAV(path, tt.periods, tt.options, DefaultVerifyTolerance()); err == nil || !strings.Contains(err.Error(), tt.want) {
+			ts.Errorf("%s: expected an error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+}
@@ -15,34 +15,64 @@ package audio
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 
 	"github.com/gopxl/beep/v2"
 	bwav "github.com/gopxl/beep/v2/wav"
 	"github.com/synapseq-foundation/synapseq/v3/internal/info"
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
 
 // RenderWav renders the audio to a WAV file using go-audio/wav
 func (r *AudioRenderer) RenderWav(outPath string) error {
+	return r.RenderWavContext(context.Background(), outPath)
+}
+
+// RenderWavContext is RenderWav stopped when the context is done. The file
+// of a failed or stopped render is removed.
+func (r *AudioRenderer) RenderWavContext(ctx context.Context, outPath string) (err error) {
 	out, err := os.Create(outPath)
 	if err != nil {
 		return fmt.Errorf("create output: %w", err)
 	}
-	defer out.Close()
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(outPath)
+		}
+	}()
 
-	streamer := newRendererStreamer(r)
+	// Stereo is encoded by beep, which has no multichannel support
+	if r.Layout != t.LayoutStereo {
+		return r.renderWavExtensible(ctx, out)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	streamer := newRendererStreamer(ctx, r)
 	format := beep.Format{
 		SampleRate:  beep.SampleRate(r.SampleRate),
 		NumChannels: audioChannels,
 		Precision:   audioBitDepth / 8,
 	}
 
-	if err := bwav.Encode(out, streamer, format); err != nil {
+	err = bwav.Encode(out, streamer, format)
+
+	// The render stops with the encoder and closes its background decoders
+	cancel()
+	streamer.wait()
+
+	if err != nil {
 		return err
 	}
 	if streamer.err != nil {
@@ -52,6 +82,85 @@ func (r *AudioRenderer) RenderWav(outPath string) error {
 	return nil
 }
 
+// renderWavExtensible renders the audio to a WAVE_FORMAT_EXTENSIBLE file
+// with the channel mask of the layout
+func (r *AudioRenderer) renderWavExtensible(ctx context.Context, out io.WriteSeeker) error {
+	const (
+		waveFormatExtensible = 0xFFFE
+		fmtChunkSize         = 40
+		headerSize           = 12 + 8 + fmtChunkSize + 8 // RIFF + fmt + data headers
+	)
+	// KSDATAFORMAT_SUBTYPE_PCM
+	pcmSubFormat := [16]byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0xAA, 0x00, 0x38, 0x9B, 0x71}
+
+	channels := r.Layout.Channels()
+	blockAlign := channels * audioBitDepth / 8
+
+	writeHeader := func(dataSize uint32) error {
+		var buf bytes.Buffer
+		buf.WriteString("RIFF")
+		binary.Write(&buf, binary.LittleEndian, uint32(headerSize-8)+dataSize)
+		buf.WriteString("WAVE")
+		buf.WriteString("fmt ")
+		binary.Write(&buf, binary.LittleEndian, uint32(fmtChunkSize))
+		binary.Write(&buf, binary.LittleEndian, uint16(waveFormatExtensible))
+		binary.Write(&buf, binary.LittleEndian, uint16(channels))
+		binary.Write(&buf, binary.LittleEndian, uint32(r.SampleRate))
+		binary.Write(&buf, binary.LittleEndian, uint32(r.SampleRate*blockAlign))
+		binary.Write(&buf, binary.LittleEndian, uint16(blockAlign))
+		binary.Write(&buf, binary.LittleEndian, uint16(audioBitDepth))
+		binary.Write(&buf, binary.LittleEndian, uint16(22)) // extension size
+		binary.Write(&buf, binary.LittleEndian, uint16(audioBitDepth))
+		binary.Write(&buf, binary.LittleEndian, r.Layout.Mask())
+		buf.Write(pcmSubFormat[:])
+		buf.WriteString("data")
+		binary.Write(&buf, binary.LittleEndian, dataSize)
+
+		_, err := out.Write(buf.Bytes())
+		return err
+	}
+
+	// Sizes are patched once the length is known
+	if err := writeHeader(0); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	dataSize := int64(0)
+	counter := &countingWriter{w: out, n: &dataSize}
+	if err := r.RenderRawContext(ctx, counter); err != nil {
+		return err
+	}
+
+	if dataSize > math.MaxUint32-headerSize {
+		return fmt.Errorf("output exceeds the 4 GiB limit of WAV files")
+	}
+
+	if _, err := out.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek header: %w", err)
+	}
+	if err := writeHeader(uint32(dataSize)); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	if _, err := out.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("seek end: %w", err)
+	}
+
+	return nil
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+// Write writes to the underlying writer and counts the bytes
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	*cw.n += int64(n)
+	return n, err
+}
+
 // WriteICMTChunkFromTextFile appends an ICMT chunk with base64-encoded content from the specified text file
 func WriteICMTChunkFromTextFile(wavPath string, metadata *info.Metadata) error {
 	if metadata == nil {
@@ -63,6 +172,9 @@ func WriteICMTChunkFromTextFile(wavPath string, metadata *info.Metadata) error {
 	header.WriteString("VERSION=" + metadata.Version() + "\n")
 	header.WriteString("GENERATED=" + metadata.Generated() + "\n")
 	header.WriteString("PLATFORM=" + metadata.Platform() + "\n")
+	if metadata.Loudness() != "" {
+		header.WriteString("LOUDNESS=" + metadata.Loudness() + "\n")
+	}
 	header.WriteString("CONTENT=\n")
 	header.WriteString(metadata.Content() + "\n")
 
@@ -150,8 +262,8 @@ func ExtractTextSequenceFromWAV(wavPath string) (string, error) {
 					readContent := false
 
 					var (
-						id, generated, version, platform string
-						base64Content                    []byte
+						id, generated, version, platform, loudness string
+						base64Content                              []byte
 					)
 
 					for _, line := range lines {
@@ -166,6 +278,8 @@ func ExtractTextSequenceFromWAV(wavPath string) (string, error) {
 							version = string(bytes.TrimPrefix(line, []byte("VERSION=")))
 						} else if bytes.HasPrefix(line, []byte("PLATFORM=")) {
 							platform = string(bytes.TrimPrefix(line, []byte("PLATFORM=")))
+						} else if bytes.HasPrefix(line, []byte("LOUDNESS=")) {
+							loudness = string(bytes.TrimPrefix(line, []byte("LOUDNESS=")))
 						} else if bytes.HasPrefix(line, []byte("CONTENT=")) {
 							readContent = true
 						}
@@ -182,6 +296,9 @@ func ExtractTextSequenceFromWAV(wavPath string) (string, error) {
 					content += fmt.Sprintf("#  Date     : %s\n", generated)
 					content += fmt.Sprintf("#  Version  : %s\n", version)
 					content += fmt.Sprintf("#  Platform : %s\n", platform)
+					if loudness != "" {
+						content += fmt.Sprintf("#  Loudness : %s\n", loudness)
+					}
 					content += "# ================================================\n\n\n"
 					content += string(decoded)
 
@@ -14,6 +14,9 @@
 package audio
 
 import (
+	"context"
+	"encoding/binary"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -61,6 +64,8 @@ func TestWriteAndExtractICMTChunk_Integration(ts *testing.T) {
 		ts.Fatalf("ReadWAVMetadata error: %v", err)
 	}
 
+	metadata.SetLoudness(-17.5, -23, -5.5)
+
 	// Write the ICMT chunk with the sequence
 	if err := WriteICMTChunkFromTextFile(wavPath, metadata); err != nil {
 		ts.Fatalf("WriteICMTChunkFromTextFile error: %v", err)
@@ -82,4 +87,93 @@ func TestWriteAndExtractICMTChunk_Integration(ts *testing.T) {
 	if !strings.Contains(content, "alpha") {
 		ts.Fatalf("Extracted content does not contain preset: %q", content)
 	}
+	if !strings.Contains(content, "#  Loudness : target -23.00 LUFS, measured -17.50 LUFS, gain -5.50 dB") {
+		ts.Fatalf("Extracted content does not contain loudness: %q", content)
+	}
+}
+
+func TestRenderWav_Extensible(ts *testing.T) {
+	var p0, pEnd t.Period
+	p0.TrackStart[0] = t.Track{
+		Type:      t.TrackPinkNoise,
+		Amplitude: t.AmplitudePercentToRaw(20),
+		Route:     t.RouteAll,
+	}
+	p0.TrackEnd = p0.TrackStart
+	pEnd.Time = 500
+
+	r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{
+		SampleRate: 44100,
+		Volume:     100,
+		Layout:     t.LayoutQuad,
+	})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	wavPath := filepath.Join(ts.TempDir(), "quad.wav")
+	if err := r.RenderWav(wavPath); err != nil {
+		ts.Fatalf("RenderWav failed: %v", err)
+	}
+
+	data, err := os.ReadFile(wavPath)
+	if err != nil {
+		ts.Fatalf("failed to read WAV: %v", err)
+	}
+
+	le := binary.LittleEndian
+	dataSize := 22050 * 4 * 2
+	if len(data) != 68+dataSize {
+		ts.Fatalf("expected %d bytes, got %d", 68+dataSize, len(data))
+	}
+	if string(data[0:4]) != "RIFF" || int(le.Uint32(data[4:8])) != len(data)-8 {
+		ts.Errorf("unexpected RIFF header")
+	}
+	if le.Uint16(data[20:22]) != 0xFFFE {
+		ts.Errorf("expected WAVE_FORMAT_EXTENSIBLE, got %#x", le.Uint16(data[20:22]))
+	}
+	if le.Uint16(data[22:24]) != 4 {
+		ts.Errorf("expected 4 channels, got %d", le.Uint16(data[22:24]))
+	}
+	if le.Uint32(data[40:44]) != t.LayoutQuad.Mask() {
+		ts.Errorf("expected channel mask %#x, got %#x", t.LayoutQuad.Mask(), le.Uint32(data[40:44]))
+	}
+	if string(data[60:64]) != "data" || int(le.Uint32(data[64:68])) != dataSize {
+		ts.Errorf("unexpected data chunk header")
+	}
+}
+
+func TestAudioRenderer_RenderWavContext_Cancel(ts *testing.T) {
+	for _, layout := range []t.ChannelLayout{t.LayoutStereo, t.LayoutQuad} {
+		var p0, pEnd t.Period
+		p0.TrackStart[0] = t.Track{Type: t.TrackPinkNoise, Amplitude: t.AmplitudePercentToRaw(20)}
+		p0.TrackEnd = p0.TrackStart
+		pEnd.Time = 60000
+
+		r, err := NewAudioRenderer([]t.Period{p0, pEnd}, &AudioRendererOptions{
+			SampleRate:     44100,
+			Volume:         100,
+			Layout:         layout,
+			BackgroundPath: filepath.Join("testdata", "noise.wav"),
+		})
+		if err != nil {
+			ts.Fatalf("NewAudioRenderer failed: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		wavPath := filepath.Join(ts.TempDir(), "cancelled.wav")
+		if err := r.RenderWavContext(ctx, wavPath); !errors.Is(err, context.Canceled) {
+			ts.Fatalf("%v: expected the context error, got: %v", layout, err)
+		}
+		if _, err := os.Stat(wavPath); !os.IsNotExist(err) {
+			ts.Errorf("%v: expected the partial WAV removed, got: %v", layout, err)
+		}
+		for name, bg := range r.backgroundAudio {
+			if bg.IsEnabled() {
+				ts.Errorf("%v: expected background %q closed after cancellation", layout, name)
+			}
+		}
+	}
 }
@@ -0,0 +1,133 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"fmt"
+	"math"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// maxWaveformFrames bounds the length of a single-cycle waveform file
+const maxWaveformFrames = 1 << 16
+
+// loadWaveform builds the wave table of a user-defined waveform
+func loadWaveform(src t.WaveformSource) ([]int, error) {
+	if len(src.Harmonics) > 0 {
+		return harmonicTable(src.Harmonics), nil
+	}
+
+	cycle, err := loadWaveformCycle(src)
+	if err != nil {
+		return nil, err
+	}
+
+	table, ok := cycleTable(cycle)
+	if !ok {
+		return nil, fmt.Errorf("waveform %q is silent", src.Name)
+	}
+
+	return table, nil
+}
+
+// harmonicTable builds a wave table from the amplitudes of its harmonics,
+// starting at the fundamental, scaled to the full table amplitude
+func harmonicTable(harmonics []float64) []int {
+	cycle := make([]float64, t.SineTableSize)
+	for j := range cycle {
+		phase := float64(j) * 2.0 * math.Pi / float64(t.SineTableSize)
+		for k, amplitude := range harmonics {
+			cycle[j] += amplitude * math.Sin(float64(k+1)*phase)
+		}
+	}
+
+	table, _ := normalizeTable(cycle)
+	return table
+}
+
+// loadWaveformCycle decodes a single-cycle file into mono samples at its own rate
+func loadWaveformCycle(src t.WaveformSource) ([]float64, error) {
+	label := fmt.Sprintf("waveform %q", src.Name)
+
+	bg, err := openSourceAudio(src.Path, label)
+	if err != nil {
+		return nil, err
+	}
+	defer bg.Close()
+
+	if frames := bg.decoder.Len(); frames > maxWaveformFrames {
+		return nil, fmt.Errorf("%s is too long for a single cycle (%d frames, at most %d)", label, frames, maxWaveformFrames)
+	}
+
+	// The cycle is stretched over the table, its sample rate does not matter
+	pcm, err := bg.decodePCM(bg.sampleRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", label, err)
+	}
+
+	cycle := make([]float64, len(pcm)/audioChannels)
+	for i := range cycle {
+		cycle[i] = (float64(pcm[2*i]) + float64(pcm[2*i+1])) / 2
+	}
+
+	return cycle, nil
+}
+
+// cycleTable resamples one cycle of a waveform over a wave table. The cycle
+// wraps around, its DC offset is removed and its peak scaled to the full
+// table amplitude. It reports false for a silent cycle.
+func cycleTable(cycle []float64) ([]int, bool) {
+	var mean float64
+	for _, v := range cycle {
+		mean += v
+	}
+	mean /= float64(len(cycle))
+
+	resampled := make([]float64, t.SineTableSize)
+	for j := range resampled {
+		pos := float64(j) * float64(len(cycle)) / float64(t.SineTableSize)
+		i := int(pos)
+		frac := pos - float64(i)
+		next := cycle[(i+1)%len(cycle)]
+		resampled[j] = cycle[i]*(1-frac) + next*frac - mean
+	}
+
+	return normalizeTable(resampled)
+}
+
+// normalizeTable scales a cycle so its peak reaches the full table amplitude.
+// It reports false for a silent cycle.
+func normalizeTable(cycle []float64) ([]int, bool) {
+	var peak float64
+	for _, v := range cycle {
+		peak = max(peak, math.Abs(v))
+	}
+	if peak < 1e-9 {
+		return nil, false
+	}
+
+	table := make([]int, len(cycle))
+	for j, v := range cycle {
+		table[j] = int(t.WaveTableAmplitude * v / peak)
+	}
+
+	return table, true
+}
+
+// waveTableIndex returns the index of the wave table of a track waveform
+func (r *AudioRenderer) waveTableIndex(tr *t.Track) int {
+	if tr.Waveform != t.WaveformCustom {
+		return int(tr.Waveform)
+	}
+	return r.customWaves[tr.WaveformName]
+}
@@ -0,0 +1,138 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package audio
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+func TestAudioRenderer_HarmonicWaveform(ts *testing.T) {
+	var p0, p1 t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackPureTone, Carrier: 200, Amplitude: t.AmplitudePercentToRaw(50), Waveform: t.WaveformCustom, WaveformName: "odd"}
+	p0.TrackEnd[0] = p0.TrackStart[0]
+	p1.Time = 1000
+
+	r, err := NewAudioRenderer([]t.Period{p0, p1}, &AudioRendererOptions{
+		SampleRate: 44100,
+		Volume:     100,
+		Waveforms:  []t.WaveformSource{{Name: "odd", Harmonics: []float64{1, 0, 0.5}}},
+	})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+
+	var left []int
+	if err := r.Render(func(samples []int) error {
+		for i := 0; i < len(samples); i += audioChannels {
+			left = append(left, samples[i])
+		}
+		return nil
+	}); err != nil {
+		ts.Fatalf("Render failed: %v", err)
+	}
+
+	// The third harmonic is at half the fundamental, the second is absent
+	fundamental := math.Sqrt(goertzelPower(left, 200, 44100))
+	if got := math.Sqrt(goertzelPower(left, 600, 44100)) / fundamental; math.Abs(got-0.5) > 0.01 {
+		ts.Errorf("expected the third harmonic at ratio 0.5, got %.3f", got)
+	}
+	if got := math.Sqrt(goertzelPower(left, 400, 44100)) / fundamental; got > 0.01 {
+		ts.Errorf("expected no second harmonic, got ratio %.3f", got)
+	}
+}
+
+func TestLoadWaveform_Cycle(ts *testing.T) {
+	// One second at 441 Hz of a 1 Hz sine is a single cycle of 441 frames
+	table, err := loadWaveform(t.WaveformSource{Name: "cycle", Path: writeSineWav(ts, 441, 1, 1)})
+	if err != nil {
+		ts.Fatalf("loadWaveform failed: %v", err)
+	}
+	if len(table) != t.SineTableSize {
+		ts.Fatalf("expected a table of %d entries, got %d", t.SineTableSize, len(table))
+	}
+
+	// The cycle is stretched over the table at full amplitude
+	sine := InitWaveformTables()[t.WaveformSine]
+	for j := range table {
+		if diff := abs(table[j] - sine[j]); diff > t.WaveTableAmplitude/100 {
+			ts.Fatalf("entry %d: expected %d, got %d", j, sine[j], table[j])
+		}
+	}
+}
+
+func TestLoadWaveform_Errors(ts *testing.T) {
+	if _, err := loadWaveform(t.WaveformSource{Name: "warm", Path: filepath.Join(ts.TempDir(), "missing.wav")}); err == nil ||
+		!strings.Contains(err.Error(), `waveform "warm"`) {
+		ts.Errorf("expected an error naming the waveform, got %v", err)
+	}
+
+	long := writeRampWav(ts, maxWaveformFrames+1, nil)
+	if _, err := loadWaveform(t.WaveformSource{Name: "long", Path: long}); err == nil ||
+		!strings.Contains(err.Error(), "too long") {
+		ts.Errorf("expected an error for a long cycle, got %v", err)
+	}
+
+	if _, ok := cycleTable([]float64{0.25, 0.25, 0.25}); ok {
+		ts.Errorf("expected a constant cycle to be silent")
+	}
+}
+
+func TestSync_CustomWaveTable(ts *testing.T) {
+	var p0, p1 t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackPureTone, Carrier: 200, Amplitude: t.AmplitudePercentToRaw(20), Waveform: t.WaveformCustom, WaveformName: "flute"}
+	p0.TrackStart[1] = t.Track{
+		Type:         t.TrackPinkNoise,
+		Effect:       t.Effect{Type: t.EffectPulse, Intensity: t.IntensityPercentToRaw(50)},
+		Resonance:    4,
+		Amplitude:    t.AmplitudePercentToRaw(20),
+		Waveform:     t.WaveformCustom,
+		WaveformName: "organ",
+	}
+	p0.TrackStart[2] = t.Track{Type: t.TrackPureTone, Carrier: 300, Amplitude: t.AmplitudePercentToRaw(20), Waveform: t.WaveformTriangle}
+	p0.TrackEnd = p0.TrackStart
+	p1.Time = 1000
+
+	r, err := NewAudioRenderer([]t.Period{p0, p1}, &AudioRendererOptions{
+		SampleRate: 44100,
+		Volume:     100,
+		Waveforms: []t.WaveformSource{
+			{Name: "organ", Harmonics: []float64{1, 0.5}},
+			{Name: "flute", Harmonics: []float64{1, 0, 0.2}},
+		},
+	})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+	r.sync(0, 0)
+
+	// User-defined tables follow the built-in ones in declaration order
+	for ch, want := range []int{int(t.WaveformCustom) + 1, int(t.WaveformCustom), int(t.WaveformTriangle)} {
+		if got := r.channels[ch].WaveTable; got != want {
+			ts.Errorf("channel %d: expected wave table %d, got %d", ch, want, got)
+		}
+	}
+
+	// The pulse LFO of the noise follows the custom table
+	channel := &r.channels[1]
+	channel.Offset[1] = t.SineTableSize / 4 << 16
+	modVal := float64(r.waveTables[channel.WaveTable][t.SineTableSize/4])
+	want := (modVal - 0.3*t.WaveTableAmplitude) / (0.7 * t.WaveTableAmplitude)
+	want = want * want * (3 - 2*want)
+	if got := r.calcPulseFactor(channel); math.Abs(got-want) > 1e-12 {
+		ts.Errorf("expected the pulse factor %.6f from the custom table, got %.6f", want, got)
+	}
+}
@@ -17,9 +17,10 @@ import (
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
 
-// InitWaveformTables initializes the waveform tables
-func InitWaveformTables() [4][]int {
-	var waveTables [4][]int
+// InitWaveformTables initializes the tables of the built-in waveforms, indexed
+// by waveform type
+func InitWaveformTables() [][]int {
+	waveTables := make([][]int, t.WaveformCustom)
 	for i := range waveTables {
 		waveformTable := make([]int, t.SineTableSize)
 
@@ -0,0 +1,251 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package chart
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// anchor is the horizontal alignment of a text
+type anchor int
+
+const (
+	anchorStart anchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+// point is a position on a canvas, in pixels
+type point struct {
+	x, y float64
+}
+
+// canvas draws a chart in an image format. Texts are vertically centered on
+// their position.
+type canvas interface {
+	rect(x, y, w, h float64, fill color.RGBA)
+	line(x0, y0, x1, y1 float64, stroke color.RGBA, width float64, dashed bool)
+	polyline(pts []point, stroke color.RGBA, width float64)
+	text(x, y float64, s string, fill color.RGBA, align anchor)
+	image(x, y float64, img *image.RGBA)
+	encode(w io.Writer) error
+}
+
+// newCanvas returns a canvas of a size in a format
+func newCanvas(format Format, width, height int) canvas {
+	if format == FormatSVG {
+		return newSVGCanvas(width, height)
+	}
+	return newPNGCanvas(width, height)
+}
+
+// textWidth returns the width of a text in pixels, the same in both formats
+func textWidth(s string) float64 {
+	return float64(utf8.RuneCountInString(s) * glyphAdvance * fontScale)
+}
+
+// svgCanvas writes SVG elements
+type svgCanvas struct {
+	width, height int
+	body          strings.Builder
+}
+
+// newSVGCanvas returns an SVG canvas of a size
+func newSVGCanvas(width, height int) *svgCanvas {
+	return &svgCanvas{width: width, height: height}
+}
+
+// svgColor returns the SVG notation of a color
+func svgColor(c color.RGBA) string {
+	if c.A == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("rgba(%d,%d,%d,%.3f)", c.R, c.G, c.B, float64(c.A)/255)
+}
+
+func (sc *svgCanvas) rect(x, y, w, h float64, fill color.RGBA) {
+	fmt.Fprintf(&sc.body, "<rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"%s\"/>\n", x, y, w, h, svgColor(fill))
+}
+
+func (sc *svgCanvas) line(x0, y0, x1, y1 float64, stroke color.RGBA, width float64, dashed bool) {
+	dash := ""
+	if dashed {
+		dash = fmt.Sprintf(" stroke-dasharray=\"%d %d\"", dashOn, dashOff)
+	}
+	fmt.Fprintf(&sc.body, "<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"%s\" stroke-width=\"%.1f\"%s/>\n",
+		x0, y0, x1, y1, svgColor(stroke), width, dash)
+}
+
+func (sc *svgCanvas) polyline(pts []point, stroke color.RGBA, width float64) {
+	if len(pts) < 2 {
+		return
+	}
+	coords := make([]string, len(pts))
+	for i, p := range pts {
+		coords[i] = fmt.Sprintf("%.1f,%.1f", p.x, p.y)
+	}
+	fmt.Fprintf(&sc.body, "<polyline points=\"%s\" fill=\"none\" stroke=\"%s\" stroke-width=\"%.1f\" stroke-linejoin=\"round\"/>\n",
+		strings.Join(coords, " "), svgColor(stroke), width)
+}
+
+func (sc *svgCanvas) text(x, y float64, s string, fill color.RGBA, align anchor) {
+	textAnchor := [...]string{"start", "middle", "end"}[align]
+	fmt.Fprintf(&sc.body, "<text x=\"%.1f\" y=\"%.1f\" fill=\"%s\" text-anchor=\"%s\" dominant-baseline=\"middle\">%s</text>\n",
+		x, y, svgColor(fill), textAnchor, escapeXML(s))
+}
+
+func (sc *svgCanvas) image(x, y float64, img *image.RGBA) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return
+	}
+	b := img.Bounds()
+	fmt.Fprintf(&sc.body, "<image x=\"%.1f\" y=\"%.1f\" width=\"%d\" height=\"%d\" preserveAspectRatio=\"none\" href=\"data:image/png;base64,%s\"/>\n",
+		x, y, b.Dx(), b.Dy(), base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+func (sc *svgCanvas) encode(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" "+
+		"font-family=\"monospace\" font-size=\"%d\">\n%s</svg>\n",
+		sc.width, sc.height, sc.width, sc.height, glyphHeight*fontScale+2, sc.body.String())
+	return err
+}
+
+// escapeXML escapes the markup characters of a text
+func escapeXML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;").Replace(s)
+}
+
+// pngCanvas rasterizes on an image
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+// newPNGCanvas returns a PNG canvas of a size
+func newPNGCanvas(width, height int) *pngCanvas {
+	return &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+}
+
+// blend paints a pixel over the image
+func (pc *pngCanvas) blend(x, y int, c color.RGBA) {
+	if !(image.Point{x, y}.In(pc.img.Bounds())) {
+		return
+	}
+	if c.A == 255 {
+		pc.img.SetRGBA(x, y, c)
+		return
+	}
+
+	dst := pc.img.RGBAAt(x, y)
+	a := uint32(c.A)
+	mix := func(s, d uint8) uint8 {
+		return uint8((uint32(s)*a + uint32(d)*(255-a)) / 255)
+	}
+	pc.img.SetRGBA(x, y, color.RGBA{mix(c.R, dst.R), mix(c.G, dst.G), mix(c.B, dst.B), 255})
+}
+
+func (pc *pngCanvas) rect(x, y, w, h float64, fill color.RGBA) {
+	r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+	if fill.A == 255 {
+		draw.Draw(pc.img, r, &image.Uniform{fill}, image.Point{}, draw.Src)
+		return
+	}
+	r = r.Intersect(pc.img.Bounds())
+	for py := r.Min.Y; py < r.Max.Y; py++ {
+		for px := r.Min.X; px < r.Max.X; px++ {
+			pc.blend(px, py, fill)
+		}
+	}
+}
+
+// line stamps a square of the width every half pixel along the segment
+func (pc *pngCanvas) line(x0, y0, x1, y1 float64, stroke color.RGBA, width float64, dashed bool) {
+	length := math.Hypot(x1-x0, y1-y0)
+	steps := max(1, int(math.Ceil(length*2)))
+	half := width / 2
+
+	// Pixels are painted once, so translucent strokes keep their tone
+	painted := make(map[image.Point]bool)
+	for i := 0; i <= steps; i++ {
+		f := float64(i) / float64(steps)
+		if dashed && math.Mod(f*length, dashOn+dashOff) >= dashOn {
+			continue
+		}
+		x, y := x0+(x1-x0)*f, y0+(y1-y0)*f
+		for py := int(math.Floor(y - half + 0.5)); py < int(math.Floor(y+half+0.5)); py++ {
+			for px := int(math.Floor(x - half + 0.5)); px < int(math.Floor(x+half+0.5)); px++ {
+				p := image.Point{px, py}
+				if !painted[p] {
+					painted[p] = true
+					pc.blend(px, py, stroke)
+				}
+			}
+		}
+	}
+}
+
+func (pc *pngCanvas) polyline(pts []point, stroke color.RGBA, width float64) {
+	for i := 1; i < len(pts); i++ {
+		pc.line(pts[i-1].x, pts[i-1].y, pts[i].x, pts[i].y, stroke, width, false)
+	}
+}
+
+// text draws the bitmap font, uppercase as the font has no lowercase letters
+func (pc *pngCanvas) text(x, y float64, s string, fill color.RGBA, align anchor) {
+	s = strings.ToUpper(s)
+	switch align {
+	case anchorMiddle:
+		x -= textWidth(s) / 2
+	case anchorEnd:
+		x -= textWidth(s)
+	}
+
+	top := int(math.Round(y)) - glyphHeight*fontScale/2
+	left := int(math.Round(x))
+	i := 0
+	for _, r := range s {
+		g := glyphFor(r)
+		for row := range glyphHeight {
+			for col := range glyphWidth {
+				if g[row]&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				for dy := range fontScale {
+					for dx := range fontScale {
+						pc.blend(left+(i*glyphAdvance+col)*fontScale+dx, top+row*fontScale+dy, fill)
+					}
+				}
+			}
+		}
+		i++
+	}
+}
+
+func (pc *pngCanvas) image(x, y float64, img *image.RGBA) {
+	b := img.Bounds()
+	at := image.Pt(int(math.Round(x)), int(math.Round(y)))
+	draw.Draw(pc.img, b.Add(at), img, b.Min, draw.Src)
+}
+
+func (pc *pngCanvas) encode(w io.Writer) error {
+	return png.Encode(w, pc.img)
+}
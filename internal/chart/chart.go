@@ -0,0 +1,272 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+// Package chart draws timeline charts and spectrograms of sequences as PNG or
+// SVG images, in pure Go.
+package chart
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"path/filepath"
+	"strings"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// Format is the image format of a chart
+type Format int
+
+const (
+	// FormatPNG is a raster image
+	FormatPNG Format = iota
+	// FormatSVG is a vector image
+	FormatSVG
+)
+
+// String returns the string representation of the Format
+func (f Format) String() string {
+	switch f {
+	case FormatPNG:
+		return "png"
+	case FormatSVG:
+		return "svg"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseFormat parses an image format name (png or svg)
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "png":
+		return FormatPNG, nil
+	case "svg":
+		return FormatSVG, nil
+	default:
+		return FormatPNG, fmt.Errorf("invalid chart format: %s (expected png or svg)", s)
+	}
+}
+
+// FormatFromPath returns the image format of a file from its extension
+func FormatFromPath(path string) (Format, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if ext == "" {
+		return FormatPNG, fmt.Errorf("chart file %s has no extension (expected .png or .svg)", path)
+	}
+	return ParseFormat(ext)
+}
+
+const (
+	// chartWidth is the width of every chart, in pixels
+	chartWidth = 1200
+	// marginLeft holds the value labels, marginRight the last time label
+	marginLeft  = 80
+	marginRight = 28
+	// titleHeight holds the title, bandHeight the transition labels
+	titleHeight = 30
+	bandHeight  = 24
+	// panelGap separates the panels and holds their titles
+	panelGap = 34
+	// axisHeight holds the time labels
+	axisHeight = 30
+	// dashOn and dashOff are the dash pattern of period boundaries, in pixels
+	dashOn  = 6
+	dashOff = 4
+	// minTickGap is the smallest distance between two labeled ticks, in pixels
+	minTickGap = 90
+)
+
+var (
+	colorBackground = color.RGBA{255, 255, 255, 255}
+	colorPlot       = color.RGBA{250, 250, 250, 255}
+	colorShade      = color.RGBA{0, 0, 0, 12}
+	colorGrid       = color.RGBA{225, 225, 225, 255}
+	colorBoundary   = color.RGBA{110, 110, 110, 255}
+	colorText       = color.RGBA{40, 40, 40, 255}
+	colorMuted      = color.RGBA{110, 110, 110, 255}
+
+	// channelColors tells the channels apart
+	channelColors = [...]color.RGBA{
+		{31, 119, 180, 255}, {255, 127, 14, 255}, {44, 160, 44, 255}, {214, 39, 40, 255},
+		{148, 103, 189, 255}, {140, 86, 75, 255}, {227, 119, 194, 255}, {127, 127, 127, 255},
+		{188, 189, 34, 255}, {23, 190, 207, 255}, {0, 63, 92, 255}, {188, 80, 144, 255},
+		{255, 166, 0, 255}, {102, 194, 165, 255}, {88, 80, 141, 255}, {165, 0, 38, 255},
+	}
+)
+
+// plot maps times and values of a panel to pixels
+type plot struct {
+	// Area of the panel, in pixels
+	x, y, w, h float64
+	// Time range, in milliseconds
+	t0, t1 float64
+	// Value range, from the bottom to the top
+	v0, v1 float64
+}
+
+// px returns the horizontal position of a time
+func (p *plot) px(ms float64) float64 {
+	return p.x + (ms-p.t0)/(p.t1-p.t0)*p.w
+}
+
+// py returns the vertical position of a value
+func (p *plot) py(v float64) float64 {
+	return p.y + p.h - (v-p.v0)/(p.v1-p.v0)*p.h
+}
+
+// niceStep returns a step of 1, 2 or 5 times a power of ten that divides a
+// span in at most a number of ticks
+func niceStep(span float64, ticks int) float64 {
+	if span <= 0 || ticks < 1 {
+		return 1
+	}
+	raw := span / float64(ticks)
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if m*magnitude >= raw {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// niceRange returns a range of nice values around the values of a series,
+// padded when they barely change
+func niceRange(lo, hi float64, ticks int) (float64, float64) {
+	if math.IsInf(lo, 1) || math.IsInf(hi, -1) {
+		return 0, 1
+	}
+	if hi-lo < 1e-9 {
+		pad := math.Max(1, math.Abs(hi)*0.05)
+		lo, hi = lo-pad, hi+pad
+	}
+	step := niceStep(hi-lo, ticks)
+	return math.Floor(lo/step) * step, math.Ceil(hi/step) * step
+}
+
+// timeSteps are the steps of the time axis, in milliseconds
+var timeSteps = []int{
+	100, 200, 500, 1000, 2000, 5000, 10000, 15000, 30000,
+	60000, 120000, 300000, 600000, 900000, 1800000, 3600000, 7200000,
+}
+
+// timeStep returns the shortest time step whose ticks are far enough apart
+func timeStep(p *plot) int {
+	for _, step := range timeSteps {
+		if float64(step)/(p.t1-p.t0)*p.w >= minTickGap {
+			return step
+		}
+	}
+	return timeSteps[len(timeSteps)-1]
+}
+
+// formatTime returns a time as M:SS or H:MM:SS, with tenths below a second step
+func formatTime(ms int, step int) string {
+	h, m, s := ms/3600000, ms/60000%60, ms/1000%60
+	text := fmt.Sprintf("%d:%02d", m, s)
+	if h > 0 {
+		text = fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	if step < 1000 {
+		text += fmt.Sprintf(".%d", ms/100%10)
+	}
+	return text
+}
+
+// formatValue returns a value with the decimals of its tick step
+func formatValue(v, step float64) string {
+	decimals := 0
+	if step < 1 {
+		decimals = int(math.Ceil(-math.Log10(step)))
+	}
+	return fmt.Sprintf("%.*f", decimals, v)
+}
+
+// drawValueAxis draws the horizontal grid and the value labels of a panel
+func drawValueAxis(c canvas, p *plot, ticks int) {
+	step := niceStep(p.v1-p.v0, ticks)
+	for v := math.Ceil(p.v0/step) * step; v <= p.v1+step*1e-9; v += step {
+		y := p.py(v)
+		c.line(p.x, y, p.x+p.w, y, colorGrid, 1, false)
+		c.text(p.x-8, y, formatValue(v, step), colorMuted, anchorEnd)
+	}
+}
+
+// drawTimeAxis draws the time labels below a panel
+func drawTimeAxis(c canvas, p *plot) {
+	step := timeStep(p)
+	for ms := int(math.Ceil(p.t0/float64(step))) * step; float64(ms) <= p.t1; ms += step {
+		x := p.px(float64(ms))
+		c.line(x, p.y+p.h, x, p.y+p.h+5, colorMuted, 1, false)
+		c.text(x, p.y+p.h+axisHeight/2+4, formatTime(ms, step), colorMuted, anchorMiddle)
+	}
+}
+
+// transitionLabel returns the transition of a period, with its glide when
+// the frequencies do not slide linearly
+func transitionLabel(period *t.Period) string {
+	label := period.Transition.String()
+	if period.Glide != t.GlideLinear {
+		label += " " + period.Glide.String()
+	}
+	return label
+}
+
+// drawPeriods shades every other period of the panels, draws the period
+// boundaries across them and labels the transition of each period in the
+// band above the title of the first panel
+func drawPeriods(c canvas, periods []t.Period, panels []*plot, boundary color.RGBA) {
+	if len(panels) == 0 {
+		return
+	}
+	first := panels[0]
+	bandTop := first.y - panelGap - bandHeight
+
+	for i := 0; i+1 < len(periods); i++ {
+		start := math.Max(float64(periods[i].Time), first.t0)
+		end := math.Min(float64(periods[i+1].Time), first.t1)
+		if end <= start {
+			continue
+		}
+		x0, x1 := first.px(start), first.px(end)
+
+		if i%2 == 1 {
+			c.rect(x0, bandTop, x1-x0, bandHeight, colorShade)
+			for _, p := range panels {
+				c.rect(x0, p.y, x1-x0, p.h, colorShade)
+			}
+		}
+
+		// Labels that do not fit their period are left out
+		if label := transitionLabel(&periods[i]); textWidth(label)+8 <= x1-x0 {
+			c.text((x0+x1)/2, bandTop+bandHeight/2, label, colorMuted, anchorMiddle)
+		}
+	}
+
+	for i := 1; i+1 < len(periods); i++ {
+		ms := float64(periods[i].Time)
+		if ms <= first.t0 || ms >= first.t1 {
+			continue
+		}
+		x := first.px(ms)
+		c.line(x, bandTop, x, bandTop+bandHeight, boundary, 1, true)
+		for _, p := range panels {
+			c.line(x, p.y, x, p.y+p.h, boundary, 1, true)
+		}
+	}
+}
+
+// drawPanelTitle draws the title of a panel above its top left corner
+func drawPanelTitle(c canvas, p *plot, title string) {
+	c.text(p.x, p.y-12, title, colorText, anchorStart)
+}
@@ -0,0 +1,82 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package chart
+
+import (
+	"testing"
+)
+
+func TestNiceRange(ts *testing.T) {
+	tests := []struct {
+		lo, hi float64
+		wantLo float64
+		wantHi float64
+	}{
+		{0, 37, 0, 40},
+		{195, 305, 150, 350},
+		{0.2, 0.9, 0.2, 1},
+		// Constant series are padded
+		{200, 200, 190, 210},
+		{0, 0, -1, 1},
+	}
+
+	for _, test := range tests {
+		lo, hi := niceRange(test.lo, test.hi, 5)
+		if lo != test.wantLo || hi != test.wantHi {
+			ts.Errorf("niceRange(%v, %v): expected %v-%v, got %v-%v", test.lo, test.hi, test.wantLo, test.wantHi, lo, hi)
+		}
+	}
+}
+
+func TestFormatTime(ts *testing.T) {
+	tests := []struct {
+		ms, step int
+		want     string
+	}{
+		{0, 1000, "0:00"},
+		{90000, 30000, "1:30"},
+		{3725000, 600000, "1:02:05"},
+		{1500, 500, "0:01.5"},
+	}
+
+	for _, test := range tests {
+		if got := formatTime(test.ms, test.step); got != test.want {
+			ts.Errorf("formatTime(%d, %d): expected %q, got %q", test.ms, test.step, test.want, got)
+		}
+	}
+}
+
+func TestFormatFromPath(ts *testing.T) {
+	tests := []struct {
+		path        string
+		want        Format
+		expectError bool
+	}{
+		{"chart.png", FormatPNG, false},
+		{"out/Chart.SVG", FormatSVG, false},
+		{"chart.wav", FormatPNG, true},
+		{"chart", FormatPNG, true},
+	}
+
+	for _, test := range tests {
+		got, err := FormatFromPath(test.path)
+		if test.expectError {
+			if err == nil {
+				ts.Errorf("FormatFromPath(%q): expected error, got %v", test.path, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			ts.Errorf("FormatFromPath(%q): expected %v, got %v (%v)", test.path, test.want, got, err)
+		}
+	}
+}
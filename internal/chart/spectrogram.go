@@ -0,0 +1,195 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package chart
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+
+	"github.com/synapseq-foundation/synapseq/v3/internal/audio"
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+const (
+	// spectrogramPanelHeight is the height of the panel of an ear, in pixels
+	spectrogramPanelHeight = 240
+	// spectrogramMarginRight holds the color scale
+	spectrogramMarginRight = 100
+	// spectrogramTicks is the most frequency ticks of a panel
+	spectrogramTicks = 6
+	// Level range of the color scale, in dBFS
+	spectrogramMinLevel = -100.0
+	spectrogramMaxLevel = 0.0
+	// colorScaleWidth is the width of the color scale, in pixels
+	colorScaleWidth = 14
+)
+
+// SpectrogramColumns is the number of spectrogram columns drawn by
+// WriteSpectrogram, one per pixel of the panels
+const SpectrogramColumns = chartWidth - marginLeft - spectrogramMarginRight
+
+var (
+	// colorSpectrogramBoundary shows the period boundaries over the levels
+	colorSpectrogramBoundary = color.RGBA{255, 255, 255, 170}
+
+	// levelColors are the colors of the levels from the lowest, in even steps
+	levelColors = []color.RGBA{
+		{0, 0, 4, 255}, {40, 11, 84, 255}, {101, 21, 110, 255}, {159, 42, 99, 255},
+		{212, 72, 66, 255}, {245, 125, 21, 255}, {250, 193, 39, 255}, {252, 255, 164, 255},
+	}
+)
+
+// levelColor returns the color of a level in dBFS
+func levelColor(level float64) color.RGBA {
+	f := (level - spectrogramMinLevel) / (spectrogramMaxLevel - spectrogramMinLevel)
+	f = math.Max(0, math.Min(1, f)) * float64(len(levelColors)-1)
+
+	i := min(int(f), len(levelColors)-2)
+	frac := f - float64(i)
+	a, b := levelColors[i], levelColors[i+1]
+	mix := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*frac))
+	}
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 255}
+}
+
+// FrequencyRange returns the top frequency of a spectrogram that shows every
+// tone of the timeline points with some room above, rounded up to 100 Hz and
+// at most the Nyquist frequency of the sample rate. Sequences without tones
+// show up to 1000 Hz.
+func FrequencyRange(points []audio.TimelinePoint, sampleRate int) float64 {
+	var top float64
+	for i := range points {
+		for ch := range points[i].Tracks {
+			top = math.Max(top, trackFrequency(&points[i].Tracks[ch]))
+		}
+	}
+
+	nyquist := float64(sampleRate) / 2
+	if top == 0 {
+		return math.Min(1000, nyquist)
+	}
+	return math.Min(math.Max(100, math.Ceil(top*1.25/100)*100), nyquist)
+}
+
+// trackFrequency returns the highest frequency of a tone, or 0 for the
+// tracks without a pitch
+func trackFrequency(tr *t.Track) float64 {
+	if tr.Type == t.TrackOff || tr.Type == t.TrackSilence {
+		return 0
+	}
+
+	carrier := tr.Carrier
+	for i := range min(tr.Partials.Count, t.MaxPartials) {
+		carrier = math.Max(carrier, tr.Carrier*tr.Partials.Ratios[i])
+	}
+
+	switch {
+	case tr.Type == t.TrackFMTone:
+		return carrier + tr.Depth + math.Abs(tr.Resonance)
+	case tr.IsTone() || tr.IsModulated():
+		return carrier + math.Abs(tr.Resonance)
+	case tr.Type == t.TrackBell:
+		return carrier
+	}
+	return 0
+}
+
+// WriteSpectrogram draws the spectrogram of each ear of a render up to its
+// top bin, with the period boundaries and transitions of the sequence and a
+// color scale of the levels
+func WriteSpectrogram(w io.Writer, format Format, periods []t.Period, sp *audio.Spectrogram) error {
+	if len(sp.Levels[0]) == 0 || sp.Bins() == 0 {
+		return fmt.Errorf("the spectrogram has no columns to chart")
+	}
+
+	height := titleHeight + bandHeight + 2*(panelGap+spectrogramPanelHeight) + axisHeight + 10
+	c := newCanvas(format, chartWidth, height)
+	c.rect(0, 0, chartWidth, float64(height), colorBackground)
+
+	maxFreq := float64(sp.Bins()-1) * sp.BinWidth
+	plotWidth := float64(SpectrogramColumns)
+	c.text(marginLeft, titleHeight/2+2, "Spectrogram", colorText, anchorStart)
+	c.text(marginLeft+plotWidth, titleHeight/2+2,
+		fmt.Sprintf("%s-%s", formatTime(sp.Start, 1000), formatTime(sp.End, 1000)), colorMuted, anchorEnd)
+
+	panels := make([]*plot, 2)
+	y := float64(titleHeight + bandHeight)
+	for e, title := range []string{"Left ear", "Right ear"} {
+		y += panelGap
+		p := &plot{
+			x: marginLeft, y: y, w: plotWidth, h: spectrogramPanelHeight,
+			t0: float64(sp.Start), t1: float64(sp.End), v0: 0, v1: maxFreq,
+		}
+		panels[e] = p
+		y += spectrogramPanelHeight
+
+		drawPanelTitle(c, p, title)
+		drawValueAxis(c, p, spectrogramTicks)
+		c.image(p.x, p.y, spectrogramImage(sp.Levels[e], sp.BinWidth, p))
+	}
+	drawPeriods(c, periods, panels, colorSpectrogramBoundary)
+	drawTimeAxis(c, panels[len(panels)-1])
+	drawColorScale(c, panels[0].y, panels[len(panels)-1].y+spectrogramPanelHeight)
+
+	return c.encode(w)
+}
+
+// spectrogramImage draws the levels of an ear on the pixels of a panel, the
+// frequencies between two bins are interpolated
+func spectrogramImage(levels [][]float64, binWidth float64, p *plot) *image.RGBA {
+	width, height := int(p.w), int(p.h)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for x := range width {
+		column := levels[min(x*len(levels)/width, len(levels)-1)]
+		for y := range height {
+			level := column[0]
+			if len(column) > 1 {
+				bin := (p.v0 + (float64(height-y)-0.5)/float64(height)*(p.v1-p.v0)) / binWidth
+				i := min(int(bin), len(column)-2)
+				level = column[i] + (column[i+1]-column[i])*(bin-float64(i))
+			}
+			img.SetRGBA(x, y, levelColor(level))
+		}
+	}
+
+	return img
+}
+
+// drawColorScale draws the colors of the levels right of the panels, from
+// the top to the bottom of a span
+func drawColorScale(c canvas, top, bottom float64) {
+	x := float64(chartWidth - spectrogramMarginRight + 16)
+	height := int(bottom - top)
+
+	img := image.NewRGBA(image.Rect(0, 0, colorScaleWidth, height))
+	for y := range height {
+		level := spectrogramMaxLevel - (float64(y)+0.5)/float64(height)*(spectrogramMaxLevel-spectrogramMinLevel)
+		for dx := range colorScaleWidth {
+			img.SetRGBA(dx, y, levelColor(level))
+		}
+	}
+	c.image(x, top, img)
+	c.text(x, top-18, "dBFS", colorText, anchorStart)
+
+	scale := &plot{y: top, h: bottom - top, v0: spectrogramMinLevel, v1: spectrogramMaxLevel}
+	step := niceStep(spectrogramMaxLevel-spectrogramMinLevel, 5)
+	for v := spectrogramMinLevel; v <= spectrogramMaxLevel; v += step {
+		ly := scale.py(v)
+		c.line(x+colorScaleWidth, ly, x+colorScaleWidth+4, ly, colorMuted, 1, false)
+		c.text(x+colorScaleWidth+8, ly, formatValue(v, step), colorMuted, anchorStart)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/synapseq-foundation/synapseq/v3/internal/audio"
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+func TestFrequencyRange(ts *testing.T) {
+	periods := testPeriods()
+	if got := FrequencyRange(audio.Timeline(periods, 44100, 16), 44100); got != 400 {
+		ts.Errorf("expected 400 Hz above the 300 Hz carrier, got %.0f", got)
+	}
+
+	// Partials raise the range, the Nyquist frequency caps it
+	var p0, p1 t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackPureTone, Carrier: 2000, Partials: t.HarmonicPartials(4), Amplitude: t.AmplitudePercentToRaw(10)}
+	p0.TrackEnd = p0.TrackStart
+	p1.Time = 1000
+	points := audio.Timeline([]t.Period{p0, p1}, 8000, 4)
+	if got := FrequencyRange(points, 44100); got != 10000 {
+		ts.Errorf("expected 10000 Hz above the 4th harmonic, got %.0f", got)
+	}
+	if got := FrequencyRange(points, 8000); got != 4000 {
+		ts.Errorf("expected the Nyquist frequency, got %.0f", got)
+	}
+
+	if got := FrequencyRange(nil, 44100); got != 1000 {
+		ts.Errorf("expected 1000 Hz without tones, got %.0f", got)
+	}
+}
+
+func TestLevelColor(ts *testing.T) {
+	if got := levelColor(spectrogramMinLevel - 20); got != levelColors[0] {
+		ts.Errorf("expected the lowest color below the scale, got %v", got)
+	}
+	if got := levelColor(spectrogramMaxLevel); got != levelColors[len(levelColors)-1] {
+		ts.Errorf("expected the highest color at the top of the scale, got %v", got)
+	}
+}
+
+func TestWriteSpectrogram(ts *testing.T) {
+	periods := testPeriods()
+	periods[2].Time = 4000
+	periods[1].Time = 2000
+
+	r, err := audio.NewAudioRenderer(periods, &audio.AudioRendererOptions{SampleRate: 44100, Volume: 100})
+	if err != nil {
+		ts.Fatalf("NewAudioRenderer failed: %v", err)
+	}
+	sp, err := r.Spectrogram(SpectrogramColumns, 1000)
+	if err != nil {
+		ts.Fatalf("Spectrogram failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSpectrogram(&buf, FormatPNG, periods, sp); err != nil {
+		ts.Fatalf("WriteSpectrogram failed: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		ts.Fatalf("expected a valid PNG: %v", err)
+	}
+	if w := img.Bounds().Dx(); w != chartWidth {
+		ts.Errorf("expected a width of %d, got %d", chartWidth, w)
+	}
+
+	buf.Reset()
+	if err := WriteSpectrogram(&buf, FormatSVG, periods, sp); err != nil {
+		ts.Fatalf("WriteSpectrogram failed: %v", err)
+	}
+	svg := buf.String()
+	for _, want := range []string{"Left ear", "Right ear", "dBFS", "data:image/png;base64,"} {
+		if !strings.Contains(svg, want) {
+			ts.Errorf("expected the chart to contain %q", want)
+		}
+	}
+}
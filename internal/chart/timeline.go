@@ -0,0 +1,192 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package chart
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/synapseq-foundation/synapseq/v3/internal/audio"
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+const (
+	// timelinePanelHeight is the height of a timeline panel, in pixels
+	timelinePanelHeight = 170
+	// timelineTicks is the most value ticks of a timeline panel
+	timelineTicks = 5
+	// legendWidth and legendHeight are the size of a legend entry, in pixels
+	legendWidth  = 210
+	legendHeight = 22
+)
+
+// timelinePanel is a value of the tracks charted over time
+type timelinePanel struct {
+	title string
+	// fromZero keeps zero in the value range
+	fromZero bool
+	// value returns the value of a track, false when the track has none
+	value func(tr *t.Track) (float64, bool)
+}
+
+// timelinePanels are the panels of a timeline chart, from the top
+var timelinePanels = []timelinePanel{
+	{"Carrier (Hz)", false, carrierValue},
+	{"Beat (Hz)", true, beatValue},
+	{"Amplitude (%)", true, amplitudeValue},
+}
+
+// carrierValue returns the carrier of a tone
+func carrierValue(tr *t.Track) (float64, bool) {
+	return tr.Carrier, tr.IsTone() || tr.IsModulated()
+}
+
+// beatValue returns the beat of a tone, the modulation rate of a modulated
+// tone or the rate of the effect of a noise or background
+func beatValue(tr *t.Track) (float64, bool) {
+	switch {
+	case tr.Type == t.TrackPureTone:
+		return 0, false
+	case tr.IsTone() || tr.IsModulated():
+		return tr.Resonance, true
+	case tr.SupportsEffect() && tr.Effect.Type != t.EffectOff:
+		return tr.Resonance, true
+	}
+	return 0, false
+}
+
+// amplitudeValue returns the amplitude of a playing track
+func amplitudeValue(tr *t.Track) (float64, bool) {
+	return tr.Amplitude.ToPercent(), tr.Type != t.TrackOff && tr.Type != t.TrackSilence
+}
+
+// WriteTimeline draws the carrier, beat and amplitude of every channel of a
+// sequence over time from its timeline points. The periods are shaded in
+// turn, their boundaries are dashed and their transitions are labeled.
+func WriteTimeline(w io.Writer, format Format, periods []t.Period, points []audio.TimelinePoint) error {
+	if len(periods) < 2 || len(points) == 0 {
+		return fmt.Errorf("the sequence has no periods to chart")
+	}
+
+	channels := timelineChannels(points)
+	plotWidth := float64(chartWidth - marginLeft - marginRight)
+	legendColumns := int(plotWidth) / legendWidth
+	legendRows := (len(channels) + legendColumns - 1) / legendColumns
+
+	height := titleHeight + bandHeight + len(timelinePanels)*(panelGap+timelinePanelHeight) +
+		axisHeight + legendRows*legendHeight + 20
+	c := newCanvas(format, chartWidth, height)
+	c.rect(0, 0, chartWidth, float64(height), colorBackground)
+
+	end := periods[len(periods)-1].Time
+	c.text(marginLeft, titleHeight/2+2, "Timeline", colorText, anchorStart)
+	c.text(chartWidth-marginRight, titleHeight/2+2, fmt.Sprintf("%d periods, %s", len(periods)-1, formatTime(end, 1000)), colorMuted, anchorEnd)
+
+	panels := make([]*plot, len(timelinePanels))
+	y := float64(titleHeight + bandHeight)
+	for i, panel := range timelinePanels {
+		y += panelGap
+		p := &plot{x: marginLeft, y: y, w: plotWidth, h: timelinePanelHeight, t1: float64(end)}
+		p.v0, p.v1 = panelRange(panel, points, channels)
+		panels[i] = p
+		y += timelinePanelHeight
+
+		c.rect(p.x, p.y, p.w, p.h, colorPlot)
+		drawPanelTitle(c, p, panel.title)
+		drawValueAxis(c, p, timelineTicks)
+	}
+	drawPeriods(c, periods, panels, colorBoundary)
+
+	for i, panel := range timelinePanels {
+		for _, ch := range channels {
+			drawSeries(c, panels[i], panel, points, ch)
+		}
+	}
+	drawTimeAxis(c, panels[len(panels)-1])
+
+	// Legend of the channels and their track types
+	legendTop := y + axisHeight + 10
+	for i, ch := range channels {
+		x := marginLeft + float64(i%legendColumns*legendWidth)
+		ly := legendTop + float64(i/legendColumns*legendHeight) + legendHeight/2
+		c.rect(x, ly-3, 18, 6, channelColors[ch%len(channelColors)])
+		c.text(x+26, ly, fmt.Sprintf("ch %d %s", ch+1, channelTypes(points, ch)), colorText, anchorStart)
+	}
+
+	return c.encode(w)
+}
+
+// timelineChannels returns the channels that play a track at some point
+func timelineChannels(points []audio.TimelinePoint) []int {
+	var channels []int
+	for ch := range t.NumberOfChannels {
+		for i := range points {
+			if _, ok := amplitudeValue(&points[i].Tracks[ch]); ok {
+				channels = append(channels, ch)
+				break
+			}
+		}
+	}
+	return channels
+}
+
+// channelTypes returns the track types of a channel in order of appearance
+func channelTypes(points []audio.TimelinePoint, ch int) string {
+	var types []string
+	for i := range points {
+		tr := &points[i].Tracks[ch]
+		if _, ok := amplitudeValue(tr); !ok {
+			continue
+		}
+		name := tr.Type.String()
+		if len(types) == 0 || types[len(types)-1] != name {
+			types = append(types, name)
+		}
+	}
+	return strings.Join(types, "/")
+}
+
+// panelRange returns the value range of a panel over the channels
+func panelRange(panel timelinePanel, points []audio.TimelinePoint, channels []int) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i := range points {
+		for _, ch := range channels {
+			if v, ok := panel.value(&points[i].Tracks[ch]); ok {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+
+	if panel.fromZero && !math.IsInf(lo, 1) {
+		lo = math.Min(lo, 0)
+	}
+	return niceRange(lo, hi, timelineTicks)
+}
+
+// drawSeries draws the values of a channel, broken where it has none
+func drawSeries(c canvas, p *plot, panel timelinePanel, points []audio.TimelinePoint, ch int) {
+	stroke := channelColors[ch%len(channelColors)]
+
+	var pts []point
+	for i := range points {
+		v, ok := panel.value(&points[i].Tracks[ch])
+		if !ok {
+			c.polyline(pts, stroke, 2)
+			pts = pts[:0]
+			continue
+		}
+		pts = append(pts, point{p.px(float64(points[i].Time)), p.py(v)})
+	}
+	c.polyline(pts, stroke, 2)
+}
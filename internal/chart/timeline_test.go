@@ -0,0 +1,78 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/synapseq-foundation/synapseq/v3/internal/audio"
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// testPeriods returns a binaural tone sliding in the first period and a noise
+// joining in the second one
+func testPeriods() []t.Period {
+	var p0, p1, p2 t.Period
+	p0.TrackStart[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 200, Resonance: 10, Amplitude: t.AmplitudePercentToRaw(40)}
+	p0.TrackEnd[0] = t.Track{Type: t.TrackBinauralBeat, Carrier: 300, Resonance: 6, Amplitude: t.AmplitudePercentToRaw(20)}
+	p0.Transition = t.TransitionEaseOut
+	p1.Time = 60000
+	p1.TrackStart[0] = p0.TrackEnd[0]
+	p1.TrackEnd[0] = p0.TrackEnd[0]
+	p1.TrackStart[1] = t.Track{Type: t.TrackPinkNoise, Amplitude: t.AmplitudePercentToRaw(30)}
+	p1.TrackEnd[1] = p1.TrackStart[1]
+	p2.Time = 90000
+	return []t.Period{p0, p1, p2}
+}
+
+func TestWriteTimeline_SVG(ts *testing.T) {
+	periods := testPeriods()
+	var buf bytes.Buffer
+	if err := WriteTimeline(&buf, FormatSVG, periods, audio.Timeline(periods, 44100, 16)); err != nil {
+		ts.Fatalf("WriteTimeline failed: %v", err)
+	}
+
+	svg := buf.String()
+	for _, want := range []string{"<svg", "Carrier (Hz)", "Beat (Hz)", "Amplitude (%)", "ease-out", "ch 1 binaural", "ch 2 pink", "stroke-dasharray"} {
+		if !strings.Contains(svg, want) {
+			ts.Errorf("expected the chart to contain %q", want)
+		}
+	}
+
+	// One boundary between the periods, in the band and the three panels
+	if got := strings.Count(svg, "stroke-dasharray"); got != 4 {
+		ts.Errorf("expected 4 dashed boundary lines, got %d", got)
+	}
+}
+
+func TestWriteTimeline_PNG(ts *testing.T) {
+	periods := testPeriods()
+	var buf bytes.Buffer
+	if err := WriteTimeline(&buf, FormatPNG, periods, audio.Timeline(periods, 44100, 16)); err != nil {
+		ts.Fatalf("WriteTimeline failed: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		ts.Fatalf("expected a valid PNG: %v", err)
+	}
+	if w := img.Bounds().Dx(); w != chartWidth {
+		ts.Errorf("expected a width of %d, got %d", chartWidth, w)
+	}
+
+	if err := WriteTimeline(&buf, FormatPNG, periods[:1], nil); err == nil {
+		ts.Errorf("expected an error without periods to chart")
+	}
+}
@@ -14,8 +14,12 @@ package cli
 import (
 	"flag"
 	"fmt"
+	"math"
 	"os"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/synapseq-foundation/synapseq/v3/internal/info"
 )
@@ -42,6 +46,18 @@ type CLIOptions struct {
 	UnsafeNoMetadata bool
 	// Convert to text from json/xml/yaml
 	ConvertToText bool
+	// Verify a WAV file against its sequence
+	Verify bool
+	// Chart of the sequence to draw (timeline or spectrogram)
+	Chart string
+	// Normalize the output to a target loudness
+	Normalize bool
+	// Target integrated loudness in LUFS
+	Loudness float64
+	// Time range of the output (an end of 0 is the end of the sequence)
+	Start, End time.Duration
+	// Fade at the edges of the time range
+	EdgeFade time.Duration
 	// Hub update index of available sequences
 	HubUpdate bool
 	// Hub clean up local cache
@@ -88,7 +104,7 @@ func Help() {
 
 	fmt.Printf("OUTPUT formats:\n")
 	fmt.Printf("    WAV file:            path/to/output.wav\n")
-	fmt.Printf("    Standard output:     - (raw PCM, 16-bit stereo)\n\n")
+	fmt.Printf("    Standard output:     - (raw PCM, 16-bit stereo or the @layout channels)\n\n")
 
 	fmt.Printf("Main options:\n")
 	fmt.Printf("  -json          		Read input as JSON format\n")
@@ -98,7 +114,14 @@ func Help() {
 	fmt.Printf("  -test          		Validate syntax without generating output\n")
 	fmt.Printf("  -extract       		Extract text sequence from WAV file\n")
 	fmt.Printf("  -convert       		Convert to text from json/xml/yaml\n")
+	fmt.Printf("  -verify        		Verify a WAV file against its sequence: [input] <file.wav>\n")
+	fmt.Printf("                 		(a -start/-end render is verified with the same -start, -end and -fade)\n")
+	fmt.Printf("  -chart         		Draw a PNG or SVG chart: timeline or spectrogram\n")
 	fmt.Printf("  -unsafe-no-metadata  	  	Do not embed metadata in output WAV file\n")
+	fmt.Printf("  -loudness      		Normalize to a target loudness in LUFS (e.g. -23)\n")
+	fmt.Printf("  -start         		Start the output at a time (HH:MM:SS, MM:SS or seconds)\n")
+	fmt.Printf("  -end           		End the output at a time (HH:MM:SS, MM:SS or seconds)\n")
+	fmt.Printf("  -fade          		Fade in and out the -start/-end edges over seconds (e.g. 0.5)\n")
 	fmt.Printf("  -version       		Show version information\n")
 	fmt.Printf("  -help         		Show this help message\n\n")
 
@@ -169,6 +192,12 @@ func ParseFlags() (*CLIOptions, []string, error) {
 	fs.BoolVar(&opts.ExtractTextSequence, "extract", false, "Extract text sequence from WAV file")
 	fs.BoolVar(&opts.UnsafeNoMetadata, "unsafe-no-metadata", false, "Do not embed metadata in output WAV file")
 	fs.BoolVar(&opts.ConvertToText, "convert", false, "Convert to text from json/xml/yaml")
+	fs.BoolVar(&opts.Verify, "verify", false, "Verify a WAV file against its sequence")
+	fs.Func("chart", "Draw a chart of the sequence", chartFlag(&opts.Chart))
+	fs.Float64Var(&opts.Loudness, "loudness", 0, "Normalize to a target loudness in LUFS")
+	fs.Func("start", "Start the output at a time", timeFlag(&opts.Start))
+	fs.Func("end", "End the output at a time", timeFlag(&opts.End))
+	fs.Func("fade", "Fade the edges of the time range", timeFlag(&opts.EdgeFade))
 	fs.BoolVar(&opts.ShowHelp, "help", false, "Show help")
 
 	// External tool options
@@ -183,5 +212,65 @@ func ParseFlags() (*CLIOptions, []string, error) {
 	fs.BoolVar(&opts.UninstallFileAssociation, "uninstall-file-association", false, "Remove .spsq file association (Windows only)")
 
 	err := fs.Parse(os.Args[1:])
+
+	// 0 LUFS is a valid target, so normalization follows the presence of the flag
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "loudness" {
+			opts.Normalize = true
+		}
+	})
+
 	return opts, fs.Args(), err
 }
+
+// timeFlag returns the parser of a time flag storing into d
+func timeFlag(d *time.Duration) func(string) error {
+	return func(s string) error {
+		v, err := ParseTime(s)
+		if err != nil {
+			return err
+		}
+		*d = v
+		return nil
+	}
+}
+
+// chartFlag returns the parser of a chart flag storing into kind
+func chartFlag(kind *string) func(string) error {
+	return func(s string) error {
+		switch s {
+		case "timeline", "spectrogram":
+			*kind = s
+			return nil
+		default:
+			return fmt.Errorf("invalid chart (expected timeline or spectrogram): %s", s)
+		}
+	}
+}
+
+// ParseTime parses a time in HH:MM:SS, MM:SS or seconds, where the seconds
+// may have a fraction (e.g. 01:30:00, 90:00, 5400 or 2.5)
+func ParseTime(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time (expected HH:MM:SS, MM:SS or seconds): %s", s)
+	}
+
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || seconds < 0 || math.IsNaN(seconds) || math.IsInf(seconds, 0) || (len(parts) > 1 && seconds >= 60) {
+		return 0, fmt.Errorf("invalid seconds in time: %s", s)
+	}
+
+	total := seconds
+	scale := 60.0
+	for i := len(parts) - 2; i >= 0; i-- {
+		v, err := strconv.Atoi(parts[i])
+		if err != nil || v < 0 || (i > 0 && v >= 60) {
+			return 0, fmt.Errorf("invalid time field %q in time: %s", parts[i], s)
+		}
+		total += float64(v) * scale
+		scale *= 60
+	}
+
+	return time.Duration(math.Round(total * float64(time.Second))), nil
+}
@@ -14,6 +14,7 @@ package cli
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestParseFlags(ts *testing.T) {
@@ -117,6 +118,55 @@ func TestParseFlags(ts *testing.T) {
 			expectedArgs: []string{"input.xml", "output.wav"},
 			expectError:  false,
 		},
+		// Loudness target
+		{
+			args:         []string{"cmd", "-loudness", "-23", "input.spsq"},
+			expected:     &CLIOptions{Normalize: true, Loudness: -23},
+			expectedArgs: []string{"input.spsq"},
+			expectError:  false,
+		},
+		// Loudness target of 0 LUFS
+		{
+			args:         []string{"cmd", "-loudness", "0", "input.spsq"},
+			expected:     &CLIOptions{Normalize: true},
+			expectedArgs: []string{"input.spsq"},
+			expectError:  false,
+		},
+		// Invalid loudness target
+		{
+			args:         []string{"cmd", "-loudness", "loud", "input.spsq"},
+			expected:     nil,
+			expectedArgs: nil,
+			expectError:  true,
+		},
+		// Time range with an edge fade
+		{
+			args:         []string{"cmd", "-start", "01:30", "-end", "00:02:15.5", "-fade", "0.5", "input.spsq"},
+			expected:     &CLIOptions{Start: 90 * time.Second, End: 135500 * time.Millisecond, EdgeFade: 500 * time.Millisecond},
+			expectedArgs: []string{"input.spsq"},
+			expectError:  false,
+		},
+		// Chart of the sequence
+		{
+			args:         []string{"cmd", "-chart", "spectrogram", "input.spsq", "chart.svg"},
+			expected:     &CLIOptions{Chart: "spectrogram"},
+			expectedArgs: []string{"input.spsq", "chart.svg"},
+			expectError:  false,
+		},
+		// Invalid chart
+		{
+			args:         []string{"cmd", "-chart", "waveform", "input.spsq"},
+			expected:     nil,
+			expectedArgs: nil,
+			expectError:  true,
+		},
+		// Invalid time
+		{
+			args:         []string{"cmd", "-start", "01:75", "input.spsq"},
+			expected:     nil,
+			expectedArgs: nil,
+			expectError:  true,
+		},
 		// All boolean flags enabled
 		{
 			args:         []string{"cmd", "-quiet", "-test", "-json", "input.json"},
@@ -177,6 +227,17 @@ func TestParseFlags(ts *testing.T) {
 		if opts.FormatYAML != test.expected.FormatYAML {
 			ts.Errorf("For args %v, FormatYAML: expected %v but got %v", test.args, test.expected.FormatYAML, opts.FormatYAML)
 		}
+		if opts.Normalize != test.expected.Normalize || opts.Loudness != test.expected.Loudness {
+			ts.Errorf("For args %v, Loudness: expected %v %.2f but got %v %.2f", test.args,
+				test.expected.Normalize, test.expected.Loudness, opts.Normalize, opts.Loudness)
+		}
+		if opts.Chart != test.expected.Chart {
+			ts.Errorf("For args %v, Chart: expected %q but got %q", test.args, test.expected.Chart, opts.Chart)
+		}
+		if opts.Start != test.expected.Start || opts.End != test.expected.End || opts.EdgeFade != test.expected.EdgeFade {
+			ts.Errorf("For args %v, range: expected %v-%v fade %v but got %v-%v fade %v", test.args,
+				test.expected.Start, test.expected.End, test.expected.EdgeFade, opts.Start, opts.End, opts.EdgeFade)
+		}
 
 		if len(args) != len(test.expectedArgs) {
 			ts.Errorf("For args %v, expected args %v but got %v", test.args, test.expectedArgs, args)
@@ -234,3 +295,28 @@ func TestParseFlagsEdgeCases(ts *testing.T) {
 		ts.Errorf("expected URL args, got %v", args)
 	}
 }
+
+func TestParseTime(ts *testing.T) {
+	valid := map[string]time.Duration{
+		"0":          0,
+		"2.5":        2500 * time.Millisecond,
+		"5400":       90 * time.Minute,
+		"90:00":      90 * time.Minute,
+		"01:30:00":   90 * time.Minute,
+		"00:00:59.9": 59900 * time.Millisecond,
+	}
+	for s, want := range valid {
+		got, err := ParseTime(s)
+		if err != nil {
+			ts.Errorf("ParseTime(%q) unexpected error: %v", s, err)
+		} else if got != want {
+			ts.Errorf("ParseTime(%q) = %v, expected %v", s, got, want)
+		}
+	}
+
+	for _, s := range []string{"", "-1", "abc", "00:60", "01:60:00", "1:2:3:4", "NaN", "00:-1:00"} {
+		if _, err := ParseTime(s); err == nil {
+			ts.Errorf("ParseTime(%q) expected error", s)
+		}
+	}
+}
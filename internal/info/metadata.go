@@ -13,6 +13,7 @@ package info
 
 import (
 	"encoding/base64"
+	"fmt"
 	"runtime"
 	"time"
 
@@ -31,6 +32,8 @@ type Metadata struct {
 	platform string
 	// Content is the actual embedded content (e.g., sequence data)
 	content string
+	// Loudness describes the loudness normalization, empty when not normalized
+	loudness string
 }
 
 // NewMetadata creates a new Metadata instance with current information
@@ -68,3 +71,13 @@ func (m *Metadata) Platform() string {
 func (m *Metadata) Content() string {
 	return m.content
 }
+
+// Loudness returns the loudness normalization record
+func (m *Metadata) Loudness() string {
+	return m.loudness
+}
+
+// SetLoudness records the measured loudness and the gain applied to the output
+func (m *Metadata) SetLoudness(measured, target, gain float64) {
+	m.loudness = fmt.Sprintf("target %.2f LUFS, measured %.2f LUFS, gain %+.2f dB", target, measured, gain)
+}
@@ -0,0 +1,153 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	s "github.com/synapseq-foundation/synapseq/v3/internal/shared"
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// isBackgroundClause checks if a token starts a background option clause
+func isBackgroundClause(tok string) bool {
+	switch tok {
+	case t.KeywordAs, t.KeywordLoop, t.KeywordCrossfade, t.KeywordOffset:
+		return true
+	}
+	return false
+}
+
+// parseBackgroundSeconds parses a number of seconds of a background clause
+func parseBackgroundSeconds(clause, tok string) (float64, error) {
+	val, err := strconv.ParseFloat(tok, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid number of seconds: %q", clause, tok)
+	}
+	return val, nil
+}
+
+// parseBackgroundClauses parses the "as", "loop", "crossfade" and "offset"
+// clauses that may follow a background path, in any order
+func parseBackgroundClauses(tokens []string) (string, t.BackgroundLoop, error) {
+	var (
+		name string
+		loop t.BackgroundLoop
+	)
+
+	seen := make(map[string]bool)
+	for i := 0; i < len(tokens); {
+		clause := tokens[i]
+		if !isBackgroundClause(clause) {
+			return "", loop, fmt.Errorf("unexpected token after background path: %q", clause)
+		}
+		if seen[clause] {
+			return "", loop, fmt.Errorf("duplicate %q in background option", clause)
+		}
+		seen[clause] = true
+
+		args := 1
+		if clause == t.KeywordLoop {
+			args = 2
+		}
+		if i+args >= len(tokens) {
+			if clause == t.KeywordAs {
+				return "", loop, fmt.Errorf("expected background name after %q", t.KeywordAs)
+			}
+			return "", loop, fmt.Errorf("expected %d value(s) after %q", args, clause)
+		}
+
+		var err error
+		switch clause {
+		case t.KeywordAs:
+			name = tokens[i+1]
+			err = t.ValidateBackgroundName(name)
+		case t.KeywordLoop:
+			if loop.Start, err = parseBackgroundSeconds(clause, tokens[i+1]); err == nil {
+				loop.End, err = parseBackgroundSeconds(clause, tokens[i+2])
+			}
+		case t.KeywordCrossfade:
+			loop.Crossfade, err = parseBackgroundSeconds(clause, tokens[i+1])
+		case t.KeywordOffset:
+			loop.Offset, err = parseBackgroundSeconds(clause, tokens[i+1])
+		}
+		if err != nil {
+			return "", loop, err
+		}
+
+		i += args + 1
+	}
+
+	if err := loop.Validate(); err != nil {
+		return "", loop, err
+	}
+
+	return name, loop, nil
+}
+
+// isAudioFileToken checks if a token ends with the extension of an audio file,
+// ignoring the query of a URL
+func isAudioFileToken(tok string) bool {
+	if s.IsRemoteFile(tok) {
+		if u, err := url.Parse(tok); err == nil {
+			tok = u.Path
+		}
+	}
+
+	switch strings.ToLower(path.Ext(tok)) {
+	case ".wav", ".flac", ".ogg", ".oga", ".mp3":
+		return true
+	}
+	return false
+}
+
+// splitBackgroundOption splits a background option into its path, source name
+// and loop settings. The name is empty for the default background source.
+// The path, which may contain spaces, ends at the first token with the
+// extension of an audio file, or else at the first clause keyword.
+func splitBackgroundOption(tokens []string) (string, string, t.BackgroundLoop, error) {
+	end := slices.IndexFunc(tokens, isAudioFileToken) + 1
+	if end == 0 {
+		end = len(tokens)
+		for i := 1; i < len(tokens); i++ {
+			if isBackgroundClause(tokens[i]) {
+				end = i
+				break
+			}
+		}
+	}
+
+	if end == len(tokens) {
+		return strings.Join(tokens, " "), "", t.BackgroundLoop{}, nil
+	}
+
+	name, loop, err := parseBackgroundClauses(tokens[end:])
+	if err != nil {
+		return "", "", t.BackgroundLoop{}, err
+	}
+	return strings.Join(tokens[:end], " "), name, loop, nil
+}
+
+// setBackground stores a background path as the default or a named source
+func setBackground(options *t.SequenceOptions, name, path string, loop t.BackgroundLoop) {
+	if name == "" {
+		options.BackgroundPath = path
+		options.BackgroundLoop = loop
+		return
+	}
+	options.Backgrounds = append(options.Backgrounds, t.BackgroundSource{Name: name, Path: path, Loop: loop})
+}
@@ -0,0 +1,40 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// splitCueOption splits a cue option into its path and cue name. The name
+// follows the last "as" keyword, the path before it may contain spaces.
+func splitCueOption(tokens []string) (string, string, error) {
+	for i := len(tokens) - 2; i >= 1; i-- {
+		if tokens[i] != t.KeywordAs {
+			continue
+		}
+		if i+2 < len(tokens) {
+			return "", "", fmt.Errorf("unexpected token after cue name: %q", tokens[i+2])
+		}
+
+		name := tokens[i+1]
+		if err := t.ValidateCueName(name); err != nil {
+			return "", "", err
+		}
+		return strings.Join(tokens[:i], " "), name, nil
+	}
+
+	return "", "", fmt.Errorf("expected %q and a cue name after the cue path", t.KeywordAs)
+}
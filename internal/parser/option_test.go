@@ -74,6 +74,58 @@ func TestParseOption(ts *testing.T) {
 			fmt.Sprintf("%sgainlevel low", t.KeywordOption),
 			t.SequenceOptions{GainLevel: t.GainLevelLow},
 		},
+		{
+			fmt.Sprintf("%sgainlevel -4.5", t.KeywordOption),
+			t.SequenceOptions{GainLevel: t.GainLevelDBToRaw(-4.5)},
+		},
+		{
+			fmt.Sprintf("%sgainlevel 6", t.KeywordOption),
+			t.SequenceOptions{GainLevel: t.GainLevelDBToRaw(6)},
+		},
+		{
+			fmt.Sprintf("%sseed 12345", t.KeywordOption),
+			t.SequenceOptions{Seed: 12345},
+		},
+		{
+			fmt.Sprintf("%sseed -7", t.KeywordOption),
+			t.SequenceOptions{Seed: -7},
+		},
+		{
+			fmt.Sprintf("%sbalance -25", t.KeywordOption),
+			t.SequenceOptions{Balance: t.BalancePercentToRaw(-25)},
+		},
+		{
+			fmt.Sprintf("%slimiter soft", t.KeywordOption),
+			t.SequenceOptions{Limiter: t.LimiterSoft},
+		},
+		{
+			fmt.Sprintf("%slimiter off", t.KeywordOption),
+			t.SequenceOptions{Limiter: t.LimiterOff},
+		},
+		{
+			fmt.Sprintf("%sdither tpdf", t.KeywordOption),
+			t.SequenceOptions{Dither: t.DitherTPDF},
+		},
+		{
+			fmt.Sprintf("%sdither shaped", t.KeywordOption),
+			t.SequenceOptions{Dither: t.DitherShaped},
+		},
+		{
+			fmt.Sprintf("%sdither off", t.KeywordOption),
+			t.SequenceOptions{Dither: t.DitherOff},
+		},
+		{
+			fmt.Sprintf("%slayout quad", t.KeywordOption),
+			t.SequenceOptions{Layout: t.LayoutQuad},
+		},
+		{
+			fmt.Sprintf("%slayout 5.1", t.KeywordOption),
+			t.SequenceOptions{Layout: t.Layout51},
+		},
+		{
+			fmt.Sprintf("%slayout stereo", t.KeywordOption),
+			t.SequenceOptions{Layout: t.LayoutStereo},
+		},
 		{
 			fmt.Sprintf("%sbackground testdata/%s", t.KeywordOption, backgroundFile),
 			t.SequenceOptions{BackgroundPath: filepath.Clean(filepath.Join(basePath, "testdata", backgroundFile))},
@@ -82,6 +134,67 @@ func TestParseOption(ts *testing.T) {
 			fmt.Sprintf("%sbackground ~/Downloads/%s", t.KeywordOption, backgroundFile),
 			t.SequenceOptions{BackgroundPath: filepath.Clean(filepath.Join(homeDir, "Downloads", backgroundFile))},
 		},
+		{
+			fmt.Sprintf("%sbackground testdata/%s as rain", t.KeywordOption, backgroundFile),
+			t.SequenceOptions{Backgrounds: []t.BackgroundSource{
+				{Name: "rain", Path: filepath.Clean(filepath.Join(basePath, "testdata", backgroundFile))},
+			}},
+		},
+		{
+			fmt.Sprintf("%sbackground testdata/%s loop 1.5 30 crossfade 0.5 offset 10", t.KeywordOption, backgroundFile),
+			t.SequenceOptions{
+				BackgroundPath: filepath.Clean(filepath.Join(basePath, "testdata", backgroundFile)),
+				BackgroundLoop: t.BackgroundLoop{Start: 1.5, End: 30, Crossfade: 0.5, Offset: 10},
+			},
+		},
+		{
+			fmt.Sprintf("%sbackground testdata/%s offset 2 as rain crossfade 1", t.KeywordOption, backgroundFile),
+			t.SequenceOptions{Backgrounds: []t.BackgroundSource{
+				{
+					Name: "rain",
+					Path: filepath.Clean(filepath.Join(basePath, "testdata", backgroundFile)),
+					Loop: t.BackgroundLoop{Crossfade: 1, Offset: 2},
+				},
+			}},
+		},
+		{
+			fmt.Sprintf("%sbackground sounds/my loop.wav", t.KeywordOption),
+			t.SequenceOptions{BackgroundPath: filepath.Clean(filepath.Join(basePath, "sounds", "my loop.wav"))},
+		},
+		{
+			fmt.Sprintf("%sbackground sounds/rain as heard offset 1.flac as rain offset 2", t.KeywordOption),
+			t.SequenceOptions{Backgrounds: []t.BackgroundSource{
+				{
+					Name: "rain",
+					Path: filepath.Clean(filepath.Join(basePath, "sounds", "rain as heard offset 1.flac")),
+					Loop: t.BackgroundLoop{Offset: 2},
+				},
+			}},
+		},
+		{
+			fmt.Sprintf("%sbackground https://example.com/rain.mp3?v=1 as rain", t.KeywordOption),
+			t.SequenceOptions{Backgrounds: []t.BackgroundSource{{Name: "rain", Path: "https://example.com/rain.mp3?v=1"}}},
+		},
+		{
+			fmt.Sprintf("%scue sounds/wake up.wav as wake", t.KeywordOption),
+			t.SequenceOptions{Cues: []t.CueSource{
+				{Name: "wake", Path: filepath.Clean(filepath.Join(basePath, "sounds", "wake up.wav"))},
+			}},
+		},
+		{
+			fmt.Sprintf("%scue https://example.com/bell.wav as bell", t.KeywordOption),
+			t.SequenceOptions{Cues: []t.CueSource{{Name: "bell", Path: "https://example.com/bell.wav"}}},
+		},
+		{
+			fmt.Sprintf("%swaveform harmonics 1 0 0.33 0 0.2 as organ", t.KeywordOption),
+			t.SequenceOptions{Waveforms: []t.WaveformSource{{Name: "organ", Harmonics: []float64{1, 0, 0.33, 0, 0.2}}}},
+		},
+		{
+			fmt.Sprintf("%swaveform cycles/warm pad.wav as warm", t.KeywordOption),
+			t.SequenceOptions{Waveforms: []t.WaveformSource{
+				{Name: "warm", Path: filepath.Clean(filepath.Join(basePath, "cycles", "warm pad.wav"))},
+			}},
+		},
 	}
 
 	for _, test := range tests {
@@ -99,3 +212,135 @@ func TestParseOption(ts *testing.T) {
 		}
 	}
 }
+
+func TestParseOption_InvalidSeed(ts *testing.T) {
+	lines := []string{
+		fmt.Sprintf("%sseed", t.KeywordOption),
+		fmt.Sprintf("%sseed abc", t.KeywordOption),
+		fmt.Sprintf("%sseed 1.5", t.KeywordOption),
+		fmt.Sprintf("%sseed 1 2", t.KeywordOption),
+	}
+
+	for _, line := range lines {
+		option := t.SequenceOptions{}
+		ctx := NewTextParser(line)
+		if err := ctx.ParseOption(&option, ""); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseOption_InvalidGainLevelAndLimiter(ts *testing.T) {
+	lines := []string{
+		fmt.Sprintf("%sgainlevel", t.KeywordOption),
+		fmt.Sprintf("%sgainlevel loud", t.KeywordOption),
+		fmt.Sprintf("%sgainlevel -6db", t.KeywordOption),
+		fmt.Sprintf("%sgainlevel -6 -3", t.KeywordOption),
+		fmt.Sprintf("%slimiter", t.KeywordOption),
+		fmt.Sprintf("%slimiter hard", t.KeywordOption),
+		fmt.Sprintf("%slimiter soft now", t.KeywordOption),
+	}
+
+	for _, line := range lines {
+		option := t.SequenceOptions{}
+		ctx := NewTextParser(line)
+		if err := ctx.ParseOption(&option, ""); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseOption_InvalidDither(ts *testing.T) {
+	lines := []string{
+		fmt.Sprintf("%sdither", t.KeywordOption),
+		fmt.Sprintf("%sdither rpdf", t.KeywordOption),
+		fmt.Sprintf("%sdither tpdf shaped", t.KeywordOption),
+	}
+
+	for _, line := range lines {
+		option := t.SequenceOptions{}
+		ctx := NewTextParser(line)
+		if err := ctx.ParseOption(&option, ""); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseOption_InvalidLayout(ts *testing.T) {
+	lines := []string{
+		fmt.Sprintf("%slayout", t.KeywordOption),
+		fmt.Sprintf("%slayout 7.1", t.KeywordOption),
+		fmt.Sprintf("%slayout quad stereo", t.KeywordOption),
+	}
+
+	for _, line := range lines {
+		option := t.SequenceOptions{}
+		ctx := NewTextParser(line)
+		if err := ctx.ParseOption(&option, ""); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseOption_InvalidBackground(ts *testing.T) {
+	lines := []string{
+		fmt.Sprintf("%sbackground noise.wav as", t.KeywordOption),
+		fmt.Sprintf("%sbackground noise.wav as spin", t.KeywordOption),
+		fmt.Sprintf("%sbackground noise.wav as Rain", t.KeywordOption),
+		fmt.Sprintf("%sbackground noise.wav as loop", t.KeywordOption),
+		fmt.Sprintf("%sbackground noise.wav loop 10", t.KeywordOption),
+		fmt.Sprintf("%sbackground noise.wav loop 10 5", t.KeywordOption),
+		fmt.Sprintf("%sbackground noise.wav loop 0 10 crossfade 6", t.KeywordOption),
+		fmt.Sprintf("%sbackground noise.wav offset 1 offset 2", t.KeywordOption),
+		fmt.Sprintf("%sbackground noise.wav crossfade -1", t.KeywordOption),
+	}
+
+	for _, line := range lines {
+		option := t.SequenceOptions{}
+		ctx := NewTextParser(line)
+		if err := ctx.ParseOption(&option, ""); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseOption_InvalidCue(ts *testing.T) {
+	lines := []string{
+		fmt.Sprintf("%scue", t.KeywordOption),
+		fmt.Sprintf("%scue bell.wav", t.KeywordOption),
+		fmt.Sprintf("%scue bell.wav as", t.KeywordOption),
+		fmt.Sprintf("%scue bell.wav as Bell", t.KeywordOption),
+		fmt.Sprintf("%scue bell.wav as amplitude", t.KeywordOption),
+		fmt.Sprintf("%scue bell.wav as bell loop 1 2", t.KeywordOption),
+		fmt.Sprintf("%scue - as bell", t.KeywordOption),
+	}
+
+	for _, line := range lines {
+		option := t.SequenceOptions{}
+		ctx := NewTextParser(line)
+		if err := ctx.ParseOption(&option, ""); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseOption_InvalidWaveform(ts *testing.T) {
+	lines := []string{
+		fmt.Sprintf("%swaveform", t.KeywordOption),
+		fmt.Sprintf("%swaveform harmonics 1 0.5", t.KeywordOption),
+		fmt.Sprintf("%swaveform harmonics as organ", t.KeywordOption),
+		fmt.Sprintf("%swaveform harmonics 1 x as organ", t.KeywordOption),
+		fmt.Sprintf("%swaveform harmonics 0 0 as organ", t.KeywordOption),
+		fmt.Sprintf("%swaveform harmonics 1 as square", t.KeywordOption),
+		fmt.Sprintf("%swaveform harmonics 1 as Organ", t.KeywordOption),
+		fmt.Sprintf("%swaveform - as organ", t.KeywordOption),
+	}
+
+	for _, line := range lines {
+		option := t.SequenceOptions{}
+		ctx := NewTextParser(line)
+		if err := ctx.ParseOption(&option, ""); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
@@ -62,6 +62,51 @@ func (ctx *TextParser) ParseOption(options *t.SequenceOptions) error {
 			return fmt.Errorf("volume: %v", err)
 		}
 		options.Volume = volume
+	case t.KeywordOptionSeed:
+		seed, err := ctx.Line.NextIntStrict()
+		if err != nil {
+			return fmt.Errorf("seed: %v", err)
+		}
+		options.Seed = int64(seed)
+	case t.KeywordOptionBalance:
+		balance, err := ctx.Line.NextFloat64Strict()
+		if err != nil {
+			return fmt.Errorf("balance: %v", err)
+		}
+		options.Balance = t.BalancePercentToRaw(balance)
+	case t.KeywordOptionLimiter:
+		limiter, ok := ctx.Line.NextToken()
+		if !ok {
+			return fmt.Errorf("expected limiter: %s", ln)
+		}
+
+		mode, err := t.ParseLimiter(limiter)
+		if err != nil {
+			return err
+		}
+		options.Limiter = mode
+	case t.KeywordOptionDither:
+		dither, ok := ctx.Line.NextToken()
+		if !ok {
+			return fmt.Errorf("expected dither: %s", ln)
+		}
+
+		mode, err := t.ParseDither(dither)
+		if err != nil {
+			return err
+		}
+		options.Dither = mode
+	case t.KeywordOptionLayout:
+		name, ok := ctx.Line.NextToken()
+		if !ok {
+			return fmt.Errorf("expected layout: %s", ln)
+		}
+
+		layout, err := t.ParseChannelLayout(name)
+		if err != nil {
+			return err
+		}
+		options.Layout = layout
 	case t.KeywordOptionBackground, t.KeywordOptionPresetList:
 		_, ok := ctx.Line.NextToken()
 		if !ok {
@@ -69,39 +114,73 @@ func (ctx *TextParser) ParseOption(options *t.SequenceOptions) error {
 		}
 
 		content := strings.Join(ctx.Line.Tokens[1:], " ")
+
+		name := ""
+		var loop t.BackgroundLoop
+		if option == t.KeywordOptionBackground {
+			var err error
+			if content, name, loop, err = splitBackgroundOption(ctx.Line.Tokens[1:]); err != nil {
+				return err
+			}
+		}
+
 		if !s.IsRemoteFile(content) {
 			return fmt.Errorf("file paths are not supported in WASM for background or preset list: %s", content)
 		}
 
 		if option == t.KeywordBackground {
-			options.BackgroundPath = content
+			setBackground(options, name, content, loop)
 		} else {
 			options.PresetList = append(options.PresetList, content)
 		}
+	case t.KeywordOptionCue:
+		if _, ok := ctx.Line.NextToken(); !ok {
+			return fmt.Errorf("expected path: %s", ln)
+		}
+
+		path, name, err := splitCueOption(ctx.Line.Tokens[1:])
+		if err != nil {
+			return err
+		}
+
+		if !s.IsRemoteFile(path) {
+			return fmt.Errorf("file paths are not supported in WASM for cues: %s", path)
+		}
+
+		options.Cues = append(options.Cues, t.CueSource{Name: name, Path: path})
+	case t.KeywordOptionWaveform:
+		if _, ok := ctx.Line.NextToken(); !ok {
+			return fmt.Errorf("expected waveform definition: %s", ln)
+		}
+
+		waveform, err := splitWaveformOption(ctx.Line.Tokens[1:])
+		if err != nil {
+			return err
+		}
+
+		if waveform.Path != "" && !s.IsRemoteFile(waveform.Path) {
+			return fmt.Errorf("file paths are not supported in WASM for waveforms: %s", waveform.Path)
+		}
+
+		options.Waveforms = append(options.Waveforms, waveform)
 	case t.KeywordOptionGainLevel:
 		gainLevel, ok := ctx.Line.NextToken()
 		if !ok {
 			return fmt.Errorf("expected gain level: %s", ln)
 		}
 
-		switch gainLevel {
-		case t.KeywordOff:
-			options.GainLevel = t.GainLevelOff
-		case t.KeywordOptionGainLevelHigh:
-			options.GainLevel = t.GainLevelHigh
-		case t.KeywordOptionGainLevelMedium:
-			options.GainLevel = t.GainLevelMedium
-		case t.KeywordOptionGainLevelLow:
-			options.GainLevel = t.GainLevelLow
-		default:
-			return fmt.Errorf("invalid gain level: %q", gainLevel)
+		// A preset keyword or any gain in dB
+		level, err := t.ParseGainLevel(gainLevel)
+		if err != nil {
+			return err
 		}
+		options.GainLevel = level
 	default:
 		return fmt.Errorf("invalid option: %q", option)
 	}
 
-	// If the option is not background, ensure no extra tokens are present
-	if option != t.KeywordOptionBackground {
+	// If the option is not background, cue or waveform, ensure no extra tokens are present
+	if option != t.KeywordOptionBackground && option != t.KeywordOptionCue && option != t.KeywordOptionWaveform {
 		unknown, ok := ctx.Line.Peek()
 		if ok {
 			return fmt.Errorf("unexpected token after option definition: %q", unknown)
@@ -107,6 +107,15 @@ func (ctx *TextParser) ParseTimeline(presets *[]t.Preset) (*t.Period, error) {
 		}
 	}
 
+	// Optional frequency glide after the transition, linear by default
+	glide := t.GlideLinear
+	if tok, ok := ctx.Line.Peek(); ok {
+		if g, err := t.ParseGlide(tok); err == nil {
+			ctx.Line.NextToken()
+			glide = g
+		}
+	}
+
 	unknown, ok := ctx.Line.Peek()
 	if ok {
 		return nil, fmt.Errorf("unexpected token on timeline %q: %s", unknown, ln)
@@ -126,6 +135,7 @@ func (ctx *TextParser) ParseTimeline(presets *[]t.Preset) (*t.Period, error) {
 		TrackStart: p.Track,
 		TrackEnd:   p.Track,
 		Transition: transitionType,
+		Glide:      glide,
 	}
 
 	return period, nil
@@ -204,6 +204,42 @@ func TestParseTimelineWithTransitions(ts *testing.T) {
 	}
 }
 
+func TestParseTimeline_Glide(ts *testing.T) {
+	alpha, err := t.NewPreset("alpha", false, nil)
+	if err != nil {
+		ts.Fatalf("unexpected error creating preset 'alpha': %v", err)
+	}
+	presets := []t.Preset{*alpha}
+
+	tests := map[string]t.GlideType{
+		"00:00:00 alpha":                    t.GlideLinear,
+		"00:00:00 alpha steady linear":      t.GlideLinear,
+		"00:00:00 alpha steady log":         t.GlideLog,
+		"00:00:00 alpha smooth log-carrier": t.GlideLogCarrier,
+		"00:00:00 alpha ease-in log-beat":   t.GlideLogBeat,
+	}
+	for line, want := range tests {
+		per, err := NewTextParser(line).ParseTimeline(&presets)
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", line, err)
+			continue
+		}
+		if per.Glide != want {
+			ts.Errorf("For line '%s', expected glide %s but got %s", line, want, per.Glide)
+		}
+	}
+
+	for _, line := range []string{
+		"00:00:00 alpha log",
+		"00:00:00 alpha steady exponential",
+		"00:00:00 alpha steady log log",
+	} {
+		if _, err := NewTextParser(line).ParseTimeline(&presets); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
 func TestParseTimeline_TemplatePresetNotAllowed(ts *testing.T) {
 	var presets []t.Preset
 
@@ -13,6 +13,7 @@ package parser
 
 import (
 	"fmt"
+	"strconv"
 
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
@@ -36,32 +37,205 @@ func (ctx *TextParser) HasTrack() bool {
 	return false
 }
 
+// parseEnvelopeShape parses an envelope shape keyword
+func (ctx *TextParser) parseEnvelopeShape() (t.EnvelopeShape, error) {
+	tok, err := ctx.Line.NextExpectOneOf(t.KeywordSquare, t.KeywordSmoothstep, t.KeywordRaisedCosine, t.KeywordExponential)
+	if err != nil {
+		return t.EnvelopeDefault, fmt.Errorf("expected %q, %q, %q or %q after envelope: %s", t.KeywordSquare, t.KeywordSmoothstep, t.KeywordRaisedCosine, t.KeywordExponential, ctx.Line.Raw)
+	}
+
+	switch tok {
+	case t.KeywordSquare:
+		return t.EnvelopeSquare, nil
+	case t.KeywordSmoothstep:
+		return t.EnvelopeSmoothstep, nil
+	case t.KeywordRaisedCosine:
+		return t.EnvelopeRaisedCosine, nil
+	default:
+		return t.EnvelopeExponential, nil
+	}
+}
+
+// parseEnvelope parses an envelope definition: envelope <shape> [duty N] [attack N] [release N]
+func (ctx *TextParser) parseEnvelope() (t.Envelope, error) {
+	ctx.Line.NextToken() // skip "envelope"
+
+	shape, err := ctx.parseEnvelopeShape()
+	if err != nil {
+		return t.Envelope{}, err
+	}
+
+	// Default to a 50% duty cycle with hard edges
+	envelope := t.Envelope{Shape: shape, Duty: t.DutyPercentToRaw(50)}
+
+	seen := make(map[string]bool)
+	for {
+		tok, ok := ctx.Line.Peek()
+		if !ok || (tok != t.KeywordDuty && tok != t.KeywordAttack && tok != t.KeywordRelease) {
+			break
+		}
+		ctx.Line.NextToken()
+
+		if seen[tok] {
+			return t.Envelope{}, fmt.Errorf("duplicate %q in envelope: %s", tok, ctx.Line.Raw)
+		}
+		seen[tok] = true
+
+		val, err := ctx.Line.NextFloat64Strict()
+		if err != nil {
+			return t.Envelope{}, fmt.Errorf("%s: %w", tok, err)
+		}
+
+		switch tok {
+		case t.KeywordDuty:
+			envelope.Duty = t.DutyPercentToRaw(val)
+		case t.KeywordAttack:
+			envelope.Attack = val
+		case t.KeywordRelease:
+			envelope.Release = val
+		}
+	}
+
+	return envelope, nil
+}
+
+// parsePartials parses the partials of a tone:
+// harmonics <N> | chord <name> | ratios <R...>, followed by an optional rolloff <dB>
+func (ctx *TextParser) parsePartials() (t.Partials, error) {
+	ln := ctx.Line.Raw
+	kind, _ := ctx.Line.NextToken()
+
+	var partials t.Partials
+	switch kind {
+	case t.KeywordHarmonics:
+		count, err := ctx.Line.NextIntStrict()
+		if err != nil {
+			return t.Partials{}, fmt.Errorf("harmonics: %w", err)
+		}
+		partials = t.HarmonicPartials(count)
+	case t.KeywordChord:
+		name, ok := ctx.Line.NextToken()
+		if !ok {
+			return t.Partials{}, fmt.Errorf("expected chord name after %q: %s", t.KeywordChord, ln)
+		}
+		chord, err := t.ParseChord(name)
+		if err != nil {
+			return t.Partials{}, err
+		}
+		partials = t.ChordPartials(chord)
+	case t.KeywordRatios:
+		var ratios []float64
+		for {
+			tok, ok := ctx.Line.Peek()
+			if !ok {
+				break
+			}
+			if _, err := strconv.ParseFloat(tok, 64); err != nil {
+				break
+			}
+			ratio, err := ctx.Line.NextFloat64Strict()
+			if err != nil {
+				return t.Partials{}, fmt.Errorf("ratio: %w", err)
+			}
+			ratios = append(ratios, ratio)
+		}
+		if len(ratios) == 0 {
+			return t.Partials{}, fmt.Errorf("expected ratios after %q: %s", t.KeywordRatios, ln)
+		}
+		partials = t.RatioPartials(ratios)
+	}
+
+	if tok, ok := ctx.Line.Peek(); ok && tok == t.KeywordRolloff {
+		ctx.Line.NextToken() // skip "rolloff"
+
+		var err error
+		if partials.Rolloff, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return t.Partials{}, fmt.Errorf("rolloff: %w", err)
+		}
+	}
+
+	return partials, nil
+}
+
+// parseEffect parses the amplitude or the spin/pulse effect of a background or noise track
+func (ctx *TextParser) parseEffect(kind string) (effect t.Effect, carrier, resonance, amplitude float64, err error) {
+	ln := ctx.Line.Raw
+	effect.Type = t.EffectOff
+	intensity := 0.0
+
+	switch kind {
+	case t.KeywordAmplitude:
+		if amplitude, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("amplitude: %w", err)
+		}
+	case t.KeywordSpin:
+		effect.Type = t.EffectSpin
+		if carrier, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("carrier: %w", err)
+		}
+		if _, err := ctx.Line.NextExpectOneOf(t.KeywordRate); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("expected %q after carrier: %s", t.KeywordRate, ln)
+		}
+		if resonance, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("resonance: %w", err)
+		}
+		if _, err := ctx.Line.NextExpectOneOf(t.KeywordIntensity); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("expected %q after resonance: %s", t.KeywordIntensity, ln)
+		}
+		if intensity, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("intensity: %w", err)
+		}
+		if _, err := ctx.Line.NextExpectOneOf(t.KeywordAmplitude); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("expected %q after resonance: %s", t.KeywordAmplitude, ln)
+		}
+		if amplitude, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("amplitude: %w", err)
+		}
+	case t.KeywordPulse:
+		effect.Type = t.EffectPulse
+		if resonance, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("resonance: %w", err)
+		}
+		if _, err := ctx.Line.NextExpectOneOf(t.KeywordIntensity); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("expected %q after resonance: %s", t.KeywordIntensity, ln)
+		}
+		if intensity, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("intensity: %w", err)
+		}
+		if _, err := ctx.Line.NextExpectOneOf(t.KeywordAmplitude); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("expected %q after intensity: %s", t.KeywordAmplitude, ln)
+		}
+		if amplitude, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return t.Effect{}, 0, 0, 0, fmt.Errorf("amplitude: %w", err)
+		}
+	}
+
+	effect.Intensity = t.IntensityPercentToRaw(intensity)
+	return effect, carrier, resonance, amplitude, nil
+}
+
 // ParseTrack extracts and returns a Track from the current line context
 func (ctx *TextParser) ParseTrack() (*t.Track, error) {
 	waveform := t.WaveformSine
+	waveformName := ""
 	ln := ctx.Line.Raw
 
 	if tok, ok := ctx.Line.Peek(); ok && tok == t.KeywordWaveform {
 		ctx.Line.NextToken() // skip "waveform"
 
-		wfTok, err := ctx.Line.NextExpectOneOf(t.KeywordSine, t.KeywordSquare, t.KeywordTriangle, t.KeywordSawtooth)
-		if err != nil {
-			return nil, fmt.Errorf("expected %q, %q, %q, or %q after waveform: %s", t.KeywordSine, t.KeywordSquare, t.KeywordTriangle, t.KeywordSawtooth, ln)
+		// A built-in waveform or the name of a user-defined one
+		wfTok, ok := ctx.Line.NextToken()
+		if !ok {
+			return nil, fmt.Errorf("expected %q, %q, %q, %q or a waveform name after waveform: %s", t.KeywordSine, t.KeywordSquare, t.KeywordTriangle, t.KeywordSawtooth, ln)
 		}
 
-		switch wfTok {
-		case t.KeywordSine:
-			waveform = t.WaveformSine
-		case t.KeywordSquare:
-			waveform = t.WaveformSquare
-		case t.KeywordTriangle:
-			waveform = t.WaveformTriangle
-		case t.KeywordSawtooth:
-			waveform = t.WaveformSawtooth
+		var err error
+		if waveform, waveformName, err = t.ParseWaveform(wfTok); err != nil {
+			return nil, err
 		}
 
-		if _, err := ctx.Line.NextExpectOneOf(t.KeywordTone, t.KeywordBackground); err != nil {
-			return nil, fmt.Errorf("expected %q or %q after waveform type: %s", t.KeywordTone, t.KeywordBackground, ln)
+		if _, err := ctx.Line.NextExpectOneOf(t.KeywordTone, t.KeywordNoise, t.KeywordBackground); err != nil {
+			return nil, fmt.Errorf("expected %q, %q or %q after waveform type: %s", t.KeywordTone, t.KeywordNoise, t.KeywordBackground, ln)
 		}
 
 		ctx.Line.RewindToken(1) // rewind to re-process the tone line
@@ -69,12 +243,14 @@ func (ctx *TextParser) ParseTrack() (*t.Track, error) {
 
 	first, ok := ctx.Line.NextToken()
 	if !ok {
-		return nil, fmt.Errorf("expected %q, %s or %q: %s", t.KeywordTone, t.KeywordNoise, t.KeywordBackground, ln)
+		return nil, fmt.Errorf("expected %q, %q, %q, %q or %q: %s", t.KeywordTone, t.KeywordNoise, t.KeywordBackground, t.KeywordCue, t.KeywordBell, ln)
 	}
 
 	var (
 		carrier, resonance, amplitude float64
+		decay, interval, depth        float64
 		trackType                     t.TrackType
+		source                        string
 	)
 
 	effect := t.Effect{Type: t.EffectOff, Intensity: 0.0}
@@ -86,9 +262,9 @@ func (ctx *TextParser) ParseTrack() (*t.Track, error) {
 			return nil, fmt.Errorf("carrier: %w", err)
 		}
 
-		kind, err := ctx.Line.NextExpectOneOf(t.KeywordBinaural, t.KeywordMonaural, t.KeywordIsochronic, t.KeywordAmplitude)
+		kind, err := ctx.Line.NextExpectOneOf(t.KeywordBinaural, t.KeywordMonaural, t.KeywordIsochronic, t.KeywordFM, t.KeywordAM, t.KeywordAmplitude)
 		if err != nil {
-			return nil, fmt.Errorf("expected %q, %q, %q or %q after carrier: %s", t.KeywordBinaural, t.KeywordMonaural, t.KeywordIsochronic, t.KeywordAmplitude, ln)
+			return nil, fmt.Errorf("expected %q, %q, %q, %q, %q or %q after carrier: %s", t.KeywordBinaural, t.KeywordMonaural, t.KeywordIsochronic, t.KeywordFM, t.KeywordAM, t.KeywordAmplitude, ln)
 		}
 
 		switch kind {
@@ -98,11 +274,28 @@ func (ctx *TextParser) ParseTrack() (*t.Track, error) {
 			trackType = t.TrackMonauralBeat
 		case t.KeywordIsochronic:
 			trackType = t.TrackIsochronicBeat
+		case t.KeywordFM:
+			trackType = t.TrackFMTone
+		case t.KeywordAM:
+			trackType = t.TrackAMTone
 		case t.KeywordAmplitude:
 			trackType = t.TrackPureTone
 		}
 
-		if trackType != t.TrackPureTone {
+		if trackType == t.TrackFMTone || trackType == t.TrackAMTone {
+			if resonance, err = ctx.Line.NextFloat64Strict(); err != nil {
+				return nil, fmt.Errorf("modulator: %w", err)
+			}
+			if _, err := ctx.Line.NextExpectOneOf(t.KeywordDepth); err != nil {
+				return nil, fmt.Errorf("expected %q after modulator: %s", t.KeywordDepth, ln)
+			}
+			if depth, err = ctx.Line.NextFloat64Strict(); err != nil {
+				return nil, fmt.Errorf("depth: %w", err)
+			}
+			if _, err := ctx.Line.NextExpectOneOf(t.KeywordAmplitude); err != nil {
+				return nil, fmt.Errorf("expected %q after depth: %s", t.KeywordAmplitude, ln)
+			}
+		} else if trackType != t.TrackPureTone {
 			if resonance, err = ctx.Line.NextFloat64Strict(); err != nil {
 				return nil, fmt.Errorf("resonance: %w", err)
 			}
@@ -130,71 +323,150 @@ func (ctx *TextParser) ParseTrack() (*t.Track, error) {
 			trackType = t.TrackBrownNoise
 		}
 
-		if _, err := ctx.Line.NextExpectOneOf(t.KeywordAmplitude); err != nil {
-			return nil, fmt.Errorf("expected %q after noise type: %s", t.KeywordAmplitude, ln)
+		kind, err = ctx.Line.NextExpectOneOf(t.KeywordAmplitude, t.KeywordSpin, t.KeywordPulse)
+		if err != nil {
+			return nil, fmt.Errorf("expected %q, %q or %q after noise type: %s", t.KeywordAmplitude, t.KeywordSpin, t.KeywordPulse, ln)
 		}
-		if amplitude, err = ctx.Line.NextFloat64Strict(); err != nil {
-			return nil, fmt.Errorf("amplitude: %w", err)
+
+		if effect, carrier, resonance, amplitude, err = ctx.parseEffect(kind); err != nil {
+			return nil, err
 		}
 	case t.KeywordBackground:
 		trackType = t.TrackBackground
+
+		// Optional background source name, a word followed by a number is a
+		// misspelled keyword
+		if tok, ok := ctx.Line.Peek(); ok && tok != t.KeywordAmplitude && tok != t.KeywordSpin && tok != t.KeywordPulse {
+			ctx.Line.NextToken()
+			if next, ok := ctx.Line.Peek(); ok {
+				if _, err := strconv.ParseFloat(next, 64); err == nil {
+					return nil, fmt.Errorf("unknown keyword %q after background, expected %q, %q or %q: %s", tok, t.KeywordAmplitude, t.KeywordSpin, t.KeywordPulse, ln)
+				}
+			}
+			if err := t.ValidateBackgroundName(tok); err != nil {
+				return nil, err
+			}
+			source = tok
+		}
+
+		next, _ := ctx.Line.Peek()
 		kind, err := ctx.Line.NextExpectOneOf(t.KeywordAmplitude, t.KeywordSpin, t.KeywordPulse)
 		if err != nil {
+			if source != "" && next != "" {
+				return nil, fmt.Errorf("unknown keyword %q after background %s, expected %q, %q or %q: %s", next, source, t.KeywordAmplitude, t.KeywordSpin, t.KeywordPulse, ln)
+			}
 			return nil, fmt.Errorf("expected %q, %q or %q after background: %s", t.KeywordAmplitude, t.KeywordSpin, t.KeywordPulse, ln)
 		}
 
-		intensity := 0.0
+		if effect, carrier, resonance, amplitude, err = ctx.parseEffect(kind); err != nil {
+			return nil, err
+		}
+	case t.KeywordCue:
+		trackType = t.TrackCue
 
-		switch kind {
-		case t.KeywordAmplitude:
-			if amplitude, err = ctx.Line.NextFloat64Strict(); err != nil {
-				return nil, fmt.Errorf("amplitude: %w", err)
-			}
-		case t.KeywordSpin:
-			effect.Type = t.EffectSpin
-			if carrier, err = ctx.Line.NextFloat64Strict(); err != nil {
-				return nil, fmt.Errorf("carrier: %w", err)
-			}
-			if _, err := ctx.Line.NextExpectOneOf(t.KeywordRate); err != nil {
-				return nil, fmt.Errorf("expected %q after carrier: %s", t.KeywordRate, ln)
-			}
-			if resonance, err = ctx.Line.NextFloat64Strict(); err != nil {
-				return nil, fmt.Errorf("resonance: %w", err)
-			}
-			if _, err := ctx.Line.NextExpectOneOf(t.KeywordIntensity); err != nil {
-				return nil, fmt.Errorf("expected %q after resonance: %s", t.KeywordIntensity, ln)
-			}
-			if intensity, err = ctx.Line.NextFloat64Strict(); err != nil {
-				return nil, fmt.Errorf("intensity: %w", err)
-			}
-			if _, err := ctx.Line.NextExpectOneOf(t.KeywordAmplitude); err != nil {
-				return nil, fmt.Errorf("expected %q after resonance: %s", t.KeywordAmplitude, ln)
-			}
-			if amplitude, err = ctx.Line.NextFloat64Strict(); err != nil {
-				return nil, fmt.Errorf("amplitude: %w", err)
-			}
-		case t.KeywordPulse:
-			effect.Type = t.EffectPulse
-			if resonance, err = ctx.Line.NextFloat64Strict(); err != nil {
-				return nil, fmt.Errorf("resonance: %w", err)
-			}
-			if _, err := ctx.Line.NextExpectOneOf(t.KeywordIntensity); err != nil {
-				return nil, fmt.Errorf("expected %q after resonance: %s", t.KeywordIntensity, ln)
-			}
-			if intensity, err = ctx.Line.NextFloat64Strict(); err != nil {
-				return nil, fmt.Errorf("intensity: %w", err)
+		name, ok := ctx.Line.NextToken()
+		if !ok {
+			return nil, fmt.Errorf("expected cue name after %q: %s", t.KeywordCue, ln)
+		}
+		if err := t.ValidateCueName(name); err != nil {
+			return nil, err
+		}
+		source = name
+
+		if _, err := ctx.Line.NextExpectOneOf(t.KeywordAmplitude); err != nil {
+			return nil, fmt.Errorf("expected %q after cue name: %s", t.KeywordAmplitude, ln)
+		}
+
+		var err error
+		if amplitude, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return nil, fmt.Errorf("amplitude: %w", err)
+		}
+	case t.KeywordBell:
+		trackType = t.TrackBell
+
+		var err error
+		if carrier, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return nil, fmt.Errorf("frequency: %w", err)
+		}
+		if _, err := ctx.Line.NextExpectOneOf(t.KeywordDecay); err != nil {
+			return nil, fmt.Errorf("expected %q after bell frequency: %s", t.KeywordDecay, ln)
+		}
+		if decay, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return nil, fmt.Errorf("decay: %w", err)
+		}
+
+		// Optional strike interval
+		if tok, ok := ctx.Line.Peek(); ok && tok == t.KeywordEvery {
+			ctx.Line.NextToken() // skip "every"
+
+			if interval, err = ctx.Line.NextFloat64Strict(); err != nil {
+				return nil, fmt.Errorf("interval: %w", err)
 			}
-			if _, err := ctx.Line.NextExpectOneOf(t.KeywordAmplitude); err != nil {
-				return nil, fmt.Errorf("expected %q after intensity: %s", t.KeywordAmplitude, ln)
+		}
+
+		if _, err := ctx.Line.NextExpectOneOf(t.KeywordAmplitude); err != nil {
+			return nil, fmt.Errorf("expected %q after decay: %s", t.KeywordAmplitude, ln)
+		}
+		if amplitude, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return nil, fmt.Errorf("amplitude: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("expected %q, %q, %q, %q, %q or %q. Received: %s", t.KeywordTone, t.KeywordNoise, t.KeywordBackground, t.KeywordCue, t.KeywordBell, t.KeywordTrack, first)
+	}
+
+	var partials t.Partials
+	if first == t.KeywordTone {
+		if tok, ok := ctx.Line.Peek(); ok && (tok == t.KeywordHarmonics || tok == t.KeywordChord || tok == t.KeywordRatios) {
+			var err error
+			if partials, err = ctx.parsePartials(); err != nil {
+				return nil, err
 			}
-			if amplitude, err = ctx.Line.NextFloat64Strict(); err != nil {
-				return nil, fmt.Errorf("amplitude: %w", err)
+		}
+	}
+
+	var envelope t.Envelope
+	if trackType == t.TrackIsochronicBeat || effect.Type == t.EffectPulse {
+		if tok, ok := ctx.Line.Peek(); ok && tok == t.KeywordEnvelope {
+			var err error
+			if envelope, err = ctx.parseEnvelope(); err != nil {
+				return nil, err
 			}
 		}
+	}
 
-		effect.Intensity = t.IntensityPercentToRaw(intensity)
-	default:
-		return nil, fmt.Errorf("expected %q, %q, %q or %q. Received: %s", t.KeywordTone, t.KeywordNoise, t.KeywordBackground, t.KeywordTrack, first)
+	gain := 0.0
+	if tok, ok := ctx.Line.Peek(); ok && tok == t.KeywordGain {
+		ctx.Line.NextToken() // skip "gain"
+
+		var err error
+		if gain, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return nil, fmt.Errorf("gain: %w", err)
+		}
+	}
+
+	pan := 0.0
+	if tok, ok := ctx.Line.Peek(); ok && tok == t.KeywordPan {
+		ctx.Line.NextToken() // skip "pan"
+
+		var err error
+		if pan, err = ctx.Line.NextFloat64Strict(); err != nil {
+			return nil, fmt.Errorf("pan: %w", err)
+		}
+	}
+
+	route := t.RouteFront
+	if tok, ok := ctx.Line.Peek(); ok && tok == t.KeywordRoute {
+		ctx.Line.NextToken() // skip "route"
+
+		speakers, ok := ctx.Line.NextToken()
+		if !ok {
+			return nil, fmt.Errorf("expected route after %q: %s", t.KeywordRoute, ctx.Line.Raw)
+		}
+
+		var err error
+		if route, err = t.ParseRoute(speakers); err != nil {
+			return nil, err
+		}
 	}
 
 	unknown, ok := ctx.Line.Peek()
@@ -203,12 +475,22 @@ func (ctx *TextParser) ParseTrack() (*t.Track, error) {
 	}
 
 	track := t.Track{
-		Type:      trackType,
-		Carrier:   carrier,
-		Resonance: resonance,
-		Amplitude: t.AmplitudePercentToRaw(amplitude),
-		Waveform:  waveform,
-		Effect:    effect,
+		Type:         trackType,
+		Carrier:      carrier,
+		Resonance:    resonance,
+		Amplitude:    t.AmplitudePercentToRaw(amplitude),
+		Waveform:     waveform,
+		WaveformName: waveformName,
+		Effect:       effect,
+		Envelope:     envelope,
+		Pan:          t.PanPercentToRaw(pan),
+		Source:       source,
+		Gain:         gain,
+		Route:        route,
+		Decay:        decay,
+		Interval:     interval,
+		Partials:     partials,
+		Depth:        depth,
 	}
 	if err := track.Validate(); err != nil {
 		return nil, fmt.Errorf("%w", err)
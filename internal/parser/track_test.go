@@ -192,6 +192,19 @@ func TestParseTrack_Background(ts *testing.T) {
 			Type:      t.TrackBackground,
 			Amplitude: t.AmplitudePercentToRaw(33),
 		},
+		{
+			Type:      t.TrackBackground,
+			Amplitude: t.AmplitudePercentToRaw(20),
+			Source:    "rain",
+		},
+		{
+			Type:      t.TrackBackground,
+			Carrier:   300,
+			Resonance: 0.5,
+			Effect:    t.Effect{Type: t.EffectSpin, Intensity: t.IntensityPercentToRaw(40)},
+			Amplitude: t.AmplitudePercentToRaw(25),
+			Source:    "birds",
+		},
 	}
 
 	tests := []struct {
@@ -203,6 +216,8 @@ func TestParseTrack_Background(ts *testing.T) {
 		{trs[2].String(), *trs[2]},
 		{trs[3].String(), *trs[3]},
 		{trs[4].String(), *trs[4]},
+		{trs[5].String(), *trs[5]},
+		{trs[6].String(), *trs[6]},
 	}
 
 	for _, tt := range tests {
@@ -218,6 +233,295 @@ func TestParseTrack_Background(ts *testing.T) {
 	}
 }
 
+func TestParseTrack_BackgroundSourceErrors(ts *testing.T) {
+	lines := []string{
+		"  background Rain amplitude 20",       // uppercase name
+		"  background 1rain amplitude 20",      // must start with a letter
+		"  background rain birds amplitude 20", // two names
+		"  background rain",                    // missing amplitude
+	}
+
+	for _, line := range lines {
+		if _, err := NewTextParser(line).ParseTrack(); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrack_BackgroundMisspelledKeyword(ts *testing.T) {
+	tests := []struct {
+		line    string
+		keyword string
+	}{
+		{"  background amplitud 20", "amplitud"},
+		{"  background spni 200 rate 5 intensity 75 amplitude 50", "spni"},
+		{"  background rain pluse 2.5 intensity 60 amplitude 40", "pluse"},
+	}
+
+	for _, tt := range tests {
+		_, err := NewTextParser(tt.line).ParseTrack()
+		if err == nil || !strings.Contains(err.Error(), "unknown keyword \""+tt.keyword+"\"") {
+			ts.Errorf("For line '%s', expected an unknown keyword %q error, got %v", tt.line, tt.keyword, err)
+		}
+	}
+}
+
+func TestParseTrack_Cue(ts *testing.T) {
+	tests := []struct {
+		line      string
+		wantTrack t.Track
+	}{
+		{
+			"  cue bell amplitude 80",
+			t.Track{Type: t.TrackCue, Source: "bell", Amplitude: t.AmplitudePercentToRaw(80)},
+		},
+		{
+			"  cue wake-up amplitude 60 pan -40 route rear",
+			t.Track{Type: t.TrackCue, Source: "wake-up", Amplitude: t.AmplitudePercentToRaw(60), Pan: t.PanPercentToRaw(-40), Route: t.RouteRear},
+		},
+	}
+
+	for _, tt := range tests {
+		tr, err := NewTextParser(tt.line).ParseTrack()
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", tt.line, err)
+			continue
+		}
+		if *tr != tt.wantTrack {
+			ts.Errorf("For line '%s', expected track %+v but got %+v", tt.line, tt.wantTrack, *tr)
+		}
+		again, err := NewTextParser("  " + tr.String()).ParseTrack()
+		if err != nil || *again != tt.wantTrack {
+			ts.Errorf("expected %q to round trip, got %+v (%v)", tr.String(), again, err)
+		}
+	}
+
+	lines := []string{
+		"  cue amplitude 80",              // missing name
+		"  cue bell",                      // missing amplitude
+		"  cue Bell amplitude 80",         // uppercase name
+		"  cue bell amplitude 80 gain -3", // gain is for backgrounds
+		"  cue bell spin 200 rate 1 intensity 50 amplitude 80",
+		"  waveform square cue bell amplitude 80",
+	}
+	for _, line := range lines {
+		if _, err := NewTextParser(line).ParseTrack(); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrack_Bell(ts *testing.T) {
+	tests := []struct {
+		line      string
+		wantTrack t.Track
+	}{
+		{
+			"  bell 440 decay 3 amplitude 30",
+			t.Track{Type: t.TrackBell, Carrier: 440, Decay: 3, Amplitude: t.AmplitudePercentToRaw(30)},
+		},
+		{
+			"  bell 220 decay 8 every 30 amplitude 50 pan 20 route center",
+			t.Track{Type: t.TrackBell, Carrier: 220, Decay: 8, Interval: 30, Amplitude: t.AmplitudePercentToRaw(50), Pan: t.PanPercentToRaw(20), Route: t.RouteCenter},
+		},
+	}
+
+	for _, tt := range tests {
+		tr, err := NewTextParser(tt.line).ParseTrack()
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", tt.line, err)
+			continue
+		}
+		if *tr != tt.wantTrack {
+			ts.Errorf("For line '%s', expected track %+v but got %+v", tt.line, tt.wantTrack, *tr)
+		}
+		again, err := NewTextParser("  " + tr.String()).ParseTrack()
+		if err != nil || *again != tt.wantTrack {
+			ts.Errorf("expected %q to round trip, got %+v (%v)", tr.String(), again, err)
+		}
+	}
+
+	lines := []string{
+		"  bell 440 amplitude 30",                    // missing decay
+		"  bell decay 3 amplitude 30",                // missing frequency
+		"  bell 440 decay 3",                         // missing amplitude
+		"  bell 0 decay 3 amplitude 30",              // no frequency
+		"  bell 440 decay 0 amplitude 30",            // no decay
+		"  bell 440 decay 61 amplitude 30",           // decay too long
+		"  bell 440 decay 3 every 0.05 amplitude 30", // interval too short
+		"  bell 440 decay 3 amplitude 30 every 10",   // interval after amplitude
+		"  bell 440 decay 3 amplitude 30 gain -3",    // gain is for backgrounds
+		"  waveform square bell 440 decay 3 amplitude 30",
+	}
+	for _, line := range lines {
+		if _, err := NewTextParser(line).ParseTrack(); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrack_Partials(ts *testing.T) {
+	rolled := t.HarmonicPartials(5)
+	rolled.Rolloff = 6
+	ratios := t.RatioPartials([]float64{1, 2.76, 5.404})
+	ratios.Rolloff = 3.5
+
+	tests := []struct {
+		line      string
+		wantTrack t.Track
+	}{
+		{
+			"  tone 110 binaural 6 amplitude 20 harmonics 5 rolloff 6",
+			t.Track{Type: t.TrackBinauralBeat, Carrier: 110, Resonance: 6, Amplitude: t.AmplitudePercentToRaw(20), Partials: rolled},
+		},
+		{
+			"  tone 220 amplitude 15 chord minor7 pan -10",
+			t.Track{Type: t.TrackPureTone, Carrier: 220, Amplitude: t.AmplitudePercentToRaw(15), Partials: t.ChordPartials(t.ChordMinor7), Pan: t.PanPercentToRaw(-10)},
+		},
+		{
+			"  waveform triangle tone 180 isochronic 8 amplitude 10 ratios 1 2.76 5.404 rolloff 3.5 envelope square",
+			t.Track{
+				Type:      t.TrackIsochronicBeat,
+				Carrier:   180,
+				Resonance: 8,
+				Amplitude: t.AmplitudePercentToRaw(10),
+				Waveform:  t.WaveformTriangle,
+				Partials:  ratios,
+				Envelope:  t.Envelope{Shape: t.EnvelopeSquare, Duty: t.DutyPercentToRaw(50)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tr, err := NewTextParser(tt.line).ParseTrack()
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", tt.line, err)
+			continue
+		}
+		if *tr != tt.wantTrack {
+			ts.Errorf("For line '%s', expected track %+v but got %+v", tt.line, tt.wantTrack, *tr)
+		}
+		again, err := NewTextParser("  " + tr.String()).ParseTrack()
+		if err != nil || *again != tt.wantTrack {
+			ts.Errorf("expected %q to round trip, got %+v (%v)", tr.String(), again, err)
+		}
+	}
+
+	lines := []string{
+		"  tone 110 amplitude 20 harmonics 0",
+		"  tone 110 amplitude 20 harmonics 9",
+		"  tone 110 amplitude 20 harmonics 2.5",
+		"  tone 110 amplitude 20 chord",
+		"  tone 110 amplitude 20 chord dominant",
+		"  tone 110 amplitude 20 ratios",
+		"  tone 110 amplitude 20 ratios 1 0 2",
+		"  tone 110 amplitude 20 ratios 1 2 3 4 5 6 7 8 9",
+		"  tone 110 amplitude 20 harmonics 3 rolloff -6",
+		"  tone 110 amplitude 20 rolloff 6",
+		"  tone 110 amplitude 20 harmonics 3 chord major",
+		"  noise pink amplitude 20 harmonics 3",
+		"  bell 440 decay 3 amplitude 30 harmonics 3",
+	}
+	for _, line := range lines {
+		if _, err := NewTextParser(line).ParseTrack(); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrack_Modulated(ts *testing.T) {
+	tests := []struct {
+		line      string
+		wantTrack t.Track
+	}{
+		{
+			"  tone 200 fm 5 depth 30 amplitude 20",
+			t.Track{Type: t.TrackFMTone, Carrier: 200, Resonance: 5, Depth: 30, Amplitude: t.AmplitudePercentToRaw(20)},
+		},
+		{
+			"  waveform triangle tone 150 am 2.5 depth 75 amplitude 10 pan 20",
+			t.Track{Type: t.TrackAMTone, Carrier: 150, Resonance: 2.5, Depth: 75, Amplitude: t.AmplitudePercentToRaw(10), Waveform: t.WaveformTriangle, Pan: t.PanPercentToRaw(20)},
+		},
+	}
+
+	for _, tt := range tests {
+		tr, err := NewTextParser(tt.line).ParseTrack()
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", tt.line, err)
+			continue
+		}
+		if *tr != tt.wantTrack {
+			ts.Errorf("For line '%s', expected track %+v but got %+v", tt.line, tt.wantTrack, *tr)
+		}
+		again, err := NewTextParser("  " + tr.String()).ParseTrack()
+		if err != nil || *again != tt.wantTrack {
+			ts.Errorf("expected %q to round trip, got %+v (%v)", tr.String(), again, err)
+		}
+	}
+
+	lines := []string{
+		"  tone 200 fm 5 amplitude 20",
+		"  tone 200 fm 5 depth amplitude 20",
+		"  tone 200 fm depth 30 amplitude 20",
+		"  tone 200 fm 5 depth 250 amplitude 20",
+		"  tone 200 fm 5 depth -1 amplitude 20",
+		"  tone 200 am 5 depth 101 amplitude 20",
+		"  tone 200 am 5 depth 50 amplitude 20 harmonics 3",
+		"  tone 200 am 5 depth 50 amplitude 20 envelope square",
+	}
+	for _, line := range lines {
+		if _, err := NewTextParser(line).ParseTrack(); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrack_CustomWaveform(ts *testing.T) {
+	tests := []struct {
+		line      string
+		wantTrack t.Track
+	}{
+		{
+			"  waveform organ tone 220 binaural 6 amplitude 20",
+			t.Track{Type: t.TrackBinauralBeat, Carrier: 220, Resonance: 6, Amplitude: t.AmplitudePercentToRaw(20), Waveform: t.WaveformCustom, WaveformName: "organ"},
+		},
+		{
+			"  waveform warm-pad tone 180 fm 3 depth 20 amplitude 15",
+			t.Track{Type: t.TrackFMTone, Carrier: 180, Resonance: 3, Depth: 20, Amplitude: t.AmplitudePercentToRaw(15), Waveform: t.WaveformCustom, WaveformName: "warm-pad"},
+		},
+		{
+			"  waveform organ noise pink pulse 4 intensity 50 amplitude 30",
+			t.Track{Type: t.TrackPinkNoise, Effect: t.Effect{Type: t.EffectPulse, Intensity: t.IntensityPercentToRaw(50)}, Resonance: 4, Amplitude: t.AmplitudePercentToRaw(30), Waveform: t.WaveformCustom, WaveformName: "organ"},
+		},
+	}
+
+	for _, tt := range tests {
+		tr, err := NewTextParser(tt.line).ParseTrack()
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", tt.line, err)
+			continue
+		}
+		if *tr != tt.wantTrack {
+			ts.Errorf("For line '%s', expected track %+v but got %+v", tt.line, tt.wantTrack, *tr)
+		}
+		again, err := NewTextParser("  " + tr.String()).ParseTrack()
+		if err != nil || *again != tt.wantTrack {
+			ts.Errorf("expected %q to round trip, got %+v (%v)", tr.String(), again, err)
+		}
+	}
+
+	lines := []string{
+		"  waveform Organ tone 220 amplitude 20",
+		"  waveform tone tone 220 amplitude 20",
+		"  waveform organ tone 220 amplitude 20 waveform sine",
+	}
+	for _, line := range lines {
+		if _, err := NewTextParser(line).ParseTrack(); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
 func TestParseTrack_Errors(ts *testing.T) {
 	tests := []string{
 		"  tone 300 binaural amplitude 10",
@@ -239,3 +543,326 @@ func TestParseTrack_Errors(ts *testing.T) {
 		}
 	}
 }
+
+func TestParseTrack_Envelope(ts *testing.T) {
+	trs := []*t.Track{
+		{
+			Type:      t.TrackIsochronicBeat,
+			Carrier:   220,
+			Resonance: 8,
+			Amplitude: t.AmplitudePercentToRaw(20),
+			Envelope:  t.Envelope{Shape: t.EnvelopeRaisedCosine, Duty: t.DutyPercentToRaw(40), Attack: 5, Release: 12.5},
+		},
+		{
+			Type:      t.TrackBackground,
+			Resonance: 2.5,
+			Effect:    t.Effect{Type: t.EffectPulse, Intensity: t.IntensityPercentToRaw(60)},
+			Amplitude: t.AmplitudePercentToRaw(40),
+			Envelope:  t.Envelope{Shape: t.EnvelopeExponential, Duty: t.DutyPercentToRaw(25), Attack: 0, Release: 80},
+		},
+	}
+
+	tests := []struct {
+		line      string
+		wantTrack t.Track
+	}{
+		{trs[0].String(), *trs[0]},
+		{trs[1].String(), *trs[1]},
+		{
+			"  tone 200 isochronic 10 amplitude 15 envelope smoothstep",
+			t.Track{
+				Type:      t.TrackIsochronicBeat,
+				Carrier:   200,
+				Resonance: 10,
+				Amplitude: t.AmplitudePercentToRaw(15),
+				Envelope:  t.Envelope{Shape: t.EnvelopeSmoothstep, Duty: t.DutyPercentToRaw(50)},
+			},
+		},
+		{
+			"  tone 200 isochronic 10 amplitude 15 envelope square release 3 duty 30",
+			t.Track{
+				Type:      t.TrackIsochronicBeat,
+				Carrier:   200,
+				Resonance: 10,
+				Amplitude: t.AmplitudePercentToRaw(15),
+				Envelope:  t.Envelope{Shape: t.EnvelopeSquare, Duty: t.DutyPercentToRaw(30), Release: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := NewTextParser(tt.line)
+		tr, err := ctx.ParseTrack()
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", tt.line, err)
+			continue
+		}
+		if *tr != tt.wantTrack {
+			ts.Errorf("For line '%s', expected track %+v but got %+v", tt.line, tt.wantTrack, *tr)
+		}
+	}
+}
+
+func TestParseTrack_EnvelopeErrors(ts *testing.T) {
+	tests := []string{
+		"  tone 300 binaural 10 amplitude 10 envelope square",
+		"  background spin 200 rate 5 intensity 75 amplitude 50 envelope square",
+		"  tone 300 isochronic 10 amplitude 10 envelope",
+		"  tone 300 isochronic 10 amplitude 10 envelope triangle",
+		"  tone 300 isochronic 10 amplitude 10 envelope square duty 0",
+		"  tone 300 isochronic 10 amplitude 10 envelope square duty 120",
+		"  tone 300 isochronic 10 amplitude 10 envelope square attack -1",
+		"  tone 300 isochronic 10 amplitude 10 envelope square duty 20 duty 30",
+		"  tone 300 isochronic 10 amplitude 10 envelope square release",
+		"  tone 300 isochronic 10 amplitude 10 envelope square extra",
+	}
+
+	for _, line := range tests {
+		ctx := NewTextParser(line)
+		_, err := ctx.ParseTrack()
+		if err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrack_Pan(ts *testing.T) {
+	trs := []*t.Track{
+		{
+			Type:      t.TrackPureTone,
+			Carrier:   300,
+			Amplitude: t.AmplitudePercentToRaw(10),
+			Pan:       t.PanPercentToRaw(-30),
+		},
+		{
+			Type:      t.TrackPinkNoise,
+			Amplitude: t.AmplitudePercentToRaw(25),
+			Pan:       t.PanPercentToRaw(100),
+		},
+		{
+			Type:      t.TrackIsochronicBeat,
+			Carrier:   220,
+			Resonance: 8,
+			Amplitude: t.AmplitudePercentToRaw(20),
+			Envelope:  t.Envelope{Shape: t.EnvelopeSquare, Duty: t.DutyPercentToRaw(50)},
+			Pan:       t.PanPercentToRaw(45.5),
+		},
+		{
+			Type:      t.TrackBackground,
+			Carrier:   300,
+			Resonance: 1,
+			Effect:    t.Effect{Type: t.EffectSpin, Intensity: t.IntensityPercentToRaw(50)},
+			Amplitude: t.AmplitudePercentToRaw(40),
+			Pan:       t.PanPercentToRaw(-10),
+		},
+	}
+
+	for _, want := range trs {
+		line := want.String()
+		tr, err := NewTextParser(line).ParseTrack()
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", line, err)
+			continue
+		}
+		if *tr != *want {
+			ts.Errorf("For line '%s', expected track %+v but got %+v", line, *want, *tr)
+		}
+	}
+
+	errors := []string{
+		"  tone 300 amplitude 10 pan",                                  // missing value
+		"  tone 300 amplitude 10 pan 120",                              // out of range
+		"  noise white amplitude 10 pan -101",                          // out of range
+		"  tone 300 amplitude 10 pan 10 pan 20",                        // duplicated
+		"  tone 200 isochronic 10 amplitude 15 pan 10 envelope square", // envelope must come first
+	}
+
+	for _, line := range errors {
+		if _, err := NewTextParser(line).ParseTrack(); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrack_Gain(ts *testing.T) {
+	trs := []*t.Track{
+		{
+			Type:      t.TrackBackground,
+			Amplitude: t.AmplitudePercentToRaw(50),
+			Gain:      -6.5,
+		},
+		{
+			Type:      t.TrackBackground,
+			Amplitude: t.AmplitudePercentToRaw(30),
+			Source:    "rain",
+			Gain:      3,
+			Pan:       t.PanPercentToRaw(20),
+		},
+		{
+			Type:      t.TrackBackground,
+			Resonance: 4,
+			Effect:    t.Effect{Type: t.EffectPulse, Intensity: t.IntensityPercentToRaw(60)},
+			Amplitude: t.AmplitudePercentToRaw(40),
+			Envelope:  t.Envelope{Shape: t.EnvelopeSmoothstep, Duty: t.DutyPercentToRaw(50)},
+			Gain:      -12,
+		},
+	}
+
+	for _, want := range trs {
+		line := want.String()
+		tr, err := NewTextParser(line).ParseTrack()
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", line, err)
+			continue
+		}
+		if *tr != *want {
+			ts.Errorf("For line '%s', expected track %+v but got %+v", line, *want, *tr)
+		}
+	}
+
+	errors := []string{
+		"  background amplitude 50 gain",            // missing value
+		"  background amplitude 50 gain -100",       // out of range
+		"  background amplitude 50 gain 30",         // out of range
+		"  tone 300 amplitude 10 gain -6",           // not a background track
+		"  noise pink amplitude 10 gain -6",         // not a background track
+		"  background amplitude 50 pan 10 gain -6",  // gain must come before pan
+		"  background amplitude 50 gain -6 gain -3", // duplicated
+		"  background amplitude 50 gain -6db",       // not a number
+	}
+
+	for _, line := range errors {
+		if _, err := NewTextParser(line).ParseTrack(); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrack_Route(ts *testing.T) {
+	trs := []*t.Track{
+		{
+			Type:      t.TrackBinauralBeat,
+			Carrier:   250,
+			Resonance: 10,
+			Amplitude: t.AmplitudePercentToRaw(20),
+			Route:     t.RouteRear,
+		},
+		{
+			Type:      t.TrackPinkNoise,
+			Amplitude: t.AmplitudePercentToRaw(30),
+			Pan:       t.PanPercentToRaw(-50),
+			Route:     t.RouteAll,
+		},
+		{
+			Type:      t.TrackPureTone,
+			Carrier:   60,
+			Amplitude: t.AmplitudePercentToRaw(40),
+			Route:     t.RouteLFE,
+		},
+		{
+			Type:      t.TrackBackground,
+			Amplitude: t.AmplitudePercentToRaw(50),
+			Gain:      -3,
+			Route:     t.RouteCenter,
+		},
+	}
+
+	for _, want := range trs {
+		line := want.String()
+		tr, err := NewTextParser(line).ParseTrack()
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", line, err)
+			continue
+		}
+		if *tr != *want {
+			ts.Errorf("For line '%s', expected track %+v but got %+v", line, *want, *tr)
+		}
+	}
+
+	// Front is the default route and is not written
+	tr, err := NewTextParser("  tone 250 binaural 10 amplitude 20 route front").ParseTrack()
+	if err != nil || tr.Route != t.RouteFront || strings.Contains(tr.String(), t.KeywordRoute) {
+		ts.Errorf("expected front route, got %+v (%v)", tr, err)
+	}
+
+	errors := []string{
+		"  tone 250 binaural 10 amplitude 20 route",                // missing value
+		"  tone 250 binaural 10 amplitude 20 route side",           // unknown route
+		"  tone 250 binaural 10 amplitude 20 route rear pan 10",    // route must come after pan
+		"  tone 250 binaural 10 amplitude 20 route rear route all", // duplicated
+	}
+
+	for _, line := range errors {
+		if _, err := NewTextParser(line).ParseTrack(); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrack_NoiseEffects(ts *testing.T) {
+	trs := []*t.Track{
+		{
+			Type:      t.TrackPinkNoise,
+			Carrier:   300,
+			Resonance: 0.5,
+			Amplitude: t.AmplitudePercentToRaw(30),
+			Waveform:  t.WaveformSine,
+			Effect:    t.Effect{Type: t.EffectSpin, Intensity: t.IntensityPercentToRaw(60)},
+		},
+		{
+			Type:      t.TrackBrownNoise,
+			Resonance: 4,
+			Amplitude: t.AmplitudePercentToRaw(25),
+			Waveform:  t.WaveformTriangle,
+			Effect:    t.Effect{Type: t.EffectPulse, Intensity: t.IntensityPercentToRaw(80)},
+			Envelope:  t.Envelope{Shape: t.EnvelopeSmoothstep, Duty: t.DutyPercentToRaw(30), Attack: 10},
+		},
+	}
+
+	tests := []struct {
+		line      string
+		wantTrack t.Track
+	}{
+		{trs[0].String(), *trs[0]},
+		{trs[1].String(), *trs[1]},
+		{"  noise white spin 200 rate 1 intensity 50 amplitude 10", t.Track{
+			Type:      t.TrackWhiteNoise,
+			Carrier:   200,
+			Resonance: 1,
+			Amplitude: t.AmplitudePercentToRaw(10),
+			Effect:    t.Effect{Type: t.EffectSpin, Intensity: t.IntensityPercentToRaw(50)},
+		}},
+		{"  waveform square noise pink pulse 2 intensity 40 amplitude 15 pan 20", t.Track{
+			Type:      t.TrackPinkNoise,
+			Resonance: 2,
+			Amplitude: t.AmplitudePercentToRaw(15),
+			Waveform:  t.WaveformSquare,
+			Effect:    t.Effect{Type: t.EffectPulse, Intensity: t.IntensityPercentToRaw(40)},
+			Pan:       t.PanPercentToRaw(20),
+		}},
+	}
+
+	for _, tt := range tests {
+		tr, err := NewTextParser(tt.line).ParseTrack()
+		if err != nil {
+			ts.Errorf("For line '%s', unexpected error: %v", tt.line, err)
+			continue
+		}
+		if *tr != tt.wantTrack {
+			ts.Errorf("For line '%s', expected track %+v but got %+v", tt.line, tt.wantTrack, *tr)
+		}
+	}
+
+	errors := []string{
+		"  noise pink spin 300 intensity 50 amplitude 10",                        // missing rate
+		"  noise pink pulse 2 amplitude 10",                                      // missing intensity
+		"  noise pink spin 300 rate 1 intensity 50 amplitude 10 envelope square", // envelope needs pulse
+		"  waveform sine noise",                                                  // missing noise type
+	}
+
+	for _, line := range errors {
+		if _, err := NewTextParser(line).ParseTrack(); err == nil {
+			ts.Errorf("For line '%s', expected error but got none", line)
+		}
+	}
+}
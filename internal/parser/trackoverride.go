@@ -68,22 +68,43 @@ func (ctx *TextParser) ParseTrackOverride(preset *t.Preset) error {
 		t.KeywordBinaural,
 		t.KeywordMonaural,
 		t.KeywordIsochronic,
+		t.KeywordFM,
+		t.KeywordAM,
+		t.KeywordDepth,
 		t.KeywordSpin,
 		t.KeywordPulse,
 		t.KeywordRate,
 		t.KeywordAmplitude,
-		t.KeywordIntensity)
+		t.KeywordIntensity,
+		t.KeywordEnvelope,
+		t.KeywordDuty,
+		t.KeywordAttack,
+		t.KeywordRelease,
+		t.KeywordPan,
+		t.KeywordGain,
+		t.KeywordRoute)
 	if err != nil {
 		return fmt.Errorf(
-			"expected one of %q, %q, %q, %q, %q, %q, %q, %q: %s",
+			"expected one of %q, %q, %q, %q, %q, %q, %q, %q, %q, %q, %q, %q, %q, %q, %q, %q, %q, %q, %q: %s",
 			t.KeywordTone,
 			t.KeywordBinaural,
 			t.KeywordMonaural,
 			t.KeywordIsochronic,
+			t.KeywordFM,
+			t.KeywordAM,
+			t.KeywordDepth,
 			t.KeywordSpin,
 			t.KeywordPulse,
 			t.KeywordRate,
 			t.KeywordAmplitude,
+			t.KeywordIntensity,
+			t.KeywordEnvelope,
+			t.KeywordDuty,
+			t.KeywordAttack,
+			t.KeywordRelease,
+			t.KeywordPan,
+			t.KeywordGain,
+			t.KeywordRoute,
 			ln)
 	}
 
@@ -91,11 +112,14 @@ func (ctx *TextParser) ParseTrackOverride(preset *t.Preset) error {
 	case t.KeywordTone, t.KeywordSpin:
 		track := preset.Track[idx]
 
-		if kind == t.KeywordTone && track.Type == t.TrackBackground {
-			return fmt.Errorf("background track %d cannot have a tone carrier", trackIdx)
+		if kind == t.KeywordTone && (track.Type == t.TrackBackground || track.Type == t.TrackCue) {
+			return fmt.Errorf("%s track %d cannot have a tone carrier", track.Type.String(), trackIdx)
 		}
-		if kind == t.KeywordSpin && track.Type != t.TrackBackground {
-			return fmt.Errorf("track %d must be a background track to set spin width, it is %q", trackIdx, track.Type.String())
+		if kind == t.KeywordTone && track.Effect.Type != t.EffectOff {
+			return fmt.Errorf("track %d with %q effect cannot have a tone carrier", trackIdx, track.Effect.Type.String())
+		}
+		if kind == t.KeywordSpin && !track.SupportsEffect() {
+			return fmt.Errorf("track %d must be a background or noise track to set spin width, it is %q", trackIdx, track.Type.String())
 		}
 		if kind == t.KeywordSpin && track.Effect.Type != t.EffectSpin {
 			return fmt.Errorf("spin width can only be set on track %d with spin effect, it is %q", trackIdx, track.Effect.Type.String())
@@ -107,15 +131,17 @@ func (ctx *TextParser) ParseTrackOverride(preset *t.Preset) error {
 		}
 
 		preset.Track[idx].Carrier = carrier
-	case t.KeywordBinaural, t.KeywordMonaural, t.KeywordIsochronic, t.KeywordRate, t.KeywordPulse:
+	case t.KeywordBinaural, t.KeywordMonaural, t.KeywordIsochronic, t.KeywordFM, t.KeywordAM, t.KeywordRate, t.KeywordPulse:
 		track := preset.Track[idx]
 
 		// Validate that the track type matches the keyword being set
 		if (kind == t.KeywordBinaural && track.Type != t.TrackBinauralBeat) ||
 			(kind == t.KeywordMonaural && track.Type != t.TrackMonauralBeat) ||
 			(kind == t.KeywordIsochronic && track.Type != t.TrackIsochronicBeat) ||
-			(kind == t.KeywordRate && track.Type != t.TrackBackground) ||
-			(kind == t.KeywordPulse && track.Type != t.TrackBackground) {
+			(kind == t.KeywordFM && track.Type != t.TrackFMTone) ||
+			(kind == t.KeywordAM && track.Type != t.TrackAMTone) ||
+			(kind == t.KeywordRate && !track.SupportsEffect()) ||
+			(kind == t.KeywordPulse && !track.SupportsEffect()) {
 			return fmt.Errorf("cannot change track %d type to %q, it is %q", trackIdx, kind, track.Type.String())
 		}
 
@@ -131,6 +157,17 @@ func (ctx *TextParser) ParseTrackOverride(preset *t.Preset) error {
 		}
 
 		preset.Track[idx].Resonance = resonance
+	case t.KeywordDepth:
+		if !preset.Track[idx].IsModulated() {
+			return fmt.Errorf("track %d must be an fm or am tone to set depth, it is %q", trackIdx, preset.Track[idx].Type.String())
+		}
+
+		depth, err := ctx.Line.NextFloat64Strict()
+		if err != nil {
+			return fmt.Errorf("depth: %w", err)
+		}
+
+		preset.Track[idx].Depth = depth
 	case t.KeywordAmplitude:
 		amplitude, err := ctx.Line.NextFloat64Strict()
 		if err != nil {
@@ -145,6 +182,70 @@ func (ctx *TextParser) ParseTrackOverride(preset *t.Preset) error {
 		}
 
 		preset.Track[idx].Effect.Intensity = t.IntensityPercentToRaw(intensity)
+	case t.KeywordPan:
+		pan, err := ctx.Line.NextFloat64Strict()
+		if err != nil {
+			return fmt.Errorf("pan: %w", err)
+		}
+
+		preset.Track[idx].Pan = t.PanPercentToRaw(pan)
+	case t.KeywordRoute:
+		speakers, ok := ctx.Line.NextToken()
+		if !ok {
+			return fmt.Errorf("expected route: %s", ln)
+		}
+
+		route, err := t.ParseRoute(speakers)
+		if err != nil {
+			return err
+		}
+
+		preset.Track[idx].Route = route
+	case t.KeywordGain:
+		if preset.Track[idx].Type != t.TrackBackground {
+			return fmt.Errorf("track %d must be a background track to set gain, it is %q", trackIdx, preset.Track[idx].Type.String())
+		}
+
+		gain, err := ctx.Line.NextFloat64Strict()
+		if err != nil {
+			return fmt.Errorf("gain: %w", err)
+		}
+
+		preset.Track[idx].Gain = gain
+	case t.KeywordEnvelope:
+		track := preset.Track[idx]
+		if !track.HasEnvelope() {
+			return fmt.Errorf("track %d must be an isochronic tone or a pulse effect to set an envelope, it is %q", trackIdx, track.Type.String())
+		}
+
+		shape, err := ctx.parseEnvelopeShape()
+		if err != nil {
+			return err
+		}
+
+		preset.Track[idx].Envelope.Shape = shape
+		if preset.Track[idx].Envelope.Duty == 0 {
+			preset.Track[idx].Envelope.Duty = t.DutyPercentToRaw(50)
+		}
+	case t.KeywordDuty, t.KeywordAttack, t.KeywordRelease:
+		track := preset.Track[idx]
+		if track.Envelope.Shape == t.EnvelopeDefault {
+			return fmt.Errorf("track %d has no envelope to set %q on", trackIdx, kind)
+		}
+
+		val, err := ctx.Line.NextFloat64Strict()
+		if err != nil {
+			return fmt.Errorf("%s: %w", kind, err)
+		}
+
+		switch kind {
+		case t.KeywordDuty:
+			preset.Track[idx].Envelope.Duty = t.DutyPercentToRaw(val)
+		case t.KeywordAttack:
+			preset.Track[idx].Envelope.Attack = val
+		case t.KeywordRelease:
+			preset.Track[idx].Envelope.Release = val
+		}
 	default:
 		return fmt.Errorf("unexpected keyword: %s", kind)
 	}
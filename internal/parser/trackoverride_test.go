@@ -335,3 +335,297 @@ func TestPresetInheritance_Integration(ts *testing.T) {
 		ts.Errorf("template should remain unchanged, expected carrier 300, got %v", templatePreset.Track[0].Carrier)
 	}
 }
+
+func TestParseTrackOverride_Envelope(ts *testing.T) {
+	templatePreset, err := t.NewPreset("base", true, nil)
+	if err != nil {
+		ts.Fatalf("failed to create template: %v", err)
+	}
+
+	templatePreset.Track[0] = t.Track{
+		Type:      t.TrackIsochronicBeat,
+		Carrier:   200,
+		Resonance: 10,
+		Amplitude: t.AmplitudePercentToRaw(20),
+	}
+	templatePreset.Track[1] = t.Track{
+		Type:      t.TrackIsochronicBeat,
+		Carrier:   300,
+		Resonance: 6,
+		Amplitude: t.AmplitudePercentToRaw(20),
+		Envelope:  t.Envelope{Shape: t.EnvelopeSmoothstep, Duty: t.DutyPercentToRaw(40), Attack: 5, Release: 5},
+	}
+	templatePreset.Track[2] = t.Track{
+		Type:      t.TrackBinauralBeat,
+		Carrier:   300,
+		Resonance: 10,
+		Amplitude: t.AmplitudePercentToRaw(20),
+	}
+
+	derivedPreset, err := t.NewPreset("derived", false, templatePreset)
+	if err != nil {
+		ts.Fatalf("failed to create derived preset: %v", err)
+	}
+
+	tests := []struct {
+		line string
+		idx  int
+		want t.Envelope
+	}{
+		{"  track 1 envelope raised-cosine", 0, t.Envelope{Shape: t.EnvelopeRaisedCosine, Duty: t.DutyPercentToRaw(50)}},
+		{"  track 2 envelope exponential", 1, t.Envelope{Shape: t.EnvelopeExponential, Duty: t.DutyPercentToRaw(40), Attack: 5, Release: 5}},
+		{"  track 2 duty 70", 1, t.Envelope{Shape: t.EnvelopeSmoothstep, Duty: t.DutyPercentToRaw(70), Attack: 5, Release: 5}},
+		{"  track 2 attack 15", 1, t.Envelope{Shape: t.EnvelopeSmoothstep, Duty: t.DutyPercentToRaw(40), Attack: 15, Release: 5}},
+		{"  track 2 release 20", 1, t.Envelope{Shape: t.EnvelopeSmoothstep, Duty: t.DutyPercentToRaw(40), Attack: 5, Release: 20}},
+	}
+
+	for _, tt := range tests {
+		derivedPreset.Track = templatePreset.Track
+
+		ctx := NewTextParser(tt.line)
+		if err := ctx.ParseTrackOverride(derivedPreset); err != nil {
+			ts.Errorf("For line %q, unexpected error: %v", tt.line, err)
+			continue
+		}
+		if derivedPreset.Track[tt.idx].Envelope != tt.want {
+			ts.Errorf("For line %q, expected envelope %+v, got %+v", tt.line, tt.want, derivedPreset.Track[tt.idx].Envelope)
+		}
+	}
+
+	errors := []string{
+		"  track 1 duty 30",              // no envelope on track
+		"  track 3 envelope square",      // binaural has no envelope
+		"  track 2 envelope sine",        // invalid shape
+		"  track 2 duty 0",               // invalid duty
+		"  track 2 attack -3",            // negative attack
+		"  track 2 envelope square duty", // extra token
+	}
+
+	for _, line := range errors {
+		derivedPreset.Track = templatePreset.Track
+
+		ctx := NewTextParser(line)
+		if err := ctx.ParseTrackOverride(derivedPreset); err == nil {
+			ts.Errorf("For line %q, expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrackOverride_Pan(ts *testing.T) {
+	templatePreset, err := t.NewPreset("base", true, nil)
+	if err != nil {
+		ts.Fatalf("failed to create template: %v", err)
+	}
+
+	templatePreset.Track[0] = t.Track{
+		Type:      t.TrackBinauralBeat,
+		Carrier:   200,
+		Resonance: 10,
+		Amplitude: t.AmplitudePercentToRaw(20),
+	}
+
+	derivedPreset, err := t.NewPreset("derived", false, templatePreset)
+	if err != nil {
+		ts.Fatalf("failed to create derived preset: %v", err)
+	}
+
+	derivedPreset.Track = templatePreset.Track
+	if err := NewTextParser("  track 1 pan -60").ParseTrackOverride(derivedPreset); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if derivedPreset.Track[0].Pan != t.PanPercentToRaw(-60) {
+		ts.Fatalf("expected pan -60, got %.2f", derivedPreset.Track[0].Pan.ToPercent())
+	}
+
+	for _, line := range []string{"  track 1 pan", "  track 1 pan 150", "  track 1 pan 10 20"} {
+		derivedPreset.Track = templatePreset.Track
+		if err := NewTextParser(line).ParseTrackOverride(derivedPreset); err == nil {
+			ts.Errorf("For line %q, expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrackOverride_Gain(ts *testing.T) {
+	templatePreset, err := t.NewPreset("base", true, nil)
+	if err != nil {
+		ts.Fatalf("failed to create template: %v", err)
+	}
+
+	templatePreset.Track[0] = t.Track{
+		Type:      t.TrackBackground,
+		Amplitude: t.AmplitudePercentToRaw(50),
+	}
+	templatePreset.Track[1] = t.Track{
+		Type:      t.TrackPinkNoise,
+		Amplitude: t.AmplitudePercentToRaw(20),
+	}
+
+	derivedPreset, err := t.NewPreset("derived", false, templatePreset)
+	if err != nil {
+		ts.Fatalf("failed to create derived preset: %v", err)
+	}
+
+	derivedPreset.Track = templatePreset.Track
+	if err := NewTextParser("  track 1 gain -9.5").ParseTrackOverride(derivedPreset); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if derivedPreset.Track[0].Gain != -9.5 {
+		ts.Fatalf("expected gain -9.5, got %.2f", derivedPreset.Track[0].Gain)
+	}
+
+	for _, line := range []string{"  track 1 gain", "  track 1 gain -120", "  track 1 gain 1 2", "  track 2 gain -6"} {
+		derivedPreset.Track = templatePreset.Track
+		if err := NewTextParser(line).ParseTrackOverride(derivedPreset); err == nil {
+			ts.Errorf("For line %q, expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrackOverride_Route(ts *testing.T) {
+	templatePreset, err := t.NewPreset("base", true, nil)
+	if err != nil {
+		ts.Fatalf("failed to create template: %v", err)
+	}
+
+	templatePreset.Track[0] = t.Track{
+		Type:      t.TrackBinauralBeat,
+		Carrier:   200,
+		Resonance: 10,
+		Amplitude: t.AmplitudePercentToRaw(20),
+	}
+
+	derivedPreset, err := t.NewPreset("derived", false, templatePreset)
+	if err != nil {
+		ts.Fatalf("failed to create derived preset: %v", err)
+	}
+
+	derivedPreset.Track = templatePreset.Track
+	if err := NewTextParser("  track 1 route rear").ParseTrackOverride(derivedPreset); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if derivedPreset.Track[0].Route != t.RouteRear {
+		ts.Fatalf("expected rear route, got %s", derivedPreset.Track[0].Route.String())
+	}
+
+	for _, line := range []string{"  track 1 route", "  track 1 route side", "  track 1 route rear all"} {
+		derivedPreset.Track = templatePreset.Track
+		if err := NewTextParser(line).ParseTrackOverride(derivedPreset); err == nil {
+			ts.Errorf("For line %q, expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrackOverride_NoiseEffects(ts *testing.T) {
+	templatePreset, err := t.NewPreset("base", true, nil)
+	if err != nil {
+		ts.Fatalf("failed to create template: %v", err)
+	}
+
+	templatePreset.Track[0] = t.Track{
+		Type:      t.TrackPinkNoise,
+		Carrier:   300,
+		Resonance: 0.5,
+		Amplitude: t.AmplitudePercentToRaw(30),
+		Effect:    t.Effect{Type: t.EffectSpin, Intensity: t.IntensityPercentToRaw(60)},
+	}
+	templatePreset.Track[1] = t.Track{
+		Type:      t.TrackBrownNoise,
+		Resonance: 4,
+		Amplitude: t.AmplitudePercentToRaw(20),
+		Effect:    t.Effect{Type: t.EffectPulse, Intensity: t.IntensityPercentToRaw(50)},
+	}
+	templatePreset.Track[2] = t.Track{
+		Type:      t.TrackWhiteNoise,
+		Amplitude: t.AmplitudePercentToRaw(10),
+	}
+
+	derivedPreset, err := t.NewPreset("derived", false, templatePreset)
+	if err != nil {
+		ts.Fatalf("failed to create derived preset: %v", err)
+	}
+
+	tests := []struct {
+		line  string
+		idx   int
+		check func(tr t.Track) bool
+	}{
+		{"  track 1 spin 450", 0, func(tr t.Track) bool { return tr.Carrier == 450 }},
+		{"  track 1 rate 2", 0, func(tr t.Track) bool { return tr.Resonance == 2 }},
+		{"  track 1 intensity 90", 0, func(tr t.Track) bool { return tr.Intensity == t.IntensityPercentToRaw(90) }},
+		{"  track 2 pulse 6", 1, func(tr t.Track) bool { return tr.Resonance == 6 }},
+		{"  track 2 envelope raised-cosine", 1, func(tr t.Track) bool { return tr.Envelope.Shape == t.EnvelopeRaisedCosine }},
+	}
+
+	for _, tt := range tests {
+		derivedPreset.Track = templatePreset.Track
+
+		if err := NewTextParser(tt.line).ParseTrackOverride(derivedPreset); err != nil {
+			ts.Errorf("For line %q, unexpected error: %v", tt.line, err)
+			continue
+		}
+
+		if !tt.check(derivedPreset.Track[tt.idx]) {
+			ts.Errorf("For line %q, override not applied: %+v", tt.line, derivedPreset.Track[tt.idx])
+		}
+	}
+
+	errors := []string{
+		"  track 1 tone 200",            // spinning noise has no tone carrier
+		"  track 1 pulse 3",             // spin effect cannot take pulse
+		"  track 2 rate 3",              // pulse effect cannot take rate
+		"  track 3 spin 300",            // noise without effect
+		"  track 1 envelope smoothstep", // envelope needs pulse
+	}
+
+	for _, line := range errors {
+		derivedPreset.Track = templatePreset.Track
+
+		if err := NewTextParser(line).ParseTrackOverride(derivedPreset); err == nil {
+			ts.Errorf("For line %q, expected error but got none", line)
+		}
+	}
+}
+
+func TestParseTrackOverride_Modulation(ts *testing.T) {
+	templatePreset, err := t.NewPreset("base", true, nil)
+	if err != nil {
+		ts.Fatalf("failed to create template: %v", err)
+	}
+
+	templatePreset.Track[0] = t.Track{Type: t.TrackFMTone, Carrier: 200, Resonance: 5, Depth: 20, Amplitude: t.AmplitudePercentToRaw(20)}
+	templatePreset.Track[1] = t.Track{Type: t.TrackAMTone, Carrier: 300, Resonance: 8, Depth: 50, Amplitude: t.AmplitudePercentToRaw(20)}
+	templatePreset.Track[2] = t.Track{Type: t.TrackBinauralBeat, Carrier: 200, Resonance: 10, Amplitude: t.AmplitudePercentToRaw(20)}
+
+	derivedPreset, err := t.NewPreset("derived", false, templatePreset)
+	if err != nil {
+		ts.Fatalf("failed to create derived preset: %v", err)
+	}
+
+	derivedPreset.Track = templatePreset.Track
+	for _, line := range []string{"  track 1 fm 7", "  track 1 depth 60", "  track 2 am 4", "  track 2 depth 80"} {
+		if err := NewTextParser(line).ParseTrackOverride(derivedPreset); err != nil {
+			ts.Fatalf("For line %q, unexpected error: %v", line, err)
+		}
+	}
+	if tr := derivedPreset.Track[0]; tr.Resonance != 7 || tr.Depth != 60 {
+		ts.Errorf("expected fm 7 depth 60, got %+v", tr)
+	}
+	if tr := derivedPreset.Track[1]; tr.Resonance != 4 || tr.Depth != 80 {
+		ts.Errorf("expected am 4 depth 80, got %+v", tr)
+	}
+
+	for _, line := range []string{
+		"  track 1 am 4",
+		"  track 2 fm 4",
+		"  track 3 fm 4",
+		"  track 3 depth 10",
+		"  track 1 depth 250",
+		"  track 2 depth 120",
+		"  track 1 depth",
+	} {
+		derivedPreset.Track = templatePreset.Track
+		if err := NewTextParser(line).ParseTrackOverride(derivedPreset); err == nil {
+			ts.Errorf("For line %q, expected error but got none", line)
+		}
+	}
+}
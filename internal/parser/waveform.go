@@ -0,0 +1,59 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// splitWaveformOption splits a waveform option into a user-defined waveform.
+// The name follows the last "as" keyword, before it are the amplitudes of the
+// harmonics after the harmonics keyword, or the path to a single-cycle file.
+func splitWaveformOption(tokens []string) (t.WaveformSource, error) {
+	if len(tokens) < 3 || tokens[len(tokens)-2] != t.KeywordAs {
+		return t.WaveformSource{}, fmt.Errorf("expected %q and a waveform name after the waveform definition", t.KeywordAs)
+	}
+
+	name := tokens[len(tokens)-1]
+	if err := t.ValidateWaveformName(name); err != nil {
+		return t.WaveformSource{}, err
+	}
+
+	body := tokens[:len(tokens)-2]
+	if body[0] != t.KeywordHarmonics {
+		return t.WaveformSource{Name: name, Path: strings.Join(body, " ")}, nil
+	}
+
+	if len(body) == 1 {
+		return t.WaveformSource{}, fmt.Errorf("expected harmonic amplitudes after %q", t.KeywordHarmonics)
+	}
+
+	harmonics := make([]float64, 0, len(body)-1)
+	for _, tok := range body[1:] {
+		amplitude, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return t.WaveformSource{}, fmt.Errorf("invalid harmonic amplitude: %q", tok)
+		}
+		harmonics = append(harmonics, amplitude)
+	}
+
+	waveform := t.WaveformSource{Name: name, Harmonics: harmonics}
+	if err := waveform.Validate(); err != nil {
+		return t.WaveformSource{}, err
+	}
+
+	return waveform, nil
+}
@@ -13,6 +13,7 @@ package sequence
 
 import (
 	"fmt"
+	"strconv"
 
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
 )
@@ -30,10 +31,48 @@ func ConvertToText(sequence *t.Sequence) (string, error) {
 		content += fmt.Sprintf("%s%s %d", t.KeywordOption, t.KeywordOptionSampleRate, options.SampleRate)
 		content += fmt.Sprintf("\n%s%s %d", t.KeywordOption, t.KeywordOptionVolume, options.Volume)
 
+		if options.Seed != 0 {
+			content += fmt.Sprintf("\n%s%s %d", t.KeywordOption, t.KeywordOptionSeed, options.Seed)
+		}
+
+		if options.Balance != 0 {
+			content += fmt.Sprintf("\n%s%s %.2f", t.KeywordOption, t.KeywordOptionBalance, options.Balance.ToPercent())
+		}
+
+		if options.Limiter != t.LimiterOff {
+			content += fmt.Sprintf("\n%s%s %s", t.KeywordOption, t.KeywordOptionLimiter, options.Limiter.String())
+		}
+
+		if options.Dither != t.DitherOff {
+			content += fmt.Sprintf("\n%s%s %s", t.KeywordOption, t.KeywordOptionDither, options.Dither.String())
+		}
+
+		if options.Layout != t.LayoutStereo {
+			content += fmt.Sprintf("\n%s%s %s", t.KeywordOption, t.KeywordOptionLayout, options.Layout.String())
+		}
+
 		if options.BackgroundPath != "" {
-			content += fmt.Sprintf("\n%s%s %s", t.KeywordOption, t.KeywordOptionBackground, options.BackgroundPath)
+			content += fmt.Sprintf("\n%s%s %s%s", t.KeywordOption, t.KeywordOptionBackground, options.BackgroundPath, options.BackgroundLoop.String())
+		}
+		for _, bg := range options.Backgrounds {
+			content += fmt.Sprintf("\n%s%s %s %s %s%s", t.KeywordOption, t.KeywordOptionBackground, bg.Path, t.KeywordAs, bg.Name, bg.Loop.String())
+		}
+		if options.BackgroundPath != "" || len(options.Backgrounds) > 0 {
 			content += fmt.Sprintf("\n%s%s %s", t.KeywordOption, t.KeywordOptionGainLevel, options.GainLevel.String())
 		}
+		for _, cue := range options.Cues {
+			content += fmt.Sprintf("\n%s%s %s %s %s", t.KeywordOption, t.KeywordOptionCue, cue.Path, t.KeywordAs, cue.Name)
+		}
+		for _, wf := range options.Waveforms {
+			definition := wf.Path
+			if len(wf.Harmonics) > 0 {
+				definition = t.KeywordHarmonics
+				for _, amplitude := range wf.Harmonics {
+					definition += " " + strconv.FormatFloat(amplitude, 'g', -1, 64)
+				}
+			}
+			content += fmt.Sprintf("\n%s%s %s %s %s", t.KeywordOption, t.KeywordOptionWaveform, definition, t.KeywordAs, wf.Name)
+		}
 		content += "\n"
 	}
 
@@ -50,7 +89,11 @@ func ConvertToText(sequence *t.Sequence) (string, error) {
 			}
 		}
 
-		timeline = append(timeline, fmt.Sprintf("%s %s %s", period.TimeString(), presetID, period.Transition.String()))
+		tline := fmt.Sprintf("%s %s %s", period.TimeString(), presetID, period.Transition.String())
+		if period.Glide != t.GlideLinear {
+			tline += " " + period.Glide.String()
+		}
+		timeline = append(timeline, tline)
 	}
 
 	content += "\n\n# Timeline"
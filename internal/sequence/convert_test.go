@@ -159,6 +159,205 @@ func TestConvertToText_WithBackgroundOptions(ts *testing.T) {
 	}
 }
 
+func TestConvertToText_NamedBackgrounds(ts *testing.T) {
+	period0 := t.Period{Time: 0, Transition: t.TransitionSteady}
+	period0.TrackStart[0] = t.Track{
+		Type:      t.TrackBackground,
+		Amplitude: t.AmplitudePercentToRaw(30),
+		Source:    "rain",
+	}
+
+	seq := &t.Sequence{
+		Periods: []t.Period{period0},
+		Options: &t.SequenceOptions{
+			SampleRate: 44100,
+			Volume:     100,
+			Backgrounds: []t.BackgroundSource{
+				{Name: "rain", Path: "sounds/rain.wav", Loop: t.BackgroundLoop{Start: 2, End: 40, Crossfade: 1.5}},
+				{Name: "birds", Path: "sounds/birds.wav"},
+			},
+			GainLevel: t.GainLevelLow,
+		},
+	}
+
+	result, err := ConvertToText(seq)
+	if err != nil {
+		ts.Fatalf("ConvertToText() error: %v", err)
+	}
+
+	for _, want := range []string{
+		"@background sounds/rain.wav as rain loop 2.00 40.00 crossfade 1.50",
+		"@background sounds/birds.wav as birds\n",
+		"@gainlevel low",
+		"background rain amplitude 30.00",
+	} {
+		if !strings.Contains(result, want) {
+			ts.Errorf("expected %q in output:\n%s", want, result)
+		}
+	}
+}
+
+func TestConvertToText_BackgroundGain(ts *testing.T) {
+	period0 := t.Period{Time: 0, Transition: t.TransitionSteady}
+	period0.TrackStart[0] = t.Track{
+		Type:      t.TrackBackground,
+		Amplitude: t.AmplitudePercentToRaw(40),
+		Gain:      -7.5,
+		Pan:       t.PanPercentToRaw(20),
+	}
+
+	seq := &t.Sequence{
+		Periods: []t.Period{period0},
+		Options: &t.SequenceOptions{
+			SampleRate:     44100,
+			Volume:         100,
+			BackgroundPath: "sounds/rain.wav",
+			GainLevel:      t.GainLevelDBToRaw(-4.5),
+		},
+	}
+
+	result, err := ConvertToText(seq)
+	if err != nil {
+		ts.Fatalf("ConvertToText() error: %v", err)
+	}
+
+	for _, want := range []string{
+		"@gainlevel -4.50",
+		"background amplitude 40.00 gain -7.50 pan 20.00",
+	} {
+		if !strings.Contains(result, want) {
+			ts.Errorf("expected %q in output:\n%s", want, result)
+		}
+	}
+}
+
+func TestConvertToText_SeedAndLimiter(ts *testing.T) {
+	period0 := t.Period{Time: 0, Transition: t.TransitionSteady}
+	period0.TrackStart[0] = t.Track{
+		Type:      t.TrackPinkNoise,
+		Amplitude: t.AmplitudePercentToRaw(25),
+	}
+
+	seq := &t.Sequence{
+		Periods: []t.Period{period0},
+		Options: &t.SequenceOptions{SampleRate: 44100, Volume: 100, Seed: 987, Limiter: t.LimiterSoft, Dither: t.DitherTPDF},
+	}
+
+	result, err := ConvertToText(seq)
+	if err != nil {
+		ts.Fatalf("ConvertToText() error: %v", err)
+	}
+	if !strings.Contains(result, "@seed 987") {
+		ts.Errorf("expected seed option not found")
+	}
+	if !strings.Contains(result, "@limiter soft") {
+		ts.Errorf("expected limiter option not found")
+	}
+	if !strings.Contains(result, "@dither tpdf") {
+		ts.Errorf("expected dither option not found")
+	}
+
+	seq.Options.Seed = 0
+	result, err = ConvertToText(seq)
+	if err != nil {
+		ts.Fatalf("ConvertToText() error: %v", err)
+	}
+	if strings.Contains(result, "@seed") {
+		ts.Errorf("expected no seed option for the default seed")
+	}
+
+	seq.Options.Limiter = t.LimiterOff
+	seq.Options.Dither = t.DitherOff
+	result, err = ConvertToText(seq)
+	if err != nil {
+		ts.Fatalf("ConvertToText() error: %v", err)
+	}
+	if strings.Contains(result, "@limiter") {
+		ts.Errorf("expected no limiter option when off")
+	}
+	if strings.Contains(result, "@dither") {
+		ts.Errorf("expected no dither option when off")
+	}
+}
+
+func TestConvertToText_PanAndBalance(ts *testing.T) {
+	period0 := t.Period{Time: 0, Transition: t.TransitionSteady}
+	period0.TrackStart[0] = t.Track{
+		Type:      t.TrackPureTone,
+		Carrier:   220,
+		Amplitude: t.AmplitudePercentToRaw(20),
+		Waveform:  t.WaveformSine,
+		Pan:       t.PanPercentToRaw(-40),
+	}
+	period0.TrackStart[1] = t.Track{
+		Type:      t.TrackBrownNoise,
+		Amplitude: t.AmplitudePercentToRaw(10),
+	}
+
+	seq := &t.Sequence{
+		Periods: []t.Period{period0},
+		Options: &t.SequenceOptions{SampleRate: 44100, Volume: 100, Balance: t.BalancePercentToRaw(15)},
+	}
+
+	result, err := ConvertToText(seq)
+	if err != nil {
+		ts.Fatalf("ConvertToText() error: %v", err)
+	}
+	if !strings.Contains(result, "@balance 15.00") {
+		ts.Errorf("expected balance option not found")
+	}
+	if !strings.Contains(result, "tone 220.00 amplitude 20.00 pan -40.00") {
+		ts.Errorf("expected panned tone not found")
+	}
+	if !strings.Contains(result, "noise brown amplitude 10.00\n") {
+		ts.Errorf("expected centered noise without pan")
+	}
+}
+
+func TestConvertToText_LayoutAndRoute(ts *testing.T) {
+	period0 := t.Period{Time: 0, Transition: t.TransitionSteady}
+	period0.TrackStart[0] = t.Track{
+		Type:      t.TrackPureTone,
+		Carrier:   220,
+		Amplitude: t.AmplitudePercentToRaw(20),
+		Waveform:  t.WaveformSine,
+		Pan:       t.PanPercentToRaw(-40),
+		Route:     t.RouteRear,
+	}
+	period0.TrackStart[1] = t.Track{
+		Type:      t.TrackBrownNoise,
+		Amplitude: t.AmplitudePercentToRaw(10),
+	}
+
+	seq := &t.Sequence{
+		Periods: []t.Period{period0},
+		Options: &t.SequenceOptions{SampleRate: 44100, Volume: 100, Layout: t.Layout51},
+	}
+
+	result, err := ConvertToText(seq)
+	if err != nil {
+		ts.Fatalf("ConvertToText() error: %v", err)
+	}
+	if !strings.Contains(result, "@layout 5.1") {
+		ts.Errorf("expected layout option not found")
+	}
+	if !strings.Contains(result, "tone 220.00 amplitude 20.00 pan -40.00 route rear") {
+		ts.Errorf("expected routed tone not found")
+	}
+	if !strings.Contains(result, "noise brown amplitude 10.00\n") {
+		ts.Errorf("expected noise without route")
+	}
+
+	seq.Options.Layout = t.LayoutStereo
+	result, err = ConvertToText(seq)
+	if err != nil {
+		ts.Fatalf("ConvertToText() error: %v", err)
+	}
+	if strings.Contains(result, "@layout") {
+		ts.Errorf("expected no layout option for stereo")
+	}
+}
+
 func TestConvertToText_MultipleTracksPerPeriod(ts *testing.T) {
 	var periods []t.Period
 
@@ -344,6 +543,7 @@ func TestConvertToText_DifferentTransitions(ts *testing.T) {
 	period1 := t.Period{
 		Time:       10000,
 		Transition: t.TransitionSmooth,
+		Glide:      t.GlideLogBeat,
 	}
 	period1.TrackStart[0] = t.Track{
 		Type:      t.TrackBinauralBeat,
@@ -384,8 +584,11 @@ func TestConvertToText_DifferentTransitions(ts *testing.T) {
 	if !strings.Contains(result, "steady") {
 		ts.Errorf("expected steady transition not found")
 	}
-	if !strings.Contains(result, "smooth") {
-		ts.Errorf("expected smooth transition not found")
+	if !strings.Contains(result, "tone-set-002 smooth log-beat") {
+		ts.Errorf("expected smooth transition with log-beat glide not found")
+	}
+	if strings.Contains(result, "linear") {
+		ts.Errorf("expected the linear glide to be omitted")
 	}
 	if !strings.Contains(result, "ease-out") {
 		ts.Errorf("expected ease-out transition not found")
@@ -569,3 +772,73 @@ func TestConvertToText_AllNoiseTypes(ts *testing.T) {
 		ts.Errorf("expected brown noise track not found")
 	}
 }
+
+func TestConvertToText_Cues(ts *testing.T) {
+	period0 := t.Period{Time: 0, Transition: t.TransitionSteady}
+	period0.TrackStart[0] = t.Track{
+		Type:      t.TrackCue,
+		Source:    "bell",
+		Amplitude: t.AmplitudePercentToRaw(80),
+		Pan:       t.PanPercentToRaw(-20),
+	}
+
+	seq := &t.Sequence{
+		Periods: []t.Period{period0},
+		Options: &t.SequenceOptions{
+			SampleRate: 44100,
+			Volume:     100,
+			Cues:       []t.CueSource{{Name: "bell", Path: "/sounds/bell.wav"}},
+		},
+	}
+
+	result, err := ConvertToText(seq)
+	if err != nil {
+		ts.Fatalf("ConvertToText() error: %v", err)
+	}
+	if !strings.Contains(result, "@cue /sounds/bell.wav as bell") {
+		ts.Errorf("expected cue option not found")
+	}
+	if !strings.Contains(result, "cue bell amplitude 80.00 pan -20.00") {
+		ts.Errorf("expected cue track not found")
+	}
+	if strings.Contains(result, "@gainlevel") {
+		ts.Errorf("expected no gain level without backgrounds")
+	}
+}
+
+func TestConvertToText_Waveforms(ts *testing.T) {
+	period0 := t.Period{Time: 0, Transition: t.TransitionSteady}
+	period0.TrackStart[0] = t.Track{
+		Type:         t.TrackPureTone,
+		Carrier:      220,
+		Amplitude:    t.AmplitudePercentToRaw(20),
+		Waveform:     t.WaveformCustom,
+		WaveformName: "organ",
+	}
+
+	seq := &t.Sequence{
+		Periods: []t.Period{period0},
+		Options: &t.SequenceOptions{
+			SampleRate: 44100,
+			Volume:     100,
+			Waveforms: []t.WaveformSource{
+				{Name: "organ", Harmonics: []float64{1, 0.5, 0.25}},
+				{Name: "warm", Path: "/cycles/warm.wav"},
+			},
+		},
+	}
+
+	result, err := ConvertToText(seq)
+	if err != nil {
+		ts.Fatalf("ConvertToText() error: %v", err)
+	}
+	if !strings.Contains(result, "@waveform harmonics 1 0.5 0.25 as organ") {
+		ts.Errorf("expected harmonic waveform option not found")
+	}
+	if !strings.Contains(result, "@waveform /cycles/warm.wav as warm") {
+		ts.Errorf("expected file waveform option not found")
+	}
+	if !strings.Contains(result, "waveform organ tone 220.00 amplitude 20.00") {
+		ts.Errorf("expected custom waveform track not found")
+	}
+}
@@ -113,8 +113,8 @@ func loadPresets(filename string) ([]t.Preset, error) {
 		if s.IsPresetEmpty(&p) {
 			return nil, fmt.Errorf("preset file: preset %q is empty", p.String())
 		}
-		if n := s.NumBackgroundTracks(&p); n > 1 {
-			return nil, fmt.Errorf("preset file: preset %q has %d background tracks; only one background track is allowed per preset", p.String(), n)
+		if name, ok := s.DuplicateBackgroundSource(&p); ok {
+			return nil, fmt.Errorf("preset file: preset %q has more than one track on the %s background; only one track per background source is allowed per preset", p.String(), t.BackgroundLabel(name))
 		}
 	}
 
@@ -110,17 +110,65 @@ func LoadStructuredSequence(filename string, format t.FileFormat) (*t.Sequence,
 		}
 	}
 
+	var backgrounds []t.BackgroundSource
+	for _, fb := range input.Options.Backgrounds {
+		path, err := resolveBackgroundPath(fb.Path, filepath.Dir(filename))
+		if err != nil {
+			return nil, err
+		}
+		backgrounds = append(backgrounds, t.BackgroundSource{Name: fb.Name, Path: path, Loop: parseFormatBackgroundLoop(fb.Loop)})
+	}
+
+	var cues []t.CueSource
+	for _, fc := range input.Options.Cues {
+		path, err := resolveBackgroundPath(fc.Path, filepath.Dir(filename))
+		if err != nil {
+			return nil, err
+		}
+		cues = append(cues, t.CueSource{Name: fc.Name, Path: path})
+	}
+
+	var waveforms []t.WaveformSource
+	for _, fw := range input.Options.Waveforms {
+		path := fw.Path
+		if path != "" {
+			var err error
+			if path, err = resolveBackgroundPath(path, filepath.Dir(filename)); err != nil {
+				return nil, err
+			}
+		}
+		waveforms = append(waveforms, t.WaveformSource{Name: fw.Name, Harmonics: fw.Harmonics, Path: path})
+	}
+
 	gainLevel := t.GainLevelOff
 	if input.Options.GainLevel != "" {
-		switch strings.ToLower(strings.TrimSpace(input.Options.GainLevel)) {
-		case t.KeywordOptionGainLevelHigh:
-			gainLevel = t.GainLevelHigh
-		case t.KeywordOptionGainLevelMedium:
-			gainLevel = t.GainLevelMedium
-		case t.KeywordOptionGainLevelLow:
-			gainLevel = t.GainLevelLow
-		default:
-			return nil, fmt.Errorf("invalid gain level: %s", input.Options.GainLevel)
+		var err error
+		if gainLevel, err = t.ParseGainLevel(strings.ToLower(strings.TrimSpace(input.Options.GainLevel))); err != nil {
+			return nil, err
+		}
+	}
+
+	limiter := t.LimiterOff
+	if input.Options.Limiter != "" {
+		var err error
+		if limiter, err = t.ParseLimiter(strings.ToLower(strings.TrimSpace(input.Options.Limiter))); err != nil {
+			return nil, err
+		}
+	}
+
+	dither := t.DitherOff
+	if input.Options.Dither != "" {
+		var err error
+		if dither, err = t.ParseDither(strings.ToLower(strings.TrimSpace(input.Options.Dither))); err != nil {
+			return nil, err
+		}
+	}
+
+	layout := t.LayoutStereo
+	if input.Options.Layout != "" {
+		var err error
+		if layout, err = t.ParseChannelLayout(strings.ToLower(strings.TrimSpace(input.Options.Layout))); err != nil {
+			return nil, err
 		}
 	}
 
@@ -129,7 +177,16 @@ func LoadStructuredSequence(filename string, format t.FileFormat) (*t.Sequence,
 		SampleRate:     input.Options.Samplerate,
 		Volume:         input.Options.Volume,
 		BackgroundPath: backgroundPath,
+		BackgroundLoop: parseFormatBackgroundLoop(input.Options.BackgroundLoop),
+		Backgrounds:    backgrounds,
+		Cues:           cues,
+		Waveforms:      waveforms,
 		GainLevel:      gainLevel,
+		Seed:           input.Options.Seed,
+		Balance:        t.BalancePercentToRaw(input.Options.Balance),
+		Limiter:        limiter,
+		Dither:         dither,
+		Layout:         layout,
 	}
 
 	if err := options.Validate(); err != nil {
@@ -147,7 +204,11 @@ func LoadStructuredSequence(filename string, format t.FileFormat) (*t.Sequence,
 		if idx >= 1 && seq.Time <= input.Sequence[idx-1].Time {
 			return nil, fmt.Errorf("timeline %d time must be greater than previous timeline time", idx+1)
 		}
-		if len(seq.Track.Tones)+len(seq.Track.Noises) > t.NumberOfChannels {
+		numBackgrounds := len(seq.Track.Backgrounds)
+		if backgroundPath != "" {
+			numBackgrounds++
+		}
+		if len(seq.Track.Tones)+len(seq.Track.Noises)+numBackgrounds+len(seq.Track.Cues)+len(seq.Track.Bells) > t.NumberOfChannels {
 			return nil, fmt.Errorf("too many elements defined (max %d)", t.NumberOfChannels)
 		}
 
@@ -164,32 +225,48 @@ func LoadStructuredSequence(filename string, format t.FileFormat) (*t.Sequence,
 				mode = t.TrackMonauralBeat
 			case t.KeywordIsochronic:
 				mode = t.TrackIsochronicBeat
+			case t.KeywordFM:
+				mode = t.TrackFMTone
+			case t.KeywordAM:
+				mode = t.TrackAMTone
 			case t.KeywordPure:
 				mode = t.TrackPureTone
 			default:
 				return nil, fmt.Errorf("invalid tone mode: %s", tone.Mode)
 			}
 
-			var waveForm t.WaveformType
-			switch tone.Waveform {
-			case t.KeywordSine:
-				waveForm = t.WaveformSine
-			case t.KeywordSquare:
-				waveForm = t.WaveformSquare
-			case t.KeywordTriangle:
-				waveForm = t.WaveformTriangle
-			case t.KeywordSawtooth:
-				waveForm = t.WaveformSawtooth
-			default:
-				return nil, fmt.Errorf("invalid waveform type: %s", tone.Waveform)
+			waveForm, waveformName, err := parseFormatWaveform("", tone.Waveform, options)
+			if err != nil {
+				return nil, err
+			}
+
+			envelope, err := parseFormatEnvelope(tone.Envelope)
+			if err != nil {
+				return nil, err
+			}
+
+			partials, err := parseFormatPartials(tone.Partials)
+			if err != nil {
+				return nil, err
+			}
+
+			route, err := parseFormatRoute(tone.Route)
+			if err != nil {
+				return nil, err
 			}
 
 			tr := t.Track{
-				Type:      mode,
-				Carrier:   tone.Carrier,
-				Resonance: tone.Resonance,
-				Amplitude: t.AmplitudePercentToRaw(tone.Amplitude),
-				Waveform:  waveForm,
+				Type:         mode,
+				Carrier:      tone.Carrier,
+				Resonance:    tone.Resonance,
+				Depth:        tone.Depth,
+				Amplitude:    t.AmplitudePercentToRaw(tone.Amplitude),
+				Waveform:     waveForm,
+				WaveformName: waveformName,
+				Envelope:     envelope,
+				Partials:     partials,
+				Pan:          t.PanPercentToRaw(tone.Pan),
+				Route:        route,
 			}
 
 			if err := tr.Validate(); err != nil {
@@ -213,9 +290,31 @@ func LoadStructuredSequence(filename string, format t.FileFormat) (*t.Sequence,
 				return nil, fmt.Errorf("invalid noise mode: %s", noise.Mode)
 			}
 
+			// Waveform only shapes the effect oscillator, sine when omitted
+			waveForm, waveformName := t.WaveformSine, ""
+			if noise.Waveform != "" {
+				var err error
+				if waveForm, waveformName, err = parseFormatWaveform("noise ", noise.Waveform, options); err != nil {
+					return nil, err
+				}
+			}
+
+			route, err := parseFormatRoute(noise.Route)
+			if err != nil {
+				return nil, err
+			}
+
 			tr := t.Track{
-				Type:      mode,
-				Amplitude: t.AmplitudePercentToRaw(noise.Amplitude),
+				Type:         mode,
+				Amplitude:    t.AmplitudePercentToRaw(noise.Amplitude),
+				Waveform:     waveForm,
+				WaveformName: waveformName,
+				Pan:          t.PanPercentToRaw(noise.Pan),
+				Route:        route,
+			}
+
+			if err := applyFormatEffect(&tr, noise.Effect); err != nil {
+				return nil, err
 			}
 
 			if err := tr.Validate(); err != nil {
@@ -231,69 +330,67 @@ func LoadStructuredSequence(filename string, format t.FileFormat) (*t.Sequence,
 				return nil, fmt.Errorf("background audio defined but no background settings found in timeline %d", idx+1)
 			}
 
-			var waveForm t.WaveformType
-			switch seq.Track.Background.Waveform {
-			case t.KeywordSine:
-				waveForm = t.WaveformSine
-			case t.KeywordSquare:
-				waveForm = t.WaveformSquare
-			case t.KeywordTriangle:
-				waveForm = t.WaveformTriangle
-			case t.KeywordSawtooth:
-				waveForm = t.WaveformSawtooth
-			default:
-				return nil, fmt.Errorf("invalid background waveform type: %s", seq.Track.Background.Waveform)
+			bgTrack, err := parseFormatBackground(seq.Track.Background, "", options)
+			if err != nil {
+				return nil, err
 			}
 
-			effect := t.Effect{Type: t.EffectOff, Intensity: 0.0}
-			if seq.Track.Background.Effect != nil {
-				effect.Intensity = t.IntensityPercentToRaw(seq.Track.Background.Effect.Intensity)
+			tracks[trackIdx] = bgTrack
+			trackIdx++
+		}
 
-				if seq.Track.Background.Effect.Spin != nil {
-					effect.Type = t.EffectSpin
-				} else if seq.Track.Background.Effect.Pulse != nil {
-					effect.Type = t.EffectPulse
-				} else {
-					return nil, fmt.Errorf("invalid background effect type")
-				}
+		usedSources := make(map[string]bool)
+		for _, fb := range seq.Track.Backgrounds {
+			if fb.Source == "" {
+				return nil, fmt.Errorf("background in timeline %d has no source name", idx+1)
 			}
-
-			var bgTrack t.Track
-			switch effect.Type {
-			case t.EffectSpin:
-				bgTrack = t.Track{
-					Type:      t.TrackBackground,
-					Carrier:   seq.Track.Background.Effect.Spin.Width,
-					Resonance: seq.Track.Background.Effect.Spin.Rate,
-					Amplitude: t.AmplitudePercentToRaw(seq.Track.Background.Amplitude),
-					Waveform:  waveForm,
-					Effect:    effect,
-				}
-			case t.EffectPulse:
-				bgTrack = t.Track{
-					Type:      t.TrackBackground,
-					Resonance: seq.Track.Background.Effect.Pulse.Resonance,
-					Amplitude: t.AmplitudePercentToRaw(seq.Track.Background.Amplitude),
-					Waveform:  waveForm,
-					Effect:    effect,
-				}
-			default:
-				bgTrack = t.Track{
-					Type:      t.TrackBackground,
-					Amplitude: t.AmplitudePercentToRaw(seq.Track.Background.Amplitude),
-					Waveform:  waveForm,
-					Effect:    effect,
-				}
+			if !options.HasBackground(fb.Source) {
+				return nil, fmt.Errorf("background %q in timeline %d is not declared in options", fb.Source, idx+1)
+			}
+			if usedSources[fb.Source] {
+				return nil, fmt.Errorf("background %q is used more than once in timeline %d", fb.Source, idx+1)
 			}
+			usedSources[fb.Source] = true
 
-			if err := bgTrack.Validate(); err != nil {
-				return nil, fmt.Errorf("%v", err)
+			bgTrack, err := parseFormatBackground(&fb, fb.Source, options)
+			if err != nil {
+				return nil, err
 			}
 
 			tracks[trackIdx] = bgTrack
 			trackIdx++
 		}
 
+		for _, fc := range seq.Track.Cues {
+			if !options.HasCue(fc.Source) {
+				return nil, fmt.Errorf("cue %q in timeline %d is not declared in options", fc.Source, idx+1)
+			}
+
+			cueTrack, err := parseFormatCue(&fc)
+			if err != nil {
+				return nil, err
+			}
+
+			tracks[trackIdx] = cueTrack
+			trackIdx++
+		}
+
+		for _, fb := range seq.Track.Bells {
+			bellTrack, err := parseFormatBell(&fb)
+			if err != nil {
+				return nil, err
+			}
+
+			tracks[trackIdx] = bellTrack
+			trackIdx++
+		}
+
+		for _, tr := range tracks {
+			if err := options.ValidateRoute(tr.Route); err != nil {
+				return nil, fmt.Errorf("timeline %d: %v", idx+1, err)
+			}
+		}
+
 		var transition t.TransitionType
 		switch seq.Transition {
 		case t.KeywordTransitionSteady:
@@ -308,12 +405,22 @@ func LoadStructuredSequence(filename string, format t.FileFormat) (*t.Sequence,
 			return nil, fmt.Errorf("invalid transition type: %s", seq.Transition)
 		}
 
+		glide := t.GlideLinear
+		if seq.Glide != "" {
+			g, err := t.ParseGlide(seq.Glide)
+			if err != nil {
+				return nil, err
+			}
+			glide = g
+		}
+
 		// Process Period
 		period := t.Period{
 			Time:       seq.Time,
 			TrackStart: tracks,
 			TrackEnd:   tracks,
 			Transition: transition,
+			Glide:      glide,
 		}
 		// Adjust previous period end if needed
 		var lastPeriod *t.Period
@@ -19,6 +19,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -75,7 +76,7 @@ func hasTrackIn(tracks [t.NumberOfChannels]t.Track, want t.Track) bool {
 func TestLoadStructured_JSON_Standalone(ts *testing.T) {
 	json := `{
   "description": ["Standalone structured test"],
-  "options": { "samplerate": 44100, "volume": 100 },
+  "options": { "samplerate": 44100, "volume": 100, "seed": 31337 },
   "sequence": [
     {
       "time": 0,
@@ -110,7 +111,7 @@ func TestLoadStructured_JSON_Standalone(ts *testing.T) {
 		ts.Fatalf("LoadStructuredSequence(json) error: %v", err)
 	}
 
-	if res.Options.SampleRate != 44100 || res.Options.Volume != 100 {
+	if res.Options.SampleRate != 44100 || res.Options.Volume != 100 || res.Options.Seed != 31337 {
 		ts.Fatalf("unexpected options: %+v", *res.Options)
 	}
 	if len(res.Comments) == 0 {
@@ -1008,3 +1009,677 @@ sequence:
 		ts.Fatalf("missing expected high intensity background in period[2]")
 	}
 }
+
+func TestLoadStructured_JSON_Envelope(ts *testing.T) {
+	json := `{
+  "description": ["Envelope test"],
+  "options": { "samplerate": 44100, "volume": 100, "background": "sounds/pink-noise.wav" },
+  "sequence": [
+    {
+      "time": 0,
+      "transition": "steady",
+      "track": {
+        "tones": [
+          {
+            "mode": "isochronic", "carrier": 200, "resonance": 10, "amplitude": 20, "waveform": "sine",
+            "envelope": { "shape": "raised-cosine", "duty": 40, "attack": 5, "release": 10 }
+          }
+        ],
+        "background": {
+          "amplitude": 30,
+          "waveform": "sine",
+          "effect": {
+            "intensity": 50,
+            "pulse": { "resonance": 4, "envelope": { "shape": "exponential" } }
+          }
+        }
+      }
+    },
+    {
+      "time": 15000,
+      "transition": "steady",
+      "track": {
+        "tones": [
+          {
+            "mode": "isochronic", "carrier": 200, "resonance": 10, "amplitude": 20, "waveform": "sine",
+            "envelope": { "shape": "raised-cosine", "duty": 40, "attack": 5, "release": 10 }
+          }
+        ],
+        "background": {
+          "amplitude": 30,
+          "waveform": "sine",
+          "effect": {
+            "intensity": 50,
+            "pulse": { "resonance": 4, "envelope": { "shape": "exponential" } }
+          }
+        }
+      }
+    }
+  ]
+}`
+	p := writeTemp(ts, "envelope.json", json)
+
+	res, err := LoadStructuredSequence(p, t.FormatJSON)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(json with envelope) error: %v", err)
+	}
+
+	var tone, bg *t.Track
+	for i := range res.Periods[0].TrackStart {
+		tr := &res.Periods[0].TrackStart[i]
+		switch tr.Type {
+		case t.TrackIsochronicBeat:
+			tone = tr
+		case t.TrackBackground:
+			bg = tr
+		}
+	}
+	if tone == nil || bg == nil {
+		ts.Fatalf("missing isochronic or background track in period[0]")
+	}
+
+	wantTone := t.Envelope{Shape: t.EnvelopeRaisedCosine, Duty: t.DutyPercentToRaw(40), Attack: 5, Release: 10}
+	if tone.Envelope != wantTone {
+		ts.Fatalf("unexpected tone envelope: %+v", tone.Envelope)
+	}
+	wantBg := t.Envelope{Shape: t.EnvelopeExponential, Duty: t.DutyPercentToRaw(50)}
+	if bg.Envelope != wantBg {
+		ts.Fatalf("unexpected background envelope: %+v", bg.Envelope)
+	}
+
+	// Invalid shape is rejected
+	bad := strings.Replace(json, `"exponential"`, `"triangle"`, 1)
+	if _, err := LoadStructuredSequence(writeTemp(ts, "bad.json", bad), t.FormatJSON); err == nil {
+		ts.Fatalf("expected error for invalid envelope shape")
+	}
+}
+
+func TestLoadStructured_YAML_PanAndBalance(ts *testing.T) {
+	yaml := `description:
+  - Pan and balance test
+options:
+  samplerate: 44100
+  volume: 100
+  balance: -20
+  limiter: soft
+  dither: shaped
+sequence:
+  - time: 0
+    transition: steady
+    track:
+      tones:
+        - mode: pure
+          carrier: 300
+          amplitude: 10
+          waveform: sine
+          pan: -75
+      noises:
+        - mode: pink
+          amplitude: 20
+          pan: 40
+  - time: 10000
+    transition: steady
+    track:
+      tones:
+        - mode: pure
+          carrier: 300
+          amplitude: 10
+          waveform: sine
+          pan: 75
+      noises:
+        - mode: pink
+          amplitude: 20
+`
+	p := writeTemp(ts, "pan.yaml", yaml)
+
+	res, err := LoadStructuredSequence(p, t.FormatYAML)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(yaml with pan) error: %v", err)
+	}
+
+	if res.Options.Balance != t.BalancePercentToRaw(-20) {
+		ts.Fatalf("expected balance -20, got %.2f", res.Options.Balance.ToPercent())
+	}
+	if res.Options.Limiter != t.LimiterSoft {
+		ts.Fatalf("expected soft limiter, got %s", res.Options.Limiter.String())
+	}
+
+	bad := strings.Replace(yaml, "limiter: soft", "limiter: brickwall", 1)
+	if _, err := LoadStructuredSequence(writeTemp(ts, "bad-limiter.yaml", bad), t.FormatYAML); err == nil {
+		ts.Fatalf("expected error for an invalid limiter")
+	}
+	if res.Options.Dither != t.DitherShaped {
+		ts.Fatalf("expected shaped dither, got %s", res.Options.Dither.String())
+	}
+	bad = strings.Replace(yaml, "dither: shaped", "dither: rectangular", 1)
+	if _, err := LoadStructuredSequence(writeTemp(ts, "bad-dither.yaml", bad), t.FormatYAML); err == nil {
+		ts.Fatalf("expected error for an invalid dither")
+	}
+
+	p0, p1 := res.Periods[0], res.Periods[1]
+	if p0.TrackStart[0].Pan != t.PanPercentToRaw(-75) || p0.TrackStart[1].Pan != t.PanPercentToRaw(40) {
+		ts.Fatalf("unexpected pan in period[0]: %+v / %+v", p0.TrackStart[0], p0.TrackStart[1])
+	}
+	if p1.TrackStart[0].Pan != t.PanPercentToRaw(75) || p1.TrackStart[1].Pan != 0 {
+		ts.Fatalf("unexpected pan in period[1]: %+v / %+v", p1.TrackStart[0], p1.TrackStart[1])
+	}
+}
+
+func TestLoadStructured_JSON_NoiseEffects(ts *testing.T) {
+	entry := func(time int, rate float64) string {
+		return fmt.Sprintf(`{
+      "time": %d,
+      "transition": "steady",
+      "track": {
+        "noises": [
+          { "mode": "pink", "amplitude": 30, "effect": { "intensity": 60, "spin": { "width": 300, "rate": %g } } },
+          { "mode": "brown", "amplitude": 20, "waveform": "triangle", "effect": { "intensity": 50, "pulse": { "resonance": 3 } } }
+        ]
+      }
+    }`, time, rate)
+	}
+	json := fmt.Sprintf(`{
+  "description": ["Noise effects test"],
+  "options": { "samplerate": 44100, "volume": 100 },
+  "sequence": [%s, %s]
+}`, entry(0, 0.5), entry(20000, 1))
+	p := writeTemp(ts, "noise-effects.json", json)
+
+	res, err := LoadStructuredSequence(p, t.FormatJSON)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(json with noise effects) error: %v", err)
+	}
+
+	spin := res.Periods[0].TrackStart[0]
+	if spin.Type != t.TrackPinkNoise || spin.Effect.Type != t.EffectSpin || spin.Carrier != 300 || spin.Resonance != 0.5 ||
+		spin.Intensity != t.IntensityPercentToRaw(60) || res.Periods[0].TrackEnd[0].Resonance != 1 {
+		ts.Fatalf("unexpected spin noise: %+v", spin)
+	}
+	pulse := res.Periods[0].TrackStart[1]
+	if pulse.Type != t.TrackBrownNoise || pulse.Effect.Type != t.EffectPulse || pulse.Resonance != 3 || pulse.Waveform != t.WaveformTriangle {
+		ts.Fatalf("unexpected pulse noise: %+v", pulse)
+	}
+
+	// An effect object without spin or pulse is rejected
+	bad := strings.Replace(json, `"spin": { "width": 300, "rate": 0.5 }`, `"other": {}`, 1)
+	if _, err := LoadStructuredSequence(writeTemp(ts, "bad.json", bad), t.FormatJSON); err == nil {
+		ts.Fatalf("expected error for noise effect without type")
+	}
+}
+
+func TestLoadStructured_JSON_NamedBackgrounds(ts *testing.T) {
+	entry := func(time int, amplitude float64) string {
+		return fmt.Sprintf(`{
+      "time": %d,
+      "transition": "steady",
+      "track": {
+        "backgrounds": [
+          { "source": "rain", "amplitude": %g, "waveform": "sine" },
+          { "source": "birds", "amplitude": 20, "waveform": "sine", "effect": { "intensity": 40, "pulse": { "resonance": 2 } } }
+        ]
+      }
+    }`, time, amplitude)
+	}
+	json := fmt.Sprintf(`{
+  "description": ["Named backgrounds test"],
+  "options": {
+    "samplerate": 44100,
+    "volume": 100,
+    "gainlevel": "low",
+    "backgrounds": [
+      { "name": "rain", "path": "sounds/rain.wav", "loop": { "start": 2, "end": 40, "crossfade": 1.5, "offset": 5 } },
+      { "name": "birds", "path": "sounds/birds.wav" }
+    ]
+  },
+  "sequence": [%s, %s]
+}`, entry(0, 0), entry(20000, 40))
+	p := writeTemp(ts, "named-bg.json", json)
+
+	res, err := LoadStructuredSequence(p, t.FormatJSON)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(json with named backgrounds) error: %v", err)
+	}
+
+	if len(res.Options.Backgrounds) != 2 || !strings.HasSuffix(res.Options.Backgrounds[1].Path, "sounds/birds.wav") {
+		ts.Fatalf("unexpected background sources: %+v", res.Options.Backgrounds)
+	}
+	if loop := res.Options.Backgrounds[0].Loop; loop != (t.BackgroundLoop{Start: 2, End: 40, Crossfade: 1.5, Offset: 5}) {
+		ts.Fatalf("unexpected rain loop settings: %+v", loop)
+	}
+	rain, birds := res.Periods[0].TrackEnd[0], res.Periods[0].TrackStart[1]
+	if rain.Source != "rain" || rain.Amplitude != t.AmplitudePercentToRaw(40) {
+		ts.Fatalf("unexpected rain track: %+v", rain)
+	}
+	if birds.Source != "birds" || birds.Effect.Type != t.EffectPulse || birds.Resonance != 2 {
+		ts.Fatalf("unexpected birds track: %+v", birds)
+	}
+
+	// A track referencing an undeclared source is rejected
+	bad := strings.Replace(json, `"source": "birds"`, `"source": "wind"`, 1)
+	if _, err := LoadStructuredSequence(writeTemp(ts, "bad.json", bad), t.FormatJSON); err == nil {
+		ts.Fatalf("expected error for undeclared background source")
+	}
+
+	// Invalid loop settings are rejected
+	bad = strings.Replace(json, `"crossfade": 1.5`, `"crossfade": 30`, 1)
+	if _, err := LoadStructuredSequence(writeTemp(ts, "bad-loop.json", bad), t.FormatJSON); err == nil {
+		ts.Fatalf("expected error for a crossfade longer than half the loop")
+	}
+}
+
+func TestLoadStructured_JSON_BackgroundGain(ts *testing.T) {
+	entry := func(time int, gain float64) string {
+		return fmt.Sprintf(`{
+      "time": %d,
+      "transition": "steady",
+      "track": {
+        "background": { "amplitude": 40, "waveform": "sine", "gain": %g }
+      }
+    }`, time, gain)
+	}
+	json := fmt.Sprintf(`{
+  "description": ["Background gain test"],
+  "options": {
+    "samplerate": 44100,
+    "volume": 100,
+    "background": "sounds/rain.wav",
+    "gainlevel": "-4.5"
+  },
+  "sequence": [%s, %s]
+}`, entry(0, -12), entry(20000, 0))
+	p := writeTemp(ts, "bg-gain.json", json)
+
+	res, err := LoadStructuredSequence(p, t.FormatJSON)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(json with background gain) error: %v", err)
+	}
+
+	if res.Options.GainLevel != t.GainLevelDBToRaw(-4.5) {
+		ts.Fatalf("expected gain level -4.5 dB, got %.2f dB", res.Options.GainLevel.ToDB())
+	}
+	if res.Periods[0].TrackStart[0].Gain != -12 || res.Periods[0].TrackEnd[0].Gain != 0 {
+		ts.Fatalf("unexpected gain slide: %+v -> %+v", res.Periods[0].TrackStart[0], res.Periods[0].TrackEnd[0])
+	}
+
+	for name, bad := range map[string]string{
+		"gain level": strings.Replace(json, `"-4.5"`, `"loud"`, 1),
+		"track gain": strings.Replace(json, `"gain": -12`, `"gain": -200`, 1),
+	} {
+		if _, err := LoadStructuredSequence(writeTemp(ts, "bad-gain.json", bad), t.FormatJSON); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadStructured_JSON_LayoutRoutes(ts *testing.T) {
+	json := `{
+  "description": ["Layout test"],
+  "options": {
+    "samplerate": 44100,
+    "volume": 100,
+    "layout": "quad"
+  },
+  "sequence": [
+    {
+      "time": 0,
+      "transition": "steady",
+      "track": {
+        "tones": [{ "mode": "binaural", "carrier": 250, "resonance": 10, "amplitude": 20, "waveform": "sine" }],
+        "noises": [{ "mode": "pink", "amplitude": 20, "route": "rear" }]
+      }
+    },
+    {
+      "time": 20000,
+      "transition": "steady",
+      "track": {
+        "tones": [{ "mode": "binaural", "carrier": 250, "resonance": 10, "amplitude": 20, "waveform": "sine" }],
+        "noises": [{ "mode": "pink", "amplitude": 20, "route": "rear" }]
+      }
+    }
+  ]
+}`
+	p := writeTemp(ts, "layout.json", json)
+
+	res, err := LoadStructuredSequence(p, t.FormatJSON)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(json with layout) error: %v", err)
+	}
+
+	if res.Options.Layout != t.LayoutQuad {
+		ts.Fatalf("expected quad layout, got %s", res.Options.Layout.String())
+	}
+	if res.Periods[0].TrackStart[0].Route != t.RouteFront || res.Periods[0].TrackStart[1].Route != t.RouteRear {
+		ts.Fatalf("unexpected routes: %+v / %+v", res.Periods[0].TrackStart[0], res.Periods[0].TrackStart[1])
+	}
+
+	for name, bad := range map[string]string{
+		"layout":          strings.Replace(json, `"quad"`, `"7.1"`, 1),
+		"route":           strings.Replace(json, `"rear"`, `"side"`, 1),
+		"route in layout": strings.Replace(json, `"rear"`, `"lfe"`, 1),
+	} {
+		if _, err := LoadStructuredSequence(writeTemp(ts, "bad-layout.json", bad), t.FormatJSON); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadStructured_JSON_Cues(ts *testing.T) {
+	json := `{
+  "description": ["Cue test"],
+  "options": {
+    "samplerate": 44100,
+    "volume": 100,
+    "cues": [{ "name": "bell", "path": "sounds/bell.wav" }]
+  },
+  "sequence": [
+    {
+      "time": 0,
+      "transition": "steady",
+      "track": {
+        "tones": [{ "mode": "binaural", "carrier": 250, "resonance": 10, "amplitude": 20, "waveform": "sine" }]
+      }
+    },
+    {
+      "time": 20000,
+      "transition": "steady",
+      "track": {
+        "tones": [{ "mode": "binaural", "carrier": 250, "resonance": 10, "amplitude": 20, "waveform": "sine" }],
+        "cues": [{ "source": "bell", "amplitude": 70, "pan": 25 }]
+      }
+    },
+    {
+      "time": 30000,
+      "transition": "steady",
+      "track": {
+        "tones": [{ "mode": "binaural", "carrier": 250, "resonance": 10, "amplitude": 20, "waveform": "sine" }]
+      }
+    }
+  ]
+}`
+	p := writeTemp(ts, "cues.json", json)
+
+	res, err := LoadStructuredSequence(p, t.FormatJSON)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(json with cues) error: %v", err)
+	}
+
+	if len(res.Options.Cues) != 1 || res.Options.Cues[0].Path != filepath.Join(filepath.Dir(p), "sounds", "bell.wav") {
+		ts.Fatalf("unexpected cues: %+v", res.Options.Cues)
+	}
+	want := t.Track{Type: t.TrackCue, Source: "bell", Amplitude: t.AmplitudePercentToRaw(70), Pan: t.PanPercentToRaw(25)}
+	if got := res.Periods[1].TrackStart[1]; got != want {
+		ts.Fatalf("expected cue track %+v, got %+v", want, got)
+	}
+
+	for name, bad := range map[string]string{
+		"undeclared cue": strings.Replace(json, `"source": "bell"`, `"source": "gong"`, 1),
+		"cue name":       strings.Replace(json, `"name": "bell"`, `"name": "Bell"`, 1),
+		"route":          strings.Replace(json, `"pan": 25`, `"route": "rear"`, 1),
+	} {
+		if _, err := LoadStructuredSequence(writeTemp(ts, "bad-cues.json", bad), t.FormatJSON); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadStructured_JSON_Waveforms(ts *testing.T) {
+	json := `{
+  "description": ["Waveform test"],
+  "options": {
+    "samplerate": 44100,
+    "volume": 100,
+    "waveforms": [
+      { "name": "organ", "harmonics": [1, 0.5, 0.25] },
+      { "name": "warm", "path": "cycles/warm.wav" }
+    ]
+  },
+  "sequence": [
+    {
+      "time": 0,
+      "transition": "steady",
+      "track": {
+        "tones": [{ "mode": "binaural", "carrier": 250, "resonance": 10, "amplitude": 20, "waveform": "organ" }],
+        "noises": [{ "mode": "pink", "amplitude": 10, "waveform": "warm", "effect": { "intensity": 40, "pulse": { "resonance": 2 } } }]
+      }
+    },
+    {
+      "time": 30000,
+      "transition": "steady",
+      "track": {
+        "tones": [{ "mode": "binaural", "carrier": 250, "resonance": 10, "amplitude": 20, "waveform": "organ" }],
+        "noises": [{ "mode": "pink", "amplitude": 10, "waveform": "warm", "effect": { "intensity": 40, "pulse": { "resonance": 2 } } }]
+      }
+    }
+  ]
+}`
+	p := writeTemp(ts, "waveforms.json", json)
+
+	res, err := LoadStructuredSequence(p, t.FormatJSON)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(json with waveforms) error: %v", err)
+	}
+
+	expected := []t.WaveformSource{
+		{Name: "organ", Harmonics: []float64{1, 0.5, 0.25}},
+		{Name: "warm", Path: filepath.Join(filepath.Dir(p), "cycles", "warm.wav")},
+	}
+	if !reflect.DeepEqual(res.Options.Waveforms, expected) {
+		ts.Fatalf("expected waveforms %+v, got %+v", expected, res.Options.Waveforms)
+	}
+	if tr := res.Periods[0].TrackStart[0]; tr.Waveform != t.WaveformCustom || tr.WaveformName != "organ" {
+		ts.Fatalf("unexpected organ track: %+v", tr)
+	}
+	if tr := res.Periods[0].TrackStart[1]; tr.Waveform != t.WaveformCustom || tr.WaveformName != "warm" {
+		ts.Fatalf("unexpected warm track: %+v", tr)
+	}
+
+	for name, bad := range map[string]string{
+		"undeclared waveform": strings.Replace(json, `"waveform": "organ"`, `"waveform": "flute"`, 1),
+		"harmonics and path":  strings.Replace(json, `"path": "cycles/warm.wav"`, `"path": "cycles/warm.wav", "harmonics": [1]`, 1),
+		"reserved name":       strings.Replace(json, `"name": "organ"`, `"name": "sine"`, 1),
+	} {
+		if _, err := LoadStructuredSequence(writeTemp(ts, "bad-waveforms.json", bad), t.FormatJSON); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadStructured_JSON_Bells(ts *testing.T) {
+	json := `{
+  "description": ["Bell test"],
+  "options": {
+    "samplerate": 44100,
+    "volume": 100
+  },
+  "sequence": [
+    {
+      "time": 0,
+      "transition": "steady",
+      "track": {
+        "tones": [{ "mode": "binaural", "carrier": 250, "resonance": 10, "amplitude": 20, "waveform": "sine" }],
+        "bells": [{ "frequency": 440, "decay": 3, "interval": 30, "amplitude": 30, "pan": -20 }]
+      }
+    },
+    {
+      "time": 60000,
+      "transition": "steady",
+      "track": {
+        "tones": [{ "mode": "binaural", "carrier": 250, "resonance": 10, "amplitude": 20, "waveform": "sine" }]
+      }
+    }
+  ]
+}`
+	res, err := LoadStructuredSequence(writeTemp(ts, "bells.json", json), t.FormatJSON)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(json with bells) error: %v", err)
+	}
+
+	want := t.Track{Type: t.TrackBell, Carrier: 440, Decay: 3, Interval: 30, Amplitude: t.AmplitudePercentToRaw(30), Pan: t.PanPercentToRaw(-20)}
+	if got := res.Periods[0].TrackStart[1]; got != want {
+		ts.Fatalf("expected bell track %+v, got %+v", want, got)
+	}
+
+	for name, bad := range map[string]string{
+		"no decay":       strings.Replace(json, `"decay": 3, `, "", 1),
+		"short interval": strings.Replace(json, `"interval": 30`, `"interval": 0.01`, 1),
+		"route":          strings.Replace(json, `"pan": -20`, `"route": "rear"`, 1),
+	} {
+		if _, err := LoadStructuredSequence(writeTemp(ts, "bad-bells.json", bad), t.FormatJSON); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadStructured_JSON_Partials(ts *testing.T) {
+	json := `{
+  "description": ["Partials test"],
+  "options": {
+    "samplerate": 44100,
+    "volume": 100
+  },
+  "sequence": [
+    {
+      "time": 0,
+      "transition": "steady",
+      "track": {
+        "tones": [
+          { "mode": "binaural", "carrier": 110, "resonance": 6, "amplitude": 20, "waveform": "sine", "partials": { "harmonics": 4, "rolloff": 6 } },
+          { "mode": "pure", "carrier": 220, "amplitude": 10, "waveform": "sine", "partials": { "chord": "major" } }
+        ]
+      }
+    },
+    {
+      "time": 60000,
+      "transition": "steady",
+      "track": {
+        "tones": [
+          { "mode": "binaural", "carrier": 110, "resonance": 6, "amplitude": 20, "waveform": "sine", "partials": { "harmonics": 4 } },
+          { "mode": "pure", "carrier": 220, "amplitude": 10, "waveform": "sine", "partials": { "chord": "major" } }
+        ]
+      }
+    }
+  ]
+}`
+	res, err := LoadStructuredSequence(writeTemp(ts, "partials.json", json), t.FormatJSON)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(json with partials) error: %v", err)
+	}
+
+	harmonics := t.HarmonicPartials(4)
+	harmonics.Rolloff = 6
+	if got := res.Periods[0].TrackStart[0].Partials; got != harmonics {
+		ts.Fatalf("expected partials %+v, got %+v", harmonics, got)
+	}
+	if got := res.Periods[0].TrackEnd[0].Partials.Rolloff; got != 0 {
+		ts.Fatalf("expected the rolloff to slide to 0, got %.2f", got)
+	}
+	if got := res.Periods[0].TrackStart[1].Partials; got != t.ChordPartials(t.ChordMajor) {
+		ts.Fatalf("expected a major chord, got %+v", got)
+	}
+
+	for name, bad := range map[string]string{
+		"two kinds": strings.Replace(json, `"chord": "major" }`, `"chord": "major", "ratios": [1, 2] }`, 1),
+		"no kind":   strings.Replace(json, `"harmonics": 4, "rolloff": 6`, `"rolloff": 6`, 1),
+		"chord":     strings.Replace(json, `"chord": "major" }`, `"chord": "diminished" }`, 1),
+		"change chord": strings.Replace(json, `"chord": "major" } }
+        ]
+      }
+    }
+  ]`, `"chord": "minor" } }
+        ]
+      }
+    }
+  ]`, 1),
+	} {
+		if _, err := LoadStructuredSequence(writeTemp(ts, "bad-partials.json", bad), t.FormatJSON); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadStructured_YAML_Modulated(ts *testing.T) {
+	yaml := `description:
+  - Modulated tones
+options:
+  samplerate: 44100
+  volume: 100
+sequence:
+  - time: 0
+    transition: steady
+    track:
+      tones:
+        - mode: fm
+          carrier: 200
+          resonance: 5
+          depth: 20
+          amplitude: 20
+          waveform: sine
+        - mode: am
+          carrier: 300
+          resonance: 8
+          depth: 60
+          amplitude: 10
+          waveform: triangle
+  - time: 60000
+    transition: steady
+    track:
+      tones:
+        - mode: fm
+          carrier: 200
+          resonance: 5
+          depth: 80
+          amplitude: 20
+          waveform: sine
+        - mode: am
+          carrier: 300
+          resonance: 8
+          depth: 60
+          amplitude: 10
+          waveform: triangle
+`
+	res, err := LoadStructuredSequence(writeTemp(ts, "modulated.yaml", yaml), t.FormatYAML)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(yaml with modulated tones) error: %v", err)
+	}
+
+	fm := res.Periods[0].TrackStart[0]
+	if fm.Type != t.TrackFMTone || fm.Resonance != 5 || fm.Depth != 20 {
+		ts.Fatalf("unexpected fm tone: %+v", fm)
+	}
+	if got := res.Periods[0].TrackEnd[0].Depth; got != 80 {
+		ts.Fatalf("expected the fm depth to slide to 80, got %.2f", got)
+	}
+	if am := res.Periods[0].TrackStart[1]; am.Type != t.TrackAMTone || am.Depth != 60 || am.Waveform != t.WaveformTriangle {
+		ts.Fatalf("unexpected am tone: %+v", am)
+	}
+
+	bad := strings.Replace(yaml, "depth: 60", "depth: 150", 1)
+	if _, err := LoadStructuredSequence(writeTemp(ts, "bad-modulated.yaml", bad), t.FormatYAML); err == nil {
+		ts.Errorf("expected error for an am depth above 100")
+	}
+}
+
+func TestLoadStructured_JSON_Glide(ts *testing.T) {
+	json := `{
+  "description": ["Glide test"],
+  "options": { "samplerate": 44100, "volume": 100 },
+  "sequence": [
+    { "time": 0, "transition": "smooth", "glide": "log-carrier",
+      "track": { "tones": [ { "mode": "binaural", "carrier": 100, "resonance": 10, "amplitude": 20, "waveform": "sine" } ] } },
+    { "time": 60000, "transition": "steady",
+      "track": { "tones": [ { "mode": "binaural", "carrier": 400, "resonance": 4, "amplitude": 20, "waveform": "sine" } ] } }
+  ]
+}`
+	res, err := LoadStructuredSequence(writeTemp(ts, "glide.json", json), t.FormatJSON)
+	if err != nil {
+		ts.Fatalf("LoadStructuredSequence(json with glide) error: %v", err)
+	}
+	if res.Periods[0].Glide != t.GlideLogCarrier || res.Periods[1].Glide != t.GlideLinear {
+		ts.Fatalf("unexpected glides: %s, %s", res.Periods[0].Glide, res.Periods[1].Glide)
+	}
+
+	bad := strings.Replace(json, `"log-carrier"`, `"exponential"`, 1)
+	if _, err := LoadStructuredSequence(writeTemp(ts, "bad-glide.json", bad), t.FormatJSON); err == nil {
+		ts.Errorf("expected error for an unknown glide")
+	}
+}
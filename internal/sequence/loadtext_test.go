@@ -16,6 +16,7 @@ package sequence
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -366,3 +367,424 @@ preparation
 		ts.Fatalf("missing preparation tracks in period[1]: %+v", result.Periods[1].TrackStart)
 	}
 }
+
+func TestLoadTextSequence_NoiseEffectsWithoutBackground(ts *testing.T) {
+	seq := `
+# Presets
+spinning
+  noise pink spin 300 rate 0.5 intensity 60 amplitude 30
+  noise brown pulse 2 intensity 50 amplitude 20 envelope raised-cosine duty 40
+
+faster
+  noise pink spin 300 rate 1 intensity 60 amplitude 30
+  noise brown pulse 4 intensity 50 amplitude 20 envelope raised-cosine duty 40
+
+# Timeline
+00:00:00 spinning
+00:01:00 faster
+`
+	p := writeSeqFile(ts, seq)
+
+	res, err := LoadTextSequence(p)
+	if err != nil {
+		ts.Fatalf("LoadTextSequence error: %v", err)
+	}
+
+	// The spin rate slides between the presets
+	start, end := res.Periods[0].TrackStart[0], res.Periods[0].TrackEnd[0]
+	if start.Effect.Type != t.EffectSpin || start.Resonance != 0.5 || end.Resonance != 1 {
+		ts.Fatalf("unexpected spin transition: %+v -> %+v", start, end)
+	}
+	pulse := res.Periods[0].TrackEnd[1]
+	if pulse.Effect.Type != t.EffectPulse || pulse.Resonance != 4 || pulse.Envelope.Shape != t.EnvelopeRaisedCosine {
+		ts.Fatalf("unexpected pulse track: %+v", pulse)
+	}
+}
+
+func TestLoadTextSequence_NamedBackgrounds(ts *testing.T) {
+	seq := `
+@background testdata/noise.wav
+@background testdata/noise.wav as rain
+@background testdata/noise.wav as birds
+
+alpha
+  background amplitude 20
+  background rain amplitude 30
+  background birds spin 300 rate 0.5 intensity 40 amplitude 10
+
+beta
+  background amplitude 20
+  background rain amplitude 10
+  background birds spin 300 rate 1 intensity 40 amplitude 10
+
+00:00:00 alpha
+00:01:00 beta
+`
+	res, err := LoadTextSequence(writeSeqFile(ts, seq))
+	if err != nil {
+		ts.Fatalf("LoadTextSequence error: %v", err)
+	}
+
+	if len(res.Options.Backgrounds) != 2 || res.Options.Backgrounds[0].Name != "rain" || res.Options.Backgrounds[1].Name != "birds" {
+		ts.Fatalf("unexpected background sources: %+v", res.Options.Backgrounds)
+	}
+	if res.Periods[0].TrackStart[1].Source != "rain" || res.Periods[0].TrackEnd[1].Amplitude != t.AmplitudePercentToRaw(10) {
+		ts.Fatalf("unexpected rain track: %+v", res.Periods[0].TrackStart[1])
+	}
+	if res.Periods[0].TrackStart[2].Source != "birds" || res.Periods[0].TrackStart[2].Effect.Type != t.EffectSpin {
+		ts.Fatalf("unexpected birds track: %+v", res.Periods[0].TrackStart[2])
+	}
+}
+
+func TestLoadTextSequence_BackgroundGain(ts *testing.T) {
+	seq := `
+@background testdata/noise.wav
+@gainlevel -4.5
+
+alpha
+  background amplitude 40 gain -12
+
+beta
+  background amplitude 40
+
+00:00:00 silence
+00:00:10 alpha
+00:01:00 beta
+00:02:00 alpha
+`
+	res, err := LoadTextSequence(writeSeqFile(ts, seq))
+	if err != nil {
+		ts.Fatalf("LoadTextSequence error: %v", err)
+	}
+
+	if res.Options.GainLevel != t.GainLevelDBToRaw(-4.5) {
+		ts.Fatalf("expected gain level -4.5 dB, got %.2f dB", res.Options.GainLevel.ToDB())
+	}
+
+	// The fade-in keeps the gain, later periods slide between gains
+	if res.Periods[0].TrackStart[0].Gain != -12 || res.Periods[0].TrackEnd[0].Gain != -12 {
+		ts.Fatalf("unexpected fade-in gain: %+v -> %+v", res.Periods[0].TrackStart[0], res.Periods[0].TrackEnd[0])
+	}
+	if res.Periods[1].TrackStart[0].Gain != -12 || res.Periods[1].TrackEnd[0].Gain != 0 {
+		ts.Fatalf("unexpected gain slide: %+v -> %+v", res.Periods[1].TrackStart[0], res.Periods[1].TrackEnd[0])
+	}
+	if res.Periods[2].TrackStart[0].Gain != 0 || res.Periods[2].TrackEnd[0].Gain != -12 {
+		ts.Fatalf("unexpected gain slide: %+v -> %+v", res.Periods[2].TrackStart[0], res.Periods[2].TrackEnd[0])
+	}
+}
+
+func TestLoadTextSequence_LayoutRoutes(ts *testing.T) {
+	seq := `
+@layout 5.1
+
+base as template
+  tone 250 binaural 10 amplitude 20
+  noise pink amplitude 20 route rear
+  tone 60 amplitude 30 route lfe
+
+alpha from base
+
+beta from base
+  track 1 route center
+
+00:00:00 silence
+00:00:10 alpha
+00:01:00 alpha
+00:01:10 silence
+00:01:20 beta
+00:02:00 silence
+`
+	res, err := LoadTextSequence(writeSeqFile(ts, seq))
+	if err != nil {
+		ts.Fatalf("LoadTextSequence error: %v", err)
+	}
+
+	if res.Options.Layout != t.Layout51 {
+		ts.Fatalf("expected 5.1 layout, got %s", res.Options.Layout.String())
+	}
+
+	routes := []t.RouteType{t.RouteFront, t.RouteRear, t.RouteLFE}
+	for ch, route := range routes {
+		if got := res.Periods[1].TrackStart[ch].Route; got != route {
+			ts.Errorf("channel %d: expected route %s, got %s", ch+1, route.String(), got.String())
+		}
+	}
+	if got := res.Periods[4].TrackStart[0].Route; got != t.RouteCenter {
+		ts.Errorf("expected overridden center route, got %s", got.String())
+	}
+}
+
+func TestLoadTextSequence_Error_RouteNotInLayout(ts *testing.T) {
+	tests := map[string]string{
+		"rear in stereo": `
+alpha
+  noise pink amplitude 20 route rear
+00:00:00 alpha
+00:01:00 alpha
+`,
+		"center in quad": `
+@layout quad
+alpha
+  noise pink amplitude 20 route center
+00:00:00 alpha
+00:01:00 alpha
+`,
+		"route change": `
+@layout quad
+alpha
+  noise pink amplitude 20
+beta
+  noise pink amplitude 20 route rear
+00:00:00 alpha
+00:01:00 beta
+`,
+	}
+
+	for name, seq := range tests {
+		if _, err := LoadTextSequence(writeSeqFile(ts, seq)); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadTextSequence_Error_GainLevelOutOfRange(ts *testing.T) {
+	seq := `
+@background testdata/noise.wav
+@gainlevel -120
+alpha
+  background amplitude 20
+00:00:00 alpha
+00:01:00 alpha
+`
+	if _, err := LoadTextSequence(writeSeqFile(ts, seq)); err == nil {
+		ts.Fatalf("expected error for out of range gain level, got nil")
+	}
+}
+
+func TestLoadTextSequence_Error_NamedBackgrounds(ts *testing.T) {
+	tests := map[string]string{
+		"undeclared source": `
+@background testdata/noise.wav as rain
+alpha
+  background birds amplitude 20
+00:00:00 alpha
+00:01:00 alpha
+`,
+		"duplicate source in preset": `
+@background testdata/noise.wav as rain
+alpha
+  background rain amplitude 20
+  background rain amplitude 30
+00:00:00 alpha
+00:01:00 alpha
+`,
+		"duplicate source name": `
+@background testdata/noise.wav as rain
+@background testdata/noise.wav as rain
+alpha
+  background rain amplitude 20
+00:00:00 alpha
+00:01:00 alpha
+`,
+	}
+
+	for name, seq := range tests {
+		if _, err := LoadTextSequence(writeSeqFile(ts, seq)); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadTextSequence_Cues(ts *testing.T) {
+	seq := `
+@cue sounds/bell.wav as bell
+@cue https://example.com/wake.wav as wake
+
+calm
+  tone 200 binaural 6 amplitude 20
+
+awake
+  tone 200 binaural 10 amplitude 20
+  cue bell amplitude 80 pan -30
+
+voice
+  tone 200 binaural 10 amplitude 20
+  cue wake amplitude 60
+
+00:00:00 calm
+00:40:00 awake
+00:40:30 voice
+00:41:00 calm
+00:42:00 calm
+`
+	path := writeSeqFile(ts, seq)
+	res, err := LoadTextSequence(path)
+	if err != nil {
+		ts.Fatalf("LoadTextSequence error: %v", err)
+	}
+
+	expected := []t.CueSource{
+		{Name: "bell", Path: filepath.Join(filepath.Dir(path), "sounds", "bell.wav")},
+		{Name: "wake", Path: "https://example.com/wake.wav"},
+	}
+	if !reflect.DeepEqual(res.Options.Cues, expected) {
+		ts.Fatalf("expected cues %+v, got %+v", expected, res.Options.Cues)
+	}
+
+	// Cues start and stop without fades, and switch source directly
+	if tr := res.Periods[1].TrackStart[1]; tr.Type != t.TrackCue || tr.Source != "bell" || tr.Pan != t.PanPercentToRaw(-30) {
+		ts.Fatalf("unexpected bell track: %+v", tr)
+	}
+	if tr := res.Periods[2].TrackStart[1]; tr.Type != t.TrackCue || tr.Source != "wake" {
+		ts.Fatalf("unexpected wake track: %+v", tr)
+	}
+	if tr := res.Periods[3].TrackStart[1]; tr.Type != t.TrackOff {
+		ts.Fatalf("expected the cue channel off, got %+v", tr)
+	}
+}
+
+func TestLoadTextSequence_Error_Cues(ts *testing.T) {
+	tests := map[string]string{
+		"undeclared cue": `
+@cue bell.wav as bell
+alpha
+  cue gong amplitude 80
+00:00:00 alpha
+00:01:00 alpha
+`,
+		"duplicate cue name": `
+@cue bell.wav as bell
+@cue gong.wav as bell
+alpha
+  cue bell amplitude 80
+00:00:00 alpha
+00:01:00 alpha
+`,
+		"cue to tone": `
+@cue bell.wav as bell
+alpha
+  cue bell amplitude 80
+beta
+  tone 200 amplitude 10
+00:00:00 alpha
+00:01:00 beta
+00:02:00 beta
+`,
+	}
+
+	for name, seq := range tests {
+		if _, err := LoadTextSequence(writeSeqFile(ts, seq)); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadTextSequence_Waveforms(ts *testing.T) {
+	seq := `
+@waveform harmonics 1 0.5 0.25 as organ
+@waveform cycles/warm.wav as warm
+
+calm
+  waveform organ tone 200 binaural 6 amplitude 20
+  waveform warm noise pink spin 300 rate 0.5 intensity 40 amplitude 10
+
+deep
+  waveform organ tone 150 binaural 4 amplitude 20
+  waveform warm noise pink spin 300 rate 0.2 intensity 40 amplitude 10
+
+00:00:00 calm
+00:10:00 deep
+00:20:00 deep
+`
+	path := writeSeqFile(ts, seq)
+	res, err := LoadTextSequence(path)
+	if err != nil {
+		ts.Fatalf("LoadTextSequence error: %v", err)
+	}
+
+	expected := []t.WaveformSource{
+		{Name: "organ", Harmonics: []float64{1, 0.5, 0.25}},
+		{Name: "warm", Path: filepath.Join(filepath.Dir(path), "cycles", "warm.wav")},
+	}
+	if !reflect.DeepEqual(res.Options.Waveforms, expected) {
+		ts.Fatalf("expected waveforms %+v, got %+v", expected, res.Options.Waveforms)
+	}
+
+	if tr := res.Periods[1].TrackStart[0]; tr.Waveform != t.WaveformCustom || tr.WaveformName != "organ" {
+		ts.Fatalf("unexpected organ track: %+v", tr)
+	}
+	if tr := res.Periods[1].TrackStart[1]; tr.Waveform != t.WaveformCustom || tr.WaveformName != "warm" {
+		ts.Fatalf("unexpected warm track: %+v", tr)
+	}
+}
+
+func TestLoadTextSequence_Error_Waveforms(ts *testing.T) {
+	tests := map[string]string{
+		"undeclared waveform": `
+@waveform harmonics 1 0.5 as organ
+alpha
+  waveform flute tone 200 amplitude 10
+00:00:00 alpha
+00:01:00 alpha
+`,
+		"duplicate waveform name": `
+@waveform harmonics 1 0.5 as organ
+@waveform harmonics 1 0.2 as organ
+alpha
+  waveform organ tone 200 amplitude 10
+00:00:00 alpha
+00:01:00 alpha
+`,
+		"waveform change": `
+@waveform harmonics 1 0.5 as organ
+@waveform harmonics 1 0 0.3 as flute
+alpha
+  waveform organ tone 200 amplitude 10
+beta
+  waveform flute tone 200 amplitude 10
+00:00:00 alpha
+00:01:00 beta
+00:02:00 beta
+`,
+	}
+
+	for name, seq := range tests {
+		if _, err := LoadTextSequence(writeSeqFile(ts, seq)); err == nil {
+			ts.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestLoadTextSequence_Bells(ts *testing.T) {
+	seq := `
+calm
+  tone 200 binaural 6 amplitude 20
+  bell 440 decay 4 every 60 amplitude 25
+
+awake
+  tone 200 binaural 10 amplitude 20
+  bell 660 decay 2 amplitude 40 pan -30
+
+00:00:00 calm
+00:10:00 awake
+00:11:00 calm
+`
+	res, err := LoadTextSequence(writeSeqFile(ts, seq))
+	if err != nil {
+		ts.Fatalf("LoadTextSequence error: %v", err)
+	}
+
+	// Bells switch settings directly
+	want := t.Track{Type: t.TrackBell, Carrier: 440, Decay: 4, Interval: 60, Amplitude: t.AmplitudePercentToRaw(25)}
+	if tr := res.Periods[0].TrackStart[1]; tr != want {
+		ts.Fatalf("expected bell track %+v, got %+v", want, tr)
+	}
+	if tr := res.Periods[0].TrackEnd[1]; tr.Carrier != 660 || tr.Interval != 0 {
+		ts.Fatalf("expected the next bell at the end of the period, got %+v", tr)
+	}
+
+	bad := strings.Replace(seq, "  bell 660 decay 2 amplitude 40 pan -30", "  noise pink amplitude 40", 1)
+	if _, err := LoadTextSequence(writeSeqFile(ts, bad)); err == nil || !strings.Contains(err.Error(), "cannot change track type") {
+		ts.Errorf("expected error when changing a bell to noise directly, got %v", err)
+	}
+}
@@ -153,10 +153,21 @@ func LoadTextSequence(rawContent []byte) (*t.Sequence, error) {
 				return nil, fmt.Errorf("line %d: %v", lnn, err)
 			}
 
-			if track.Type == t.TrackBackground && options.BackgroundPath == "" {
+			if track.Type == t.TrackBackground && !options.HasBackground(track.Source) {
+				if track.Source != "" {
+					return nil, fmt.Errorf("line %d: background %q is not declared in options", lnn, track.Source)
+				}
 				return nil, fmt.Errorf("line %d: background track defined but no background audio file specified in options", lnn)
 			}
 
+			if track.Type == t.TrackCue && !options.HasCue(track.Source) {
+				return nil, fmt.Errorf("line %d: cue %q is not declared in options", lnn, track.Source)
+			}
+
+			if track.Waveform == t.WaveformCustom && !options.HasWaveform(track.WaveformName) {
+				return nil, fmt.Errorf("line %d: waveform %q is not declared in options", lnn, track.WaveformName)
+			}
+
 			lastPreset.Track[trackIndex] = *track
 			continue
 		}
@@ -227,6 +238,8 @@ func LoadTextSequence(rawContent []byte) (*t.Sequence, error) {
 			tok == t.KeywordTone ||
 			tok == t.KeywordNoise ||
 			tok == t.KeywordBackground ||
+			tok == t.KeywordCue ||
+			tok == t.KeywordBell ||
 			tok == t.KeywordTrack {
 			return nil, fmt.Errorf("line %d: expected two-space indentation for elements under preset definition\n   %s", lnn, ctx.Line.Raw)
 		}
@@ -245,8 +258,8 @@ func LoadTextSequence(rawContent []byte) (*t.Sequence, error) {
 		if s.IsPresetEmpty(p) {
 			return nil, fmt.Errorf("preset %q is empty", presets[i].String())
 		}
-		if n := s.NumBackgroundTracks(p); n > 1 {
-			return nil, fmt.Errorf("preset %q has %d background tracks; only one background track is allowed per preset", presets[i].String(), n)
+		if name, ok := s.DuplicateBackgroundSource(p); ok {
+			return nil, fmt.Errorf("preset %q has more than one track on the %s background; only one track per background source is allowed per preset", presets[i].String(), t.BackgroundLabel(name))
 		}
 	}
 
@@ -255,6 +268,24 @@ func LoadTextSequence(rawContent []byte) (*t.Sequence, error) {
 		return nil, fmt.Errorf("at least two periods must be defined")
 	}
 
+	// Validate that every track in use has its background source, cue, waveform and speakers declared
+	for _, period := range periods {
+		for _, track := range period.TrackStart {
+			if track.Type == t.TrackBackground && !options.HasBackground(track.Source) {
+				return nil, fmt.Errorf("timeline %s uses the %s background which is not declared in options", period.TimeString(), t.BackgroundLabel(track.Source))
+			}
+			if track.Type == t.TrackCue && !options.HasCue(track.Source) {
+				return nil, fmt.Errorf("timeline %s uses the %q cue which is not declared in options", period.TimeString(), track.Source)
+			}
+			if track.Waveform == t.WaveformCustom && !options.HasWaveform(track.WaveformName) {
+				return nil, fmt.Errorf("timeline %s uses the %q waveform which is not declared in options", period.TimeString(), track.WaveformName)
+			}
+			if err := options.ValidateRoute(track.Route); err != nil {
+				return nil, fmt.Errorf("timeline %s: %v", period.TimeString(), err)
+			}
+		}
+	}
+
 	return &t.Sequence{
 		Periods:    periods,
 		Options:    options,
@@ -0,0 +1,232 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package sequence
+
+import (
+	"fmt"
+	"strings"
+
+	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
+)
+
+// parseFormatEnvelope converts a structured envelope into a track envelope
+func parseFormatEnvelope(fe *t.FormatEnvelope) (t.Envelope, error) {
+	if fe == nil {
+		return t.Envelope{Shape: t.EnvelopeDefault}, nil
+	}
+
+	var shape t.EnvelopeShape
+	switch fe.Shape {
+	case t.KeywordSquare:
+		shape = t.EnvelopeSquare
+	case t.KeywordSmoothstep:
+		shape = t.EnvelopeSmoothstep
+	case t.KeywordRaisedCosine:
+		shape = t.EnvelopeRaisedCosine
+	case t.KeywordExponential:
+		shape = t.EnvelopeExponential
+	default:
+		return t.Envelope{}, fmt.Errorf("invalid envelope shape: %s", fe.Shape)
+	}
+
+	// Omitted duty cycle defaults to 50%
+	duty := fe.Duty
+	if duty == 0 {
+		duty = 50
+	}
+
+	return t.Envelope{
+		Shape:   shape,
+		Duty:    t.DutyPercentToRaw(duty),
+		Attack:  fe.Attack,
+		Release: fe.Release,
+	}, nil
+}
+
+// parseFormatPartials converts structured partials into tone partials
+func parseFormatPartials(fp *t.FormatPartials) (t.Partials, error) {
+	if fp == nil {
+		return t.Partials{}, nil
+	}
+
+	var (
+		partials t.Partials
+		kinds    int
+	)
+	if fp.Harmonics != 0 {
+		partials = t.HarmonicPartials(fp.Harmonics)
+		kinds++
+	}
+	if fp.Chord != "" {
+		chord, err := t.ParseChord(strings.ToLower(strings.TrimSpace(fp.Chord)))
+		if err != nil {
+			return t.Partials{}, err
+		}
+		partials = t.ChordPartials(chord)
+		kinds++
+	}
+	if len(fp.Ratios) > 0 {
+		partials = t.RatioPartials(fp.Ratios)
+		kinds++
+	}
+	if kinds != 1 {
+		return t.Partials{}, fmt.Errorf("partials must have one of harmonics, chord or ratios")
+	}
+
+	partials.Rolloff = fp.Rolloff
+	return partials, nil
+}
+
+// applyFormatEffect applies a structured spin or pulse effect to a background or noise track
+func applyFormatEffect(tr *t.Track, fe *t.FormatEffect) error {
+	if fe == nil {
+		return nil
+	}
+
+	tr.Effect.Intensity = t.IntensityPercentToRaw(fe.Intensity)
+
+	switch {
+	case fe.Spin != nil:
+		tr.Effect.Type = t.EffectSpin
+		tr.Carrier = fe.Spin.Width
+		tr.Resonance = fe.Spin.Rate
+	case fe.Pulse != nil:
+		envelope, err := parseFormatEnvelope(fe.Pulse.Envelope)
+		if err != nil {
+			return err
+		}
+
+		tr.Effect.Type = t.EffectPulse
+		tr.Resonance = fe.Pulse.Resonance
+		tr.Envelope = envelope
+	default:
+		return fmt.Errorf("invalid %s effect type", tr.Type.String())
+	}
+
+	return nil
+}
+
+// parseFormatBackgroundLoop converts background loop settings, zero when omitted
+func parseFormatBackgroundLoop(fl *t.FormatBackgroundLoop) t.BackgroundLoop {
+	if fl == nil {
+		return t.BackgroundLoop{}
+	}
+	return t.BackgroundLoop{
+		Start:     fl.Start,
+		End:       fl.End,
+		Crossfade: fl.Crossfade,
+		Offset:    fl.Offset,
+	}
+}
+
+// parseFormatRoute converts a structured route, front speakers when omitted
+func parseFormatRoute(route string) (t.RouteType, error) {
+	if route == "" {
+		return t.RouteFront, nil
+	}
+	return t.ParseRoute(strings.ToLower(strings.TrimSpace(route)))
+}
+
+// parseFormatWaveform converts a structured waveform, a built-in one or a
+// user-defined one declared in the options
+func parseFormatWaveform(kind, name string, options *t.SequenceOptions) (t.WaveformType, string, error) {
+	waveForm, waveformName, err := t.ParseWaveform(name)
+	if err != nil {
+		return t.WaveformSine, "", fmt.Errorf("invalid %swaveform type: %s", kind, name)
+	}
+	if waveForm == t.WaveformCustom && !options.HasWaveform(waveformName) {
+		return t.WaveformSine, "", fmt.Errorf("%swaveform %q is not declared in options", kind, waveformName)
+	}
+	return waveForm, waveformName, nil
+}
+
+// parseFormatBackground converts a structured background into a background track
+func parseFormatBackground(fb *t.FormatBackground, source string, options *t.SequenceOptions) (t.Track, error) {
+	waveForm, waveformName, err := parseFormatWaveform("background ", fb.Waveform, options)
+	if err != nil {
+		return t.Track{}, err
+	}
+
+	route, err := parseFormatRoute(fb.Route)
+	if err != nil {
+		return t.Track{}, err
+	}
+
+	bgTrack := t.Track{
+		Type:         t.TrackBackground,
+		Amplitude:    t.AmplitudePercentToRaw(fb.Amplitude),
+		Waveform:     waveForm,
+		WaveformName: waveformName,
+		Pan:          t.PanPercentToRaw(fb.Pan),
+		Source:       source,
+		Gain:         fb.Gain,
+		Route:        route,
+	}
+
+	if err := applyFormatEffect(&bgTrack, fb.Effect); err != nil {
+		return t.Track{}, err
+	}
+
+	if err := bgTrack.Validate(); err != nil {
+		return t.Track{}, fmt.Errorf("%v", err)
+	}
+
+	return bgTrack, nil
+}
+
+// parseFormatCue converts a structured cue into a cue track
+func parseFormatCue(fc *t.FormatCue) (t.Track, error) {
+	route, err := parseFormatRoute(fc.Route)
+	if err != nil {
+		return t.Track{}, err
+	}
+
+	cueTrack := t.Track{
+		Type:      t.TrackCue,
+		Amplitude: t.AmplitudePercentToRaw(fc.Amplitude),
+		Waveform:  t.WaveformSine,
+		Pan:       t.PanPercentToRaw(fc.Pan),
+		Source:    fc.Source,
+		Route:     route,
+	}
+
+	if err := cueTrack.Validate(); err != nil {
+		return t.Track{}, fmt.Errorf("%v", err)
+	}
+
+	return cueTrack, nil
+}
+
+// parseFormatBell converts a structured bell into a bell track
+func parseFormatBell(fb *t.FormatBell) (t.Track, error) {
+	route, err := parseFormatRoute(fb.Route)
+	if err != nil {
+		return t.Track{}, err
+	}
+
+	bellTrack := t.Track{
+		Type:      t.TrackBell,
+		Amplitude: t.AmplitudePercentToRaw(fb.Amplitude),
+		Carrier:   fb.Frequency,
+		Waveform:  t.WaveformSine,
+		Pan:       t.PanPercentToRaw(fb.Pan),
+		Route:     route,
+		Decay:     fb.Decay,
+		Interval:  fb.Interval,
+	}
+
+	if err := bellTrack.Validate(); err != nil {
+		return t.Track{}, fmt.Errorf("%v", err)
+	}
+
+	return bellTrack, nil
+}
@@ -83,6 +83,8 @@ func GetFile(filePath string, typ t.FileFormat) ([]byte, error) {
 		maxSize = t.MaxStructuredFileSize
 	case t.FormatWAV:
 		maxSize = t.MaxBackgroundFileSize
+	case t.FormatAudio:
+		maxSize = t.MaxAudioFileSize
 	}
 
 	if maxSize == 0 {
@@ -312,6 +312,29 @@ func TestGetFile_HTTP_WAV(ts *testing.T) {
 	}
 }
 
+func TestGetFile_HTTP_Audio(ts *testing.T) {
+	// Past the size limit of WAV files, within the one of audio files
+	content := makeBigContent(100, int(t.MaxBackgroundFileSize+8192))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/flac")
+		_, _ = w.Write(content)
+	}))
+	defer srv.Close()
+
+	got, err := GetFile(srv.URL+"/rain.flac", t.FormatAudio)
+	if err != nil {
+		ts.Fatalf("GetFile() error: %v", err)
+	}
+
+	if !bytes.Equal(got, content) {
+		ts.Errorf("expected %d bytes, got %d", len(content), len(got))
+	}
+	if t.FormatAudio.String() != "audio" {
+		ts.Errorf("expected format name audio, got %q", t.FormatAudio.String())
+	}
+}
+
 func TestGetFile_HTTP_Truncate(ts *testing.T) {
 	const maxSize = t.MaxTextFileSize
 	bigContent := makeBigContent(100, int(maxSize+8192))
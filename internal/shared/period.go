@@ -24,8 +24,8 @@ func AdjustPeriods(last, next *t.Period) error {
 		tr1 := &last.TrackEnd[ch]
 		tr2 := &next.TrackStart[ch]
 
-		// Apply Fade-In
-		if tr0.Type == t.TrackSilence {
+		// Apply Fade-In (cues and bells play from their own period, they never fade in)
+		if tr0.Type == t.TrackSilence && !tr2.IsOneShot() {
 			tr0.Type = tr2.Type
 			tr0.Effect.Type = tr2.Effect.Type
 			tr0.Carrier = tr2.Carrier
@@ -33,6 +33,14 @@ func AdjustPeriods(last, next *t.Period) error {
 			tr0.Amplitude = 0
 			tr0.Intensity = tr2.Intensity
 			tr0.Waveform = tr2.Waveform
+			tr0.WaveformName = tr2.WaveformName
+			tr0.Envelope = tr2.Envelope
+			tr0.Pan = tr2.Pan
+			tr0.Source = tr2.Source
+			tr0.Gain = tr2.Gain
+			tr0.Route = tr2.Route
+			tr0.Partials = tr2.Partials
+			tr0.Depth = tr2.Depth
 		}
 
 		// Apply Fade-Out
@@ -40,11 +48,21 @@ func AdjustPeriods(last, next *t.Period) error {
 			tr2.Carrier = tr1.Carrier
 			tr2.Resonance = tr1.Resonance
 			tr2.Intensity = tr1.Intensity
+			tr2.Envelope = tr1.Envelope
+			tr2.Pan = tr1.Pan
+			tr2.Source = tr1.Source
+			tr2.Gain = tr1.Gain
+			tr2.Route = tr1.Route
+			tr2.Partials = tr1.Partials
+			tr2.Depth = tr1.Depth
 		}
 
+		// Cues and bells ring to their end, they can be turned off or on directly
+		oneShot := tr1.IsOneShot() || tr2.IsOneShot()
+
 		// Validate if previus period has a track on and next period turn it off or vice-versa
-		if (tr1.Type != t.TrackOff && tr1.Type != t.TrackSilence && tr2.Type == t.TrackOff) ||
-			(tr1.Type == t.TrackOff && tr2.Type != t.TrackOff && tr2.Type != t.TrackSilence) {
+		if !oneShot && ((tr1.Type != t.TrackOff && tr1.Type != t.TrackSilence && tr2.Type == t.TrackOff) ||
+			(tr1.Type == t.TrackOff && tr2.Type != t.TrackOff && tr2.Type != t.TrackSilence)) {
 			return fmt.Errorf("channel %d cannot be turned off or on directly, use silence instead: %s --> %s", ch+1, tr1.Type.String(), tr2.Type.String())
 		}
 
@@ -57,12 +75,24 @@ func AdjustPeriods(last, next *t.Period) error {
 			if tr1.Type != tr2.Type {
 				return fmt.Errorf("channel %d cannot change track type directly, use silence instead: %s --> %s", ch+1, tr1.Type.String(), tr2.Type.String())
 			}
-			if tr1.Waveform != tr2.Waveform {
-				return fmt.Errorf("channel %d cannot change waveform directly, use silence instead: %s --> %s", ch+1, tr1.Waveform.String(), tr2.Waveform.String())
+			if tr1.Waveform != tr2.Waveform || tr1.WaveformName != tr2.WaveformName {
+				return fmt.Errorf("channel %d cannot change waveform directly, use silence instead: %s --> %s", ch+1, tr1.WaveformLabel(), tr2.WaveformLabel())
 			}
 			if tr1.Effect.Type != tr2.Effect.Type {
 				return fmt.Errorf("channel %d cannot change effect type directly, use silence instead: %s --> %s", ch+1, tr1.Effect.Type.String(), tr2.Effect.Type.String())
 			}
+			if tr1.Source != tr2.Source && tr1.Type != t.TrackCue {
+				return fmt.Errorf("channel %d cannot change background source directly, use silence instead: %s --> %s", ch+1, t.BackgroundLabel(tr1.Source), t.BackgroundLabel(tr2.Source))
+			}
+			if tr1.Route != tr2.Route {
+				return fmt.Errorf("channel %d cannot change route directly, use silence instead: %s --> %s", ch+1, tr1.Route.String(), tr2.Route.String())
+			}
+			if !IsPartialSetEqual(&tr1.Partials, &tr2.Partials) {
+				return fmt.Errorf("channel %d cannot change partials directly, use silence instead: %s --> %s", ch+1, partialsLabel(&tr1.Partials), partialsLabel(&tr2.Partials))
+			}
+			if tr1.Envelope.Shape != tr2.Envelope.Shape {
+				return fmt.Errorf("channel %d cannot change envelope shape directly, use silence instead: %s --> %s", ch+1, tr1.Envelope.Shape.String(), tr2.Envelope.Shape.String())
+			}
 		}
 
 		// Carry forward the track settings from the end of the last period to the start of the next period
@@ -73,6 +103,24 @@ func AdjustPeriods(last, next *t.Period) error {
 		tr1.Amplitude = tr2.Amplitude
 		tr1.Intensity = tr2.Intensity
 		tr1.Waveform = tr2.Waveform
+		tr1.WaveformName = tr2.WaveformName
+		tr1.Envelope = tr2.Envelope
+		tr1.Pan = tr2.Pan
+		tr1.Source = tr2.Source
+		tr1.Gain = tr2.Gain
+		tr1.Route = tr2.Route
+		tr1.Decay = tr2.Decay
+		tr1.Interval = tr2.Interval
+		tr1.Partials = tr2.Partials
+		tr1.Depth = tr2.Depth
 	}
 	return nil
 }
+
+// partialsLabel returns a readable label for the partials of a tone
+func partialsLabel(p *t.Partials) string {
+	if p.Kind == t.PartialsOff {
+		return "single carrier"
+	}
+	return p.String()
+}
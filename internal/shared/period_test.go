@@ -12,6 +12,7 @@
 package shared
 
 import (
+	"strings"
 	"testing"
 
 	t "github.com/synapseq-foundation/synapseq/v3/internal/types"
@@ -155,6 +156,11 @@ func TestAdjustPeriods_Errors(ts *testing.T) {
 			tr1:  t.Track{Type: t.TrackBackground, Amplitude: t.AmplitudePercentToRaw(20), Waveform: t.WaveformSine, Effect: t.Effect{Type: t.EffectSpin}},
 			tr2:  t.Track{Type: t.TrackBackground, Amplitude: t.AmplitudePercentToRaw(25), Waveform: t.WaveformSine, Effect: t.Effect{Type: t.EffectPulse}},
 		},
+		{
+			name: "change background source while on",
+			tr1:  t.Track{Type: t.TrackBackground, Amplitude: t.AmplitudePercentToRaw(20), Waveform: t.WaveformSine, Source: "rain"},
+			tr2:  t.Track{Type: t.TrackBackground, Amplitude: t.AmplitudePercentToRaw(20), Waveform: t.WaveformSine, Source: "birds"},
+		},
 	}
 
 	for _, tc := range tests {
@@ -164,3 +170,284 @@ func TestAdjustPeriods_Errors(ts *testing.T) {
 		}
 	}
 }
+
+func TestAdjustPeriods_EnvelopeCarryAndShapeChange(ts *testing.T) {
+	iso := t.Track{
+		Type:      t.TrackIsochronicBeat,
+		Carrier:   200,
+		Resonance: 10,
+		Amplitude: t.AmplitudePercentToRaw(20),
+		Envelope:  t.Envelope{Shape: t.EnvelopeSmoothstep, Duty: t.DutyPercentToRaw(30), Attack: 2, Release: 4},
+	}
+
+	// Fade-out keeps the envelope so only the amplitude slides
+	var last, next t.Period
+	last.TrackStart[0] = iso
+	last.TrackEnd[0] = iso
+	next.TrackStart[0] = t.Track{Type: t.TrackSilence}
+
+	if err := AdjustPeriods(&last, &next); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if last.TrackEnd[0].Envelope != iso.Envelope {
+		ts.Fatalf("expected envelope carried on fade-out, got %+v", last.TrackEnd[0].Envelope)
+	}
+
+	// Changing the shape directly is rejected
+	var a, b t.Period
+	a.TrackStart[0] = iso
+	a.TrackEnd[0] = iso
+	b.TrackStart[0] = iso
+	b.TrackStart[0].Envelope.Shape = t.EnvelopeRaisedCosine
+
+	if err := AdjustPeriods(&a, &b); err == nil {
+		ts.Fatalf("expected error when changing envelope shape directly")
+	}
+}
+
+func TestAdjustPeriods_PanCarry(ts *testing.T) {
+	var last, next t.Period
+
+	last.TrackStart[0] = t.Track{Type: t.TrackSilence}
+	last.TrackEnd[0] = t.Track{Type: t.TrackSilence}
+	next.TrackStart[0] = t.Track{
+		Type:      t.TrackPinkNoise,
+		Amplitude: t.AmplitudePercentToRaw(30),
+		Pan:       t.PanPercentToRaw(-50),
+	}
+
+	if err := AdjustPeriods(&last, &next); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+
+	// Fade-in starts at the target position so only the amplitude slides
+	if last.TrackStart[0].Pan != t.PanPercentToRaw(-50) || last.TrackEnd[0].Pan != t.PanPercentToRaw(-50) {
+		ts.Fatalf("expected pan carried on fade-in, got %+v / %+v", last.TrackStart[0], last.TrackEnd[0])
+	}
+}
+
+func TestAdjustPeriods_RouteCarryAndChange(ts *testing.T) {
+	var last, next t.Period
+
+	last.TrackStart[0] = t.Track{Type: t.TrackSilence}
+	last.TrackEnd[0] = t.Track{Type: t.TrackSilence}
+	next.TrackStart[0] = t.Track{
+		Type:      t.TrackPinkNoise,
+		Amplitude: t.AmplitudePercentToRaw(30),
+		Route:     t.RouteRear,
+	}
+
+	if err := AdjustPeriods(&last, &next); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if last.TrackStart[0].Route != t.RouteRear || last.TrackEnd[0].Route != t.RouteRear {
+		ts.Fatalf("expected route carried on fade-in, got %+v / %+v", last.TrackStart[0], last.TrackEnd[0])
+	}
+
+	// The speakers cannot slide
+	var a, b t.Period
+	a.TrackStart[0] = t.Track{Type: t.TrackPinkNoise, Amplitude: t.AmplitudePercentToRaw(30)}
+	a.TrackEnd[0] = a.TrackStart[0]
+	b.TrackStart[0] = a.TrackStart[0]
+	b.TrackStart[0].Route = t.RouteCenter
+
+	if err := AdjustPeriods(&a, &b); err == nil {
+		ts.Fatalf("expected error when changing route directly")
+	}
+}
+
+func TestAdjustPeriods_Cues(ts *testing.T) {
+	bell := t.Track{Type: t.TrackCue, Source: "bell", Amplitude: t.AmplitudePercentToRaw(80)}
+	gong := t.Track{Type: t.TrackCue, Source: "gong", Amplitude: t.AmplitudePercentToRaw(60)}
+
+	// A cue does not fade in from silence
+	var last, next t.Period
+	last.TrackStart[0] = t.Track{Type: t.TrackSilence}
+	last.TrackEnd[0] = t.Track{Type: t.TrackSilence}
+	next.TrackStart[0] = bell
+
+	if err := AdjustPeriods(&last, &next); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if last.TrackStart[0].Type != t.TrackSilence {
+		ts.Fatalf("expected silence before the cue, got %+v", last.TrackStart[0])
+	}
+
+	// Cues are turned on and off directly and may change source
+	pairs := [][2]t.Track{
+		{{Type: t.TrackOff}, bell},
+		{bell, {Type: t.TrackOff}},
+		{bell, gong},
+	}
+	for _, pair := range pairs {
+		var a, b t.Period
+		a.TrackStart[0], a.TrackEnd[0] = pair[0], pair[0]
+		b.TrackStart[0] = pair[1]
+		if err := AdjustPeriods(&a, &b); err != nil {
+			ts.Errorf("%s --> %s: unexpected error: %v", pair[0].Type.String(), pair[1].Type.String(), err)
+		}
+	}
+
+	// A cue cannot become another track type
+	var a, b t.Period
+	a.TrackStart[0], a.TrackEnd[0] = bell, bell
+	b.TrackStart[0] = t.Track{Type: t.TrackPinkNoise, Amplitude: t.AmplitudePercentToRaw(30)}
+	if err := AdjustPeriods(&a, &b); err == nil {
+		ts.Fatalf("expected error when changing a cue to noise directly")
+	}
+}
+
+func TestAdjustPeriods_Bells(ts *testing.T) {
+	bell := t.Track{Type: t.TrackBell, Carrier: 440, Decay: 3, Amplitude: t.AmplitudePercentToRaw(30)}
+	chime := t.Track{Type: t.TrackBell, Carrier: 880, Decay: 1, Interval: 10, Amplitude: t.AmplitudePercentToRaw(20)}
+
+	// A bell does not fade in from silence
+	var last, next t.Period
+	last.TrackStart[0] = t.Track{Type: t.TrackSilence}
+	last.TrackEnd[0] = t.Track{Type: t.TrackSilence}
+	next.TrackStart[0] = bell
+
+	if err := AdjustPeriods(&last, &next); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if last.TrackStart[0].Type != t.TrackSilence {
+		ts.Fatalf("expected silence before the bell, got %+v", last.TrackStart[0])
+	}
+
+	// Bells are turned on and off directly and may change settings
+	pairs := [][2]t.Track{
+		{{Type: t.TrackOff}, bell},
+		{bell, {Type: t.TrackOff}},
+		{bell, chime},
+	}
+	for _, pair := range pairs {
+		var a, b t.Period
+		a.TrackStart[0], a.TrackEnd[0] = pair[0], pair[0]
+		b.TrackStart[0] = pair[1]
+		if err := AdjustPeriods(&a, &b); err != nil {
+			ts.Errorf("%s --> %s: unexpected error: %v", pair[0].Type.String(), pair[1].Type.String(), err)
+		}
+		if a.TrackEnd[0] != pair[1] {
+			ts.Errorf("expected the end of the period to carry %+v, got %+v", pair[1], a.TrackEnd[0])
+		}
+	}
+}
+
+func TestAdjustPeriods_Partials(ts *testing.T) {
+	harmonics := t.Track{Type: t.TrackBinauralBeat, Carrier: 110, Resonance: 6, Amplitude: t.AmplitudePercentToRaw(20), Partials: t.HarmonicPartials(4)}
+
+	// The rolloff slides between periods
+	rolled := harmonics
+	rolled.Partials.Rolloff = 12
+
+	var last, next t.Period
+	last.TrackStart[0], last.TrackEnd[0] = harmonics, harmonics
+	next.TrackStart[0] = rolled
+	if err := AdjustPeriods(&last, &next); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if last.TrackEnd[0].Partials.Rolloff != 12 {
+		ts.Fatalf("expected the rolloff to slide to 12, got %+v", last.TrackEnd[0].Partials)
+	}
+
+	// Partials fade in and out with their tone
+	var fadeIn, toned t.Period
+	fadeIn.TrackStart[0] = t.Track{Type: t.TrackSilence}
+	fadeIn.TrackEnd[0] = t.Track{Type: t.TrackSilence}
+	toned.TrackStart[0] = harmonics
+	if err := AdjustPeriods(&fadeIn, &toned); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if fadeIn.TrackStart[0].Partials != harmonics.Partials {
+		ts.Fatalf("expected the fade-in to carry the partials, got %+v", fadeIn.TrackStart[0].Partials)
+	}
+
+	// The partial set cannot change directly
+	for _, other := range []t.Partials{{}, t.HarmonicPartials(3), t.ChordPartials(t.ChordMajor)} {
+		chord := harmonics
+		chord.Partials = other
+
+		var a, b t.Period
+		a.TrackStart[0], a.TrackEnd[0] = harmonics, harmonics
+		b.TrackStart[0] = chord
+		if err := AdjustPeriods(&a, &b); err == nil {
+			ts.Errorf("expected error when changing partials to %+v directly", other)
+		}
+	}
+}
+
+func TestAdjustPeriods_Modulation(ts *testing.T) {
+	fm := t.Track{Type: t.TrackFMTone, Carrier: 200, Resonance: 5, Depth: 30, Amplitude: t.AmplitudePercentToRaw(20)}
+
+	// Depth fades in with the tone and slides to the next period
+	var fadeIn, toned t.Period
+	fadeIn.TrackStart[0] = t.Track{Type: t.TrackSilence}
+	fadeIn.TrackEnd[0] = t.Track{Type: t.TrackSilence}
+	toned.TrackStart[0] = fm
+	if err := AdjustPeriods(&fadeIn, &toned); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if fadeIn.TrackStart[0].Depth != 30 || fadeIn.TrackEnd[0].Depth != 30 {
+		ts.Fatalf("expected the fade-in to carry the depth, got %+v", fadeIn.TrackStart[0])
+	}
+
+	deeper := fm
+	deeper.Depth = 90
+	var last, next t.Period
+	last.TrackStart[0], last.TrackEnd[0] = fm, fm
+	next.TrackStart[0] = deeper
+	if err := AdjustPeriods(&last, &next); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if last.TrackEnd[0].Depth != 90 {
+		ts.Fatalf("expected the depth to slide to 90, got %.2f", last.TrackEnd[0].Depth)
+	}
+
+	// Depth is kept while fading out
+	var out, silent t.Period
+	out.TrackStart[0], out.TrackEnd[0] = fm, fm
+	silent.TrackStart[0] = t.Track{Type: t.TrackSilence}
+	if err := AdjustPeriods(&out, &silent); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if silent.TrackStart[0].Depth != 30 {
+		ts.Fatalf("expected the fade-out to keep the depth, got %.2f", silent.TrackStart[0].Depth)
+	}
+
+	// FM cannot turn into AM directly
+	am := fm
+	am.Type = t.TrackAMTone
+	var a, b t.Period
+	a.TrackStart[0], a.TrackEnd[0] = fm, fm
+	b.TrackStart[0] = am
+	if err := AdjustPeriods(&a, &b); err == nil {
+		ts.Fatalf("expected error when changing fm to am directly")
+	}
+}
+
+func TestAdjustPeriods_CustomWaveform(ts *testing.T) {
+	organ := t.Track{Type: t.TrackPureTone, Carrier: 200, Amplitude: t.AmplitudePercentToRaw(20), Waveform: t.WaveformCustom, WaveformName: "organ"}
+
+	// The waveform name fades in with the tone
+	var fadeIn, toned t.Period
+	fadeIn.TrackStart[0] = t.Track{Type: t.TrackSilence}
+	fadeIn.TrackEnd[0] = t.Track{Type: t.TrackSilence}
+	toned.TrackStart[0] = organ
+	if err := AdjustPeriods(&fadeIn, &toned); err != nil {
+		ts.Fatalf("unexpected error: %v", err)
+	}
+	if fadeIn.TrackStart[0].WaveformName != "organ" || fadeIn.TrackEnd[0].WaveformName != "organ" {
+		ts.Fatalf("expected the fade-in to carry the waveform name, got %+v", fadeIn.TrackStart[0])
+	}
+
+	// One user-defined waveform cannot turn into another directly
+	flute := organ
+	flute.WaveformName = "flute"
+	var a, b t.Period
+	a.TrackStart[0], a.TrackEnd[0] = organ, organ
+	b.TrackStart[0] = flute
+	err := AdjustPeriods(&a, &b)
+	if err == nil || !strings.Contains(err.Error(), "organ --> flute") {
+		ts.Fatalf("expected error when changing the waveform directly, got %v", err)
+	}
+}
@@ -47,13 +47,17 @@ func IsPresetEmpty(preset *t.Preset) bool {
 	return true
 }
 
-// NumBackgroundTracks counts the number of background tracks in the preset
-func NumBackgroundTracks(preset *t.Preset) int {
-	count := 0
+// DuplicateBackgroundSource returns the first background source used by more than one track in the preset
+func DuplicateBackgroundSource(preset *t.Preset) (string, bool) {
+	seen := make(map[string]bool)
 	for _, track := range preset.Track {
-		if track.Type == t.TrackBackground {
-			count++
+		if track.Type != t.TrackBackground {
+			continue
 		}
+		if seen[track.Source] {
+			return track.Source, true
+		}
+		seen[track.Source] = true
 	}
-	return count
+	return "", false
 }
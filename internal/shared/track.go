@@ -22,5 +22,23 @@ func IsTrackEqual(tr1, tr2 *t.Track) bool {
 		tr1.Carrier == tr2.Carrier &&
 		tr1.Resonance == tr2.Resonance &&
 		tr1.Waveform == tr2.Waveform &&
-		tr1.Intensity == tr2.Intensity
+		tr1.WaveformName == tr2.WaveformName &&
+		tr1.Intensity == tr2.Intensity &&
+		tr1.Envelope == tr2.Envelope &&
+		tr1.Pan == tr2.Pan &&
+		tr1.Source == tr2.Source &&
+		tr1.Gain == tr2.Gain &&
+		tr1.Route == tr2.Route &&
+		tr1.Decay == tr2.Decay &&
+		tr1.Interval == tr2.Interval &&
+		tr1.Partials == tr2.Partials &&
+		tr1.Depth == tr2.Depth
+}
+
+// IsPartialSetEqual checks if two tones have the same partials, whatever their rolloff
+func IsPartialSetEqual(p1, p2 *t.Partials) bool {
+	return p1.Kind == p2.Kind &&
+		p1.Chord == p2.Chord &&
+		p1.Count == p2.Count &&
+		p1.Ratios == p2.Ratios
 }
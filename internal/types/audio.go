@@ -11,6 +11,11 @@
 
 package types
 
+import (
+	"fmt"
+	"strconv"
+)
+
 const (
 	BufferSize         = 1024    // Buffer size for audio processing
 	SineTableSize      = 16384   // Number of elements in sine-table (power of 2)
@@ -18,8 +23,8 @@ const (
 	PhasePrecision     = 65536   // Phase precision (1/65536 of a cycle)
 )
 
-// Gain level (-20db, -16db, -12db, -6db, 0db) for background audio
-type GainLevel int
+// Gain level (attenuation in dB, 3 for -3dB) for background audio
+type GainLevel float64
 
 const (
 	GainLevelOff    GainLevel = 0  // No attenuation (0dB) - full background volume
@@ -28,20 +33,268 @@ const (
 	GainLevelLow    GainLevel = 18 // -18dB - subtle background
 )
 
+const (
+	MinGain = -96.0 // Lowest background gain in dB
+	MaxGain = 24.0  // Highest background gain in dB
+)
+
+const (
+	MaxBellDecay    = 60.0 // Longest bell decay in seconds
+	MinBellInterval = 0.1  // Shortest bell strike interval in seconds
+)
+
 // String returns the string representation of the GainLevel
 func (g GainLevel) String() string {
 	switch g {
+	case GainLevelOff:
+		return KeywordOff
 	case GainLevelHigh:
 		return KeywordOptionGainLevelHigh
 	case GainLevelMedium:
 		return KeywordOptionGainLevelMedium
 	case GainLevelLow:
 		return KeywordOptionGainLevelLow
+	default:
+		return fmt.Sprintf("%.2f", g.ToDB())
+	}
+}
+
+// ToDB converts a gain level to a gain in dB
+func (g GainLevel) ToDB() float64 {
+	return -float64(g)
+}
+
+// GainLevelDBToRaw converts a gain in dB to a gain level
+func GainLevelDBToRaw(v float64) GainLevel {
+	return GainLevel(-v)
+}
+
+// ParseGainLevel parses a gain level keyword or a gain in dB
+func ParseGainLevel(value string) (GainLevel, error) {
+	switch value {
+	case KeywordOff:
+		return GainLevelOff, nil
+	case KeywordOptionGainLevelHigh:
+		return GainLevelHigh, nil
+	case KeywordOptionGainLevelMedium:
+		return GainLevelMedium, nil
+	case KeywordOptionGainLevelLow:
+		return GainLevelLow, nil
+	}
+
+	db, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gain level: %q", value)
+	}
+	return GainLevelDBToRaw(db), nil
+}
+
+// LimiterType represents the limiter of the master bus
+type LimiterType int
+
+const (
+	// Output is hard clipped to full scale
+	LimiterOff LimiterType = iota
+	// Output peaks are softly compressed below full scale
+	LimiterSoft
+)
+
+// String returns the string representation of the LimiterType
+func (l LimiterType) String() string {
+	switch l {
+	case LimiterSoft:
+		return KeywordOptionLimiterSoft
+	default:
+		return KeywordOff
+	}
+}
+
+// ParseLimiter parses a limiter keyword
+func ParseLimiter(value string) (LimiterType, error) {
+	switch value {
+	case KeywordOff:
+		return LimiterOff, nil
+	case KeywordOptionLimiterSoft:
+		return LimiterSoft, nil
+	default:
+		return LimiterOff, fmt.Errorf("invalid limiter: %q", value)
+	}
+}
+
+// DitherType represents the dither of the reduction to the output bit depth
+type DitherType int
+
+const (
+	// Output is truncated to the output bit depth
+	DitherOff DitherType = iota
+	// Triangular dither is added before rounding
+	DitherTPDF
+	// Triangular dither with the requantization noise shaped towards high frequencies
+	DitherShaped
+)
+
+// String returns the string representation of the DitherType
+func (d DitherType) String() string {
+	switch d {
+	case DitherTPDF:
+		return KeywordOptionDitherTPDF
+	case DitherShaped:
+		return KeywordOptionDitherShaped
 	default:
 		return KeywordOff
 	}
 }
 
+// ParseDither parses a dither keyword
+func ParseDither(value string) (DitherType, error) {
+	switch value {
+	case KeywordOff:
+		return DitherOff, nil
+	case KeywordOptionDitherTPDF:
+		return DitherTPDF, nil
+	case KeywordOptionDitherShaped:
+		return DitherShaped, nil
+	default:
+		return DitherOff, fmt.Errorf("invalid dither: %q", value)
+	}
+}
+
+// ChannelLayout represents the speaker layout of the output
+type ChannelLayout int
+
+const (
+	// Front left and right speakers
+	LayoutStereo ChannelLayout = iota
+	// Front and rear left and right speakers
+	LayoutQuad
+	// Front left and right, center, low frequency and rear left and right speakers
+	Layout51
+)
+
+// String returns the string representation of the ChannelLayout
+func (l ChannelLayout) String() string {
+	switch l {
+	case LayoutQuad:
+		return KeywordOptionLayoutQuad
+	case Layout51:
+		return KeywordOptionLayout51
+	default:
+		return KeywordOptionLayoutStereo
+	}
+}
+
+// Channels returns the number of output channels of the layout
+func (l ChannelLayout) Channels() int {
+	switch l {
+	case LayoutQuad:
+		return 4
+	case Layout51:
+		return 6
+	default:
+		return 2
+	}
+}
+
+// Mask returns the WAVE_FORMAT_EXTENSIBLE speaker mask of the layout,
+// the output channels are interleaved in the order of the mask bits
+func (l ChannelLayout) Mask() uint32 {
+	const (
+		frontLeft    = 0x1
+		frontRight   = 0x2
+		frontCenter  = 0x4
+		lowFrequency = 0x8
+		backLeft     = 0x10
+		backRight    = 0x20
+	)
+
+	switch l {
+	case LayoutQuad:
+		return frontLeft | frontRight | backLeft | backRight
+	case Layout51:
+		return frontLeft | frontRight | frontCenter | lowFrequency | backLeft | backRight
+	default:
+		return frontLeft | frontRight
+	}
+}
+
+// Supports checks if the layout has the speakers of a route
+func (l ChannelLayout) Supports(route RouteType) bool {
+	switch route {
+	case RouteFront:
+		return true
+	case RouteRear, RouteAll:
+		return l == LayoutQuad || l == Layout51
+	case RouteCenter, RouteLFE:
+		return l == Layout51
+	default:
+		return false
+	}
+}
+
+// ParseChannelLayout parses a channel layout keyword
+func ParseChannelLayout(value string) (ChannelLayout, error) {
+	switch value {
+	case KeywordOptionLayoutStereo:
+		return LayoutStereo, nil
+	case KeywordOptionLayoutQuad:
+		return LayoutQuad, nil
+	case KeywordOptionLayout51:
+		return Layout51, nil
+	default:
+		return LayoutStereo, fmt.Errorf("invalid layout: %q", value)
+	}
+}
+
+// RouteType represents the speakers a track is routed to
+type RouteType int
+
+const (
+	// Front left and right speakers (default)
+	RouteFront RouteType = iota
+	// Rear left and right speakers
+	RouteRear
+	// Center speaker, left and right summed
+	RouteCenter
+	// Low frequency speaker, left and right summed
+	RouteLFE
+	// Front and rear speakers
+	RouteAll
+)
+
+// String returns the string representation of the RouteType
+func (r RouteType) String() string {
+	switch r {
+	case RouteRear:
+		return KeywordRouteRear
+	case RouteCenter:
+		return KeywordRouteCenter
+	case RouteLFE:
+		return KeywordRouteLFE
+	case RouteAll:
+		return KeywordRouteAll
+	default:
+		return KeywordRouteFront
+	}
+}
+
+// ParseRoute parses a route keyword
+func ParseRoute(value string) (RouteType, error) {
+	switch value {
+	case KeywordRouteFront:
+		return RouteFront, nil
+	case KeywordRouteRear:
+		return RouteRear, nil
+	case KeywordRouteCenter:
+		return RouteCenter, nil
+	case KeywordRouteLFE:
+		return RouteLFE, nil
+	case KeywordRouteAll:
+		return RouteAll, nil
+	default:
+		return RouteFront, fmt.Errorf("invalid route: %q", value)
+	}
+}
+
 type AmplitudeType float64 // Amplitude level (0-4096 for 0-100%)
 
 // ToPercent converts a raw amplitude value to a float64 percentage
@@ -65,3 +318,39 @@ func (i IntensityType) ToPercent() float64 {
 func IntensityPercentToRaw(v float64) IntensityType {
 	return IntensityType(v / 100)
 }
+
+type DutyType float64 // Duty cycle (0-1.0 for 0-100% of the pulse period)
+
+// ToPercent converts a raw duty cycle value to a float64 percentage
+func (d DutyType) ToPercent() float64 {
+	return float64(d * 100)
+}
+
+// DutyPercentToRaw converts a float64 value to a raw duty cycle value
+func DutyPercentToRaw(v float64) DutyType {
+	return DutyType(v / 100)
+}
+
+type PanType float64 // Stereo position (-1.0-1.0 for -100-100%, left to right)
+
+// ToPercent converts a raw pan value to a float64 percentage
+func (p PanType) ToPercent() float64 {
+	return float64(p * 100)
+}
+
+// PanPercentToRaw converts a float64 value to a raw pan value
+func PanPercentToRaw(v float64) PanType {
+	return PanType(v / 100)
+}
+
+type BalanceType float64 // Ear balance (-1.0-1.0 for -100-100%, left to right)
+
+// ToPercent converts a raw balance value to a float64 percentage
+func (b BalanceType) ToPercent() float64 {
+	return float64(b * 100)
+}
+
+// BalancePercentToRaw converts a float64 value to a raw balance value
+func BalancePercentToRaw(v float64) BalanceType {
+	return BalanceType(v / 100)
+}
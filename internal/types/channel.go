@@ -25,6 +25,20 @@ type Channel struct {
 	Amplitude [2]int
 	// Increment (for binaural tones, offset + increment into sine table * 65536)
 	Increment [2]int
+	// Index of the wave table of the track waveform, built-in or user-defined
+	WaveTable int
 	// Offset into waveform table (for tones, offset + increment into sine table * 65536)
 	Offset [2]int
+	// Constant-power pan gains for the left and right outputs
+	Pan [2]float64
+	// Peak increment deviation of frequency-modulated tones
+	Deviation int
+	// Background gain factor, from the track gain and the gain level
+	Gain float64
+	// Amplitude of every partial of a tone
+	PartialAmplitude [MaxPartials]int
+	// Increments of every partial of a tone (two for binaural and monaural beats)
+	PartialIncrement [MaxPartials][2]int
+	// Offsets of every partial of a tone into the waveform table
+	PartialOffset [MaxPartials][2]int
 }
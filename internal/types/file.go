@@ -18,6 +18,9 @@ const (
 	MaxBackgroundFileSize = 10 * 1024 * 1024
 	// MaxStructuredFileSize is the maximum allowed size for structured files (128KB)
 	MaxStructuredFileSize = 128 * 1024
+	// MaxAudioFileSize is the maximum allowed size for remote audio files of
+	// any supported format (32MB)
+	MaxAudioFileSize = 32 * 1024 * 1024
 )
 
 // FileFormat represents the format of the input/output file
@@ -29,6 +32,7 @@ const (
 	FormatXML
 	FormatYAML
 	FormatWAV
+	FormatAudio
 	FormatUnknown
 )
 
@@ -45,6 +49,8 @@ func (ff FileFormat) String() string {
 		return "yaml"
 	case FormatWAV:
 		return "wav"
+	case FormatAudio:
+		return "audio"
 	default:
 		return "unknown"
 	}
@@ -13,10 +13,52 @@ package types
 
 // FormatOptions holds the options for the sequence format
 type FormatOptions struct {
-	Samplerate int    `json:"samplerate" xml:"samplerate" yaml:"samplerate"`
-	Volume     int    `json:"volume" xml:"volume" yaml:"volume"`
-	Background string `json:"background,omitempty" xml:"background,omitempty" yaml:"background,omitempty"`
-	GainLevel  string `json:"gainlevel,omitempty" xml:"gainlevel,omitempty" yaml:"gainlevel,omitempty"`
+	Samplerate int     `json:"samplerate" xml:"samplerate" yaml:"samplerate"`
+	Volume     int     `json:"volume" xml:"volume" yaml:"volume"`
+	Background string  `json:"background,omitempty" xml:"background,omitempty" yaml:"background,omitempty"`
+	GainLevel  string  `json:"gainlevel,omitempty" xml:"gainlevel,omitempty" yaml:"gainlevel,omitempty"`
+	Seed       int64   `json:"seed,omitempty" xml:"seed,omitempty" yaml:"seed,omitempty"`
+	Balance    float64 `json:"balance,omitempty" xml:"balance,omitempty" yaml:"balance,omitempty"`
+	Limiter    string  `json:"limiter,omitempty" xml:"limiter,omitempty" yaml:"limiter,omitempty"`
+	Dither     string  `json:"dither,omitempty" xml:"dither,omitempty" yaml:"dither,omitempty"`
+	Layout     string  `json:"layout,omitempty" xml:"layout,omitempty" yaml:"layout,omitempty"`
+	// Loop settings of the background audio
+	BackgroundLoop *FormatBackgroundLoop `json:"backgroundloop,omitempty" xml:"backgroundloop,omitempty" yaml:"backgroundloop,omitempty"`
+	// Named background audio sources
+	Backgrounds []FormatBackgroundSource `json:"backgrounds,omitempty" xml:"backgrounds>background,omitempty" yaml:"backgrounds,omitempty"`
+	// Named one-shot audio cues
+	Cues []FormatCueSource `json:"cues,omitempty" xml:"cues>cue,omitempty" yaml:"cues,omitempty"`
+	// User-defined waveforms
+	Waveforms []FormatWaveformSource `json:"waveforms,omitempty" xml:"waveforms>waveform,omitempty" yaml:"waveforms,omitempty"`
+}
+
+// FormatWaveformSource represents a user-defined waveform in the sequence format,
+// the amplitudes of its harmonics or a single-cycle audio file
+type FormatWaveformSource struct {
+	Name      string    `json:"name" xml:"name,attr" yaml:"name"`
+	Harmonics []float64 `json:"harmonics,omitempty" xml:"harmonics>harmonic,omitempty" yaml:"harmonics,omitempty"`
+	Path      string    `json:"path,omitempty" xml:"path,attr,omitempty" yaml:"path,omitempty"`
+}
+
+// FormatCueSource represents a named one-shot audio cue in the sequence format
+type FormatCueSource struct {
+	Name string `json:"name" xml:"name,attr" yaml:"name"`
+	Path string `json:"path" xml:"path,attr" yaml:"path"`
+}
+
+// FormatBackgroundSource represents a named background audio source in the sequence format
+type FormatBackgroundSource struct {
+	Name string                `json:"name" xml:"name,attr" yaml:"name"`
+	Path string                `json:"path" xml:"path,attr" yaml:"path"`
+	Loop *FormatBackgroundLoop `json:"loop,omitempty" xml:"loop,omitempty" yaml:"loop,omitempty"`
+}
+
+// FormatBackgroundLoop represents the loop settings of a background source in seconds
+type FormatBackgroundLoop struct {
+	Start     float64 `json:"start,omitempty" xml:"start,attr,omitempty" yaml:"start,omitempty"`
+	End       float64 `json:"end,omitempty" xml:"end,attr,omitempty" yaml:"end,omitempty"`
+	Crossfade float64 `json:"crossfade,omitempty" xml:"crossfade,attr,omitempty" yaml:"crossfade,omitempty"`
+	Offset    float64 `json:"offset,omitempty" xml:"offset,attr,omitempty" yaml:"offset,omitempty"`
 }
 
 // FormatTrack represents a single element in the sequence format
@@ -24,21 +66,45 @@ type FormatTrack struct {
 	Tones      []FormatToneTrack  `json:"tones,omitempty" xml:"tone,omitempty" yaml:"tones"`
 	Noises     []FormatNoiseTrack `json:"noises,omitempty" xml:"noise,omitempty" yaml:"noises"`
 	Background *FormatBackground  `json:"background,omitempty" xml:"background,omitempty" yaml:"background,omitempty"`
+	// Tracks of the named background sources
+	Backgrounds []FormatBackground `json:"backgrounds,omitempty" xml:"backgrounds>background,omitempty" yaml:"backgrounds,omitempty"`
+	// One-shot cues played from the time of the element
+	Cues []FormatCue `json:"cues,omitempty" xml:"cues>cue,omitempty" yaml:"cues,omitempty"`
+	// Synthesized bells struck from the time of the element
+	Bells []FormatBell `json:"bells,omitempty" xml:"bells>bell,omitempty" yaml:"bells,omitempty"`
 }
 
 // FormatToneTrack represents a tone element in the sequence format
 type FormatToneTrack struct {
-	Mode      string  `json:"mode,omitempty" xml:"mode,attr,omitempty" yaml:"mode"`
-	Carrier   float64 `json:"carrier,omitempty" xml:"carrier,attr,omitempty" yaml:"carrier"`
-	Resonance float64 `json:"resonance,omitempty" xml:"resonance,attr,omitempty" yaml:"resonance"`
-	Amplitude float64 `json:"amplitude,omitempty" xml:"amplitude,attr,omitempty" yaml:"amplitude"`
-	Waveform  string  `json:"waveform,omitempty" xml:"waveform,attr,omitempty" yaml:"waveform"`
+	Mode      string          `json:"mode,omitempty" xml:"mode,attr,omitempty" yaml:"mode"`
+	Carrier   float64         `json:"carrier,omitempty" xml:"carrier,attr,omitempty" yaml:"carrier"`
+	Resonance float64         `json:"resonance,omitempty" xml:"resonance,attr,omitempty" yaml:"resonance"`
+	Depth     float64         `json:"depth,omitempty" xml:"depth,attr,omitempty" yaml:"depth,omitempty"`
+	Amplitude float64         `json:"amplitude,omitempty" xml:"amplitude,attr,omitempty" yaml:"amplitude"`
+	Waveform  string          `json:"waveform,omitempty" xml:"waveform,attr,omitempty" yaml:"waveform"`
+	Envelope  *FormatEnvelope `json:"envelope,omitempty" xml:"envelope,omitempty" yaml:"envelope,omitempty"`
+	Partials  *FormatPartials `json:"partials,omitempty" xml:"partials,omitempty" yaml:"partials,omitempty"`
+	Pan       float64         `json:"pan,omitempty" xml:"pan,attr,omitempty" yaml:"pan,omitempty"`
+	Route     string          `json:"route,omitempty" xml:"route,attr,omitempty" yaml:"route,omitempty"`
+}
+
+// FormatPartials represents the partials of a tone in the sequence format,
+// one of harmonics, chord or ratios
+type FormatPartials struct {
+	Harmonics int       `json:"harmonics,omitempty" xml:"harmonics,attr,omitempty" yaml:"harmonics,omitempty"`
+	Chord     string    `json:"chord,omitempty" xml:"chord,attr,omitempty" yaml:"chord,omitempty"`
+	Ratios    []float64 `json:"ratios,omitempty" xml:"ratios>ratio,omitempty" yaml:"ratios,omitempty"`
+	Rolloff   float64   `json:"rolloff,omitempty" xml:"rolloff,attr,omitempty" yaml:"rolloff,omitempty"`
 }
 
 // FormatNoiseTrack represents a noise element in the sequence format
 type FormatNoiseTrack struct {
-	Mode      string  `json:"mode,omitempty" xml:"mode,attr,omitempty" yaml:"mode"`
-	Amplitude float64 `json:"amplitude,omitempty" xml:"amplitude,attr,omitempty" yaml:"amplitude"`
+	Mode      string        `json:"mode,omitempty" xml:"mode,attr,omitempty" yaml:"mode"`
+	Amplitude float64       `json:"amplitude,omitempty" xml:"amplitude,attr,omitempty" yaml:"amplitude"`
+	Waveform  string        `json:"waveform,omitempty" xml:"waveform,attr,omitempty" yaml:"waveform,omitempty"`
+	Effect    *FormatEffect `json:"effect,omitempty" xml:"effect,omitempty" yaml:"effect,omitempty"`
+	Pan       float64       `json:"pan,omitempty" xml:"pan,attr,omitempty" yaml:"pan,omitempty"`
+	Route     string        `json:"route,omitempty" xml:"route,attr,omitempty" yaml:"route,omitempty"`
 }
 
 // FormatBackground represents the background audio settings in the sequence format
@@ -46,9 +112,31 @@ type FormatBackground struct {
 	Amplitude float64       `json:"amplitude,omitempty" xml:"amplitude,attr,omitempty" yaml:"amplitude"`
 	Waveform  string        `json:"waveform,omitempty" xml:"waveform,attr,omitempty" yaml:"waveform"`
 	Effect    *FormatEffect `json:"effect,omitempty" xml:"effect,omitempty" yaml:"effect,omitempty"`
+	Pan       float64       `json:"pan,omitempty" xml:"pan,attr,omitempty" yaml:"pan,omitempty"`
+	Source    string        `json:"source,omitempty" xml:"source,attr,omitempty" yaml:"source,omitempty"`
+	Gain      float64       `json:"gain,omitempty" xml:"gain,attr,omitempty" yaml:"gain,omitempty"`
+	Route     string        `json:"route,omitempty" xml:"route,attr,omitempty" yaml:"route,omitempty"`
+}
+
+// FormatCue represents a one-shot cue track in the sequence format
+type FormatCue struct {
+	Source    string  `json:"source" xml:"source,attr" yaml:"source"`
+	Amplitude float64 `json:"amplitude,omitempty" xml:"amplitude,attr,omitempty" yaml:"amplitude"`
+	Pan       float64 `json:"pan,omitempty" xml:"pan,attr,omitempty" yaml:"pan,omitempty"`
+	Route     string  `json:"route,omitempty" xml:"route,attr,omitempty" yaml:"route,omitempty"`
 }
 
-// FormatEffect represents audio effects that can be applied to tones or background audio
+// FormatBell represents a synthesized bell track in the sequence format
+type FormatBell struct {
+	Frequency float64 `json:"frequency" xml:"frequency,attr" yaml:"frequency"`
+	Decay     float64 `json:"decay" xml:"decay,attr" yaml:"decay"`
+	Interval  float64 `json:"interval,omitempty" xml:"interval,attr,omitempty" yaml:"interval,omitempty"`
+	Amplitude float64 `json:"amplitude,omitempty" xml:"amplitude,attr,omitempty" yaml:"amplitude"`
+	Pan       float64 `json:"pan,omitempty" xml:"pan,attr,omitempty" yaml:"pan,omitempty"`
+	Route     string  `json:"route,omitempty" xml:"route,attr,omitempty" yaml:"route,omitempty"`
+}
+
+// FormatEffect represents audio effects that can be applied to noise or background audio
 type FormatEffect struct {
 	Intensity float64            `json:"intensity,omitempty" xml:"intensity,attr,omitempty" yaml:"intensity"`
 	Spin      *FormatEffectSpin  `json:"spin,omitempty" xml:"spin,omitempty" yaml:"spin,omitempty"`
@@ -63,13 +151,23 @@ type FormatEffectSpin struct {
 
 // FormatEffectPulse represents a pulsing effect with resonance parameter
 type FormatEffectPulse struct {
-	Resonance float64 `json:"resonance,omitempty" xml:"resonance,attr,omitempty" yaml:"resonance"`
+	Resonance float64         `json:"resonance,omitempty" xml:"resonance,attr,omitempty" yaml:"resonance"`
+	Envelope  *FormatEnvelope `json:"envelope,omitempty" xml:"envelope,omitempty" yaml:"envelope,omitempty"`
+}
+
+// FormatEnvelope represents the pulse envelope of isochronic tones and pulse effects
+type FormatEnvelope struct {
+	Shape   string  `json:"shape" xml:"shape,attr" yaml:"shape"`
+	Duty    float64 `json:"duty,omitempty" xml:"duty,attr,omitempty" yaml:"duty"`
+	Attack  float64 `json:"attack,omitempty" xml:"attack,attr,omitempty" yaml:"attack"`
+	Release float64 `json:"release,omitempty" xml:"release,attr,omitempty" yaml:"release"`
 }
 
 // FormatSequenceEntry represents a single entry in the sequence format
 type FormatSequenceEntry struct {
 	Time       int         `json:"time" xml:"time,attr" yaml:"time"`
 	Transition string      `json:"transition,omitempty" xml:"transition,attr,omitempty" yaml:"transition,omitempty"`
+	Glide      string      `json:"glide,omitempty" xml:"glide,attr,omitempty" yaml:"glide,omitempty"`
 	Track      FormatTrack `json:"track" xml:"track" yaml:"track"`
 }
 
@@ -28,6 +28,10 @@ const (
 	KeywordOptionBackground = "background"
 	// Represents a presetlist option
 	KeywordOptionPresetList = "presetlist"
+	// Represents a cue option
+	KeywordOptionCue = "cue"
+	// Represents a user-defined waveform option
+	KeywordOptionWaveform = "waveform"
 	// Represents a gain level option
 	KeywordOptionGainLevel = "gainlevel"
 	// Represents a low gain level option
@@ -36,6 +40,28 @@ const (
 	KeywordOptionGainLevelMedium = "medium"
 	// Represents a high gain level option
 	KeywordOptionGainLevelHigh = "high"
+	// Represents a noise seed option
+	KeywordOptionSeed = "seed"
+	// Represents an ear balance option
+	KeywordOptionBalance = "balance"
+	// Represents a master limiter option
+	KeywordOptionLimiter = "limiter"
+	// Represents a soft clipping limiter option
+	KeywordOptionLimiterSoft = "soft"
+	// Represents an output dither option
+	KeywordOptionDither = "dither"
+	// Represents a triangular dither option
+	KeywordOptionDitherTPDF = "tpdf"
+	// Represents a noise-shaped triangular dither option
+	KeywordOptionDitherShaped = "shaped"
+	// Represents a channel layout option
+	KeywordOptionLayout = "layout"
+	// Represents a stereo channel layout
+	KeywordOptionLayoutStereo = "stereo"
+	// Represents a quadraphonic channel layout
+	KeywordOptionLayoutQuad = "quad"
+	// Represents a 5.1 surround channel layout
+	KeywordOptionLayout51 = "5.1"
 	// Represents a waveform option
 	KeywordWaveform = "waveform"
 	// Represents a sine wave
@@ -54,6 +80,12 @@ const (
 	KeywordMonaural = "monaural"
 	// Represents an isochronic tone
 	KeywordIsochronic = "isochronic"
+	// Represents a frequency-modulated tone
+	KeywordFM = "fm"
+	// Represents an amplitude-modulated tone
+	KeywordAM = "am"
+	// Represents a modulation depth
+	KeywordDepth = "depth"
 	// Represents an amplitude
 	KeywordAmplitude = "amplitude"
 	// Represents a noise
@@ -88,6 +120,14 @@ const (
 	KeywordTransitionEaseIn = "ease-in"
 	// Represents a smooth transition
 	KeywordTransitionSmooth = "smooth"
+	// Represents a linear frequency glide
+	KeywordGlideLinear = "linear"
+	// Represents a logarithmic glide of carrier and beat
+	KeywordGlideLog = "log"
+	// Represents a logarithmic glide of the carrier only
+	KeywordGlideLogCarrier = "log-carrier"
+	// Represents a logarithmic glide of the beat only
+	KeywordGlideLogBeat = "log-beat"
 	// Represents a from to copy preset
 	KeywordFrom = "from"
 	// Represents a track parameter
@@ -96,6 +136,72 @@ const (
 	KeywordAs = "as"
 	// Represents a template preset
 	KeywordTemplate = "template"
+	// Represents a pulse envelope
+	KeywordEnvelope = "envelope"
+	// Represents a smoothstep envelope shape
+	KeywordSmoothstep = "smoothstep"
+	// Represents a raised-cosine envelope shape
+	KeywordRaisedCosine = "raised-cosine"
+	// Represents an exponential envelope shape
+	KeywordExponential = "exponential"
+	// Represents a duty cycle parameter
+	KeywordDuty = "duty"
+	// Represents an attack time parameter
+	KeywordAttack = "attack"
+	// Represents a release time parameter
+	KeywordRelease = "release"
+	// Represents a stereo pan parameter
+	KeywordPan = "pan"
+	// Represents a background gain parameter (in dB)
+	KeywordGain = "gain"
+	// Represents a speaker route parameter
+	KeywordRoute = "route"
+	// Represents the front speakers route
+	KeywordRouteFront = "front"
+	// Represents the rear speakers route
+	KeywordRouteRear = "rear"
+	// Represents the center speaker route
+	KeywordRouteCenter = "center"
+	// Represents the low frequency speaker route
+	KeywordRouteLFE = "lfe"
+	// Represents the front and rear speakers route
+	KeywordRouteAll = "all"
+	// Represents background loop points
+	KeywordLoop = "loop"
+	// Represents a background loop crossfade length
+	KeywordCrossfade = "crossfade"
+	// Represents a background start offset
+	KeywordOffset = "offset"
+	// Represents a one-shot cue track
+	KeywordCue = "cue"
+	// Represents a synthesized bell track
+	KeywordBell = "bell"
+	// Represents a bell decay time parameter (in seconds)
+	KeywordDecay = "decay"
+	// Represents a bell strike interval parameter (in seconds)
+	KeywordEvery = "every"
+	// Represents harmonic series partials of a tone
+	KeywordHarmonics = "harmonics"
+	// Represents chord partials of a tone
+	KeywordChord = "chord"
+	// Represents frequency ratio partials of a tone
+	KeywordRatios = "ratios"
+	// Represents a partial rolloff parameter (in dB per octave)
+	KeywordRolloff = "rolloff"
+	// Represents a major chord
+	KeywordChordMajor = "major"
+	// Represents a minor chord
+	KeywordChordMinor = "minor"
+	// Represents a fifth and octave chord
+	KeywordChordFifth = "fifth"
+	// Represents a suspended second chord
+	KeywordChordSus2 = "sus2"
+	// Represents a suspended fourth chord
+	KeywordChordSus4 = "sus4"
+	// Represents a major seventh chord
+	KeywordChordMajor7 = "major7"
+	// Represents a minor seventh chord
+	KeywordChordMinor7 = "minor7"
 )
 
 // Parser defines the interface for parsing different content types
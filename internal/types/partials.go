@@ -0,0 +1,197 @@
+/*
+ * SynapSeq - Synapse-Sequenced Brainwave Generator
+ * https://synapseq.org
+ *
+ * Copyright (c) 2025-2026 SynapSeq Foundation
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 2.
+ * See the file COPYING.txt for details.
+ */
+
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	MaxPartials = 8    // Most partials of a tone
+	MaxRolloff  = 60.0 // Steepest partial rolloff in dB per octave
+)
+
+// PartialsKind represents how the partials of a tone are built from its carrier
+type PartialsKind int
+
+const (
+	// Tone is a single carrier
+	PartialsOff PartialsKind = iota
+	// Partials are the first harmonics of the carrier
+	PartialsHarmonics
+	// Partials are the notes of a chord on the carrier
+	PartialsChord
+	// Partials are a list of frequency ratios to the carrier
+	PartialsRatios
+)
+
+// ChordType represents a just intonation chord built on a carrier
+type ChordType int
+
+const (
+	// Major triad (4:5:6)
+	ChordMajor ChordType = iota
+	// Minor triad (10:12:15)
+	ChordMinor
+	// Fifth and octave (2:3:4)
+	ChordFifth
+	// Suspended second (8:9:12)
+	ChordSus2
+	// Suspended fourth (6:8:9)
+	ChordSus4
+	// Major seventh (8:10:12:15)
+	ChordMajor7
+	// Minor seventh (10:12:15:18)
+	ChordMinor7
+)
+
+// chordRatios are the frequency ratios of every chord to its carrier
+var chordRatios = map[ChordType][]float64{
+	ChordMajor:  {1, 5.0 / 4, 3.0 / 2},
+	ChordMinor:  {1, 6.0 / 5, 3.0 / 2},
+	ChordFifth:  {1, 3.0 / 2, 2},
+	ChordSus2:   {1, 9.0 / 8, 3.0 / 2},
+	ChordSus4:   {1, 4.0 / 3, 3.0 / 2},
+	ChordMajor7: {1, 5.0 / 4, 3.0 / 2, 15.0 / 8},
+	ChordMinor7: {1, 6.0 / 5, 3.0 / 2, 9.0 / 5},
+}
+
+// String returns the string representation of the ChordType
+func (c ChordType) String() string {
+	switch c {
+	case ChordMajor:
+		return KeywordChordMajor
+	case ChordMinor:
+		return KeywordChordMinor
+	case ChordFifth:
+		return KeywordChordFifth
+	case ChordSus2:
+		return KeywordChordSus2
+	case ChordSus4:
+		return KeywordChordSus4
+	case ChordMajor7:
+		return KeywordChordMajor7
+	case ChordMinor7:
+		return KeywordChordMinor7
+	default:
+		return "unknown"
+	}
+}
+
+// ParseChord parses a chord name
+func ParseChord(name string) (ChordType, error) {
+	for c := ChordMajor; c <= ChordMinor7; c++ {
+		if c.String() == name {
+			return c, nil
+		}
+	}
+	return ChordMajor, fmt.Errorf("invalid chord: %q", name)
+}
+
+// Partials represents the partials of a tone built from its carrier
+type Partials struct {
+	// How the partials are built (off for a single carrier)
+	Kind PartialsKind
+	// Chord of the partials (chords only)
+	Chord ChordType
+	// Number of partials
+	Count int
+	// Frequency ratios of the partials to the carrier
+	Ratios [MaxPartials]float64
+	// Level drop in dB per octave above the carrier
+	Rolloff float64
+}
+
+// HarmonicPartials returns the first harmonics of a carrier
+func HarmonicPartials(count int) Partials {
+	p := Partials{Kind: PartialsHarmonics, Count: count}
+	for i := range min(count, MaxPartials) {
+		p.Ratios[i] = float64(i + 1)
+	}
+	return p
+}
+
+// ChordPartials returns the notes of a chord on a carrier
+func ChordPartials(chord ChordType) Partials {
+	p := Partials{Kind: PartialsChord, Chord: chord, Count: len(chordRatios[chord])}
+	copy(p.Ratios[:], chordRatios[chord])
+	return p
+}
+
+// RatioPartials returns partials at frequency ratios to a carrier
+func RatioPartials(ratios []float64) Partials {
+	p := Partials{Kind: PartialsRatios, Count: len(ratios)}
+	copy(p.Ratios[:], ratios)
+	return p
+}
+
+// Gains returns the level of every partial after the rolloff, normalized so
+// the partials together peak at the tone amplitude
+func (p *Partials) Gains() [MaxPartials]float64 {
+	var gains [MaxPartials]float64
+
+	total := 0.0
+	for i := range p.Count {
+		gains[i] = math.Pow(10, -p.Rolloff*math.Log2(p.Ratios[i])/20)
+		total += gains[i]
+	}
+	for i := range p.Count {
+		gains[i] /= total
+	}
+
+	return gains
+}
+
+// Validate checks if the partials are valid
+func (p *Partials) Validate() error {
+	if p.Kind == PartialsOff {
+		return nil
+	}
+	if p.Count < 1 || p.Count > MaxPartials {
+		return fmt.Errorf("number of partials must be between 1 and %d. Received: %d", MaxPartials, p.Count)
+	}
+	for i := range p.Count {
+		if !(p.Ratios[i] > 0) || math.IsInf(p.Ratios[i], 0) {
+			return fmt.Errorf("partial ratios must be greater than 0. Received: %.4g", p.Ratios[i])
+		}
+	}
+	if !(p.Rolloff >= 0 && p.Rolloff <= MaxRolloff) {
+		return fmt.Errorf("rolloff must be between 0 and %.0f dB per octave. Received: %.2f", MaxRolloff, p.Rolloff)
+	}
+	return nil
+}
+
+// String returns the string representation of the Partials
+func (p *Partials) String() string {
+	var line string
+	switch p.Kind {
+	case PartialsHarmonics:
+		line = fmt.Sprintf("%s %d", KeywordHarmonics, p.Count)
+	case PartialsChord:
+		line = fmt.Sprintf("%s %s", KeywordChord, p.Chord.String())
+	case PartialsRatios:
+		ratios := make([]string, p.Count)
+		for i := range p.Count {
+			ratios[i] = strconv.FormatFloat(p.Ratios[i], 'f', -1, 64)
+		}
+		line = fmt.Sprintf("%s %s", KeywordRatios, strings.Join(ratios, " "))
+	default:
+		return ""
+	}
+	if p.Rolloff != 0 {
+		line += fmt.Sprintf(" %s %.2f", KeywordRolloff, p.Rolloff)
+	}
+	return line
+}
@@ -42,12 +42,63 @@ func (s TransitionType) String() string {
 	}
 }
 
+// GlideType defines how frequencies slide during a transition
+type GlideType int
+
+const (
+	// Frequencies slide linearly in Hz
+	GlideLinear GlideType = iota
+	// Carrier and beat slide by equal ratios, the same time per octave
+	GlideLog
+	// Only the carrier slides by equal ratios
+	GlideLogCarrier
+	// Only the beat slides by equal ratios
+	GlideLogBeat
+)
+
+// String returns the string representation of the GlideType
+func (g GlideType) String() string {
+	switch g {
+	case GlideLinear:
+		return KeywordGlideLinear
+	case GlideLog:
+		return KeywordGlideLog
+	case GlideLogCarrier:
+		return KeywordGlideLogCarrier
+	case GlideLogBeat:
+		return KeywordGlideLogBeat
+	default:
+		return "unknown"
+	}
+}
+
+// LogCarrier checks if the carrier slides by equal ratios
+func (g GlideType) LogCarrier() bool {
+	return g == GlideLog || g == GlideLogCarrier
+}
+
+// LogBeat checks if the beat slides by equal ratios
+func (g GlideType) LogBeat() bool {
+	return g == GlideLog || g == GlideLogBeat
+}
+
+// ParseGlide parses a glide name
+func ParseGlide(name string) (GlideType, error) {
+	for g := GlideLinear; g <= GlideLogBeat; g++ {
+		if g.String() == name {
+			return g, nil
+		}
+	}
+	return GlideLinear, fmt.Errorf("invalid glide: %q", name)
+}
+
 // Period represents a time period with track configurations
 type Period struct {
 	Time       int                     // Start time (end time is ->Next->Time)
 	TrackStart [NumberOfChannels]Track // Start tracks for each channel
 	TrackEnd   [NumberOfChannels]Track // End tracks for each channel
 	Transition TransitionType          // Transition type
+	Glide      GlideType               // Frequency glide of the transition
 }
 
 // TimeString returns the time of this period as a formatted string
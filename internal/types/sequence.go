@@ -11,7 +11,11 @@
 
 package types
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"strings"
+)
 
 // Sequence represents a brainwave sequence
 type Sequence struct {
@@ -29,10 +33,220 @@ type SequenceOptions struct {
 	Volume int
 	// Path to the background audio file
 	BackgroundPath string
+	// Loop settings of the background audio file
+	BackgroundLoop BackgroundLoop
+	// Named background audio sources
+	Backgrounds []BackgroundSource
+	// Named one-shot audio cues
+	Cues []CueSource
+	// User-defined waveforms
+	Waveforms []WaveformSource
 	// List of preset configuration files
 	PresetList []string
-	// Gain level (20, 16, 12, 6, 0) for audio processing
+	// Gain level (attenuation in dB) for background audio
 	GainLevel GainLevel
+	// Seed for the noise generators
+	Seed int64
+	// Ear balance (-1.0-1.0 for -100-100%, left to right)
+	Balance BalanceType
+	// Limiter of the master bus
+	Limiter LimiterType
+	// Dither of the reduction to the output bit depth
+	Dither DitherType
+	// Speaker layout of the output
+	Layout ChannelLayout
+}
+
+// BackgroundSource represents a named background audio source
+type BackgroundSource struct {
+	// Source name referenced by background tracks
+	Name string
+	// Path to the background audio file
+	Path string
+	// Loop settings of the background audio file
+	Loop BackgroundLoop
+}
+
+// CueSource represents a named one-shot audio cue
+type CueSource struct {
+	// Cue name referenced by cue tracks
+	Name string
+	// Path to the cue audio file
+	Path string
+}
+
+// WaveformSource represents a user-defined waveform, built from the
+// amplitudes of its harmonics or read from a single-cycle audio file
+type WaveformSource struct {
+	// Waveform name referenced by tracks
+	Name string
+	// Amplitudes of the harmonics, from the fundamental up
+	Harmonics []float64
+	// Path to the single-cycle audio file
+	Path string
+}
+
+// Validate checks if the user-defined waveform is valid
+func (ws *WaveformSource) Validate() error {
+	if err := ValidateWaveformName(ws.Name); err != nil {
+		return err
+	}
+	if (len(ws.Harmonics) > 0) == (strings.TrimSpace(ws.Path) != "") {
+		return fmt.Errorf("waveform %q must have either harmonics or a path", ws.Name)
+	}
+	if len(ws.Harmonics) > MaxHarmonics {
+		return fmt.Errorf("waveform %q has too many harmonics (%d, at most %d)", ws.Name, len(ws.Harmonics), MaxHarmonics)
+	}
+	silent := len(ws.Harmonics) > 0
+	for _, a := range ws.Harmonics {
+		if math.IsNaN(a) || math.IsInf(a, 0) {
+			return fmt.Errorf("waveform %q has an invalid harmonic amplitude: %f", ws.Name, a)
+		}
+		if a != 0 {
+			silent = false
+		}
+	}
+	if silent {
+		return fmt.Errorf("waveform %q has no harmonic with a non-zero amplitude", ws.Name)
+	}
+	return nil
+}
+
+// BackgroundLoop represents the loop settings of a background source (in seconds)
+type BackgroundLoop struct {
+	// Loop start point (0 is the start of the file)
+	Start float64
+	// Loop end point (0 is the end of the file)
+	End float64
+	// Crossfade length at the loop seam (0 is a hard cut)
+	Crossfade float64
+	// Playback start position, may be before the loop start
+	Offset float64
+}
+
+// HasLoopPoints checks if loop points are set, overriding the ones in the file
+func (bl BackgroundLoop) HasLoopPoints() bool {
+	return bl.Start != 0 || bl.End != 0
+}
+
+// Validate checks if the background loop settings are valid
+func (bl BackgroundLoop) Validate() error {
+	if bl.Start < 0 || bl.End < 0 || bl.Crossfade < 0 || bl.Offset < 0 {
+		return fmt.Errorf("background loop settings cannot be negative")
+	}
+	if bl.End == 0 {
+		return nil
+	}
+	if bl.End <= bl.Start {
+		return fmt.Errorf("background loop end (%.2fs) must be after its start (%.2fs)", bl.End, bl.Start)
+	}
+	if bl.Crossfade > (bl.End-bl.Start)/2 {
+		return fmt.Errorf("background crossfade (%.2fs) cannot exceed half the loop length (%.2fs)", bl.Crossfade, bl.End-bl.Start)
+	}
+	if bl.Offset >= bl.End {
+		return fmt.Errorf("background offset (%.2fs) must be before the loop end (%.2fs)", bl.Offset, bl.End)
+	}
+	return nil
+}
+
+// String returns the option clauses of the background loop settings
+func (bl BackgroundLoop) String() string {
+	var clauses string
+	if bl.HasLoopPoints() {
+		clauses += fmt.Sprintf(" %s %.2f %.2f", KeywordLoop, bl.Start, bl.End)
+	}
+	if bl.Crossfade != 0 {
+		clauses += fmt.Sprintf(" %s %.2f", KeywordCrossfade, bl.Crossfade)
+	}
+	if bl.Offset != 0 {
+		clauses += fmt.Sprintf(" %s %.2f", KeywordOffset, bl.Offset)
+	}
+	return clauses
+}
+
+// HasBackground checks if a background source is declared (empty name is the default source)
+func (so *SequenceOptions) HasBackground(name string) bool {
+	if name == "" {
+		return so.BackgroundPath != ""
+	}
+	for _, bg := range so.Backgrounds {
+		if bg.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBackgroundName checks if a background source name is valid
+func ValidateBackgroundName(name string) error {
+	return validateSourceName("background", name)
+}
+
+// ValidateCueName checks if a cue name is valid
+func ValidateCueName(name string) error {
+	return validateSourceName("cue", name)
+}
+
+// HasCue checks if a cue is declared
+func (so *SequenceOptions) HasCue(name string) bool {
+	for _, cue := range so.Cues {
+		if cue.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWaveform checks if a user-defined waveform is declared
+func (so *SequenceOptions) HasWaveform(name string) bool {
+	for _, wf := range so.Waveforms {
+		if wf.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSourceName checks if the name of a background source or cue is valid
+func validateSourceName(kind, name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("%s name cannot be empty", kind)
+	}
+
+	first := name[0]
+	if !(first >= 'a' && first <= 'z') {
+		return fmt.Errorf("%s name must start with a lowercase letter: %q", kind, name)
+	}
+
+	for i := 1; i < len(name); i++ {
+		ch := name[i]
+		if !((ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') || ch == '_' || ch == '-') {
+			return fmt.Errorf("invalid character in %s name %q: %q", kind, name, string(ch))
+		}
+	}
+
+	switch name {
+	case KeywordAmplitude, KeywordSpin, KeywordPulse, KeywordAs, KeywordLoop, KeywordCrossfade, KeywordOffset, KeywordGain, KeywordRoute:
+		return fmt.Errorf("%s name %q is reserved", kind, name)
+	}
+
+	return nil
+}
+
+// BackgroundLabel returns a readable label for a background source name
+func BackgroundLabel(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return name
+}
+
+// ValidateRoute checks if the layout has the speakers of a track route
+func (so *SequenceOptions) ValidateRoute(route RouteType) error {
+	if !so.Layout.Supports(route) {
+		return fmt.Errorf("the %s layout has no speakers for route %q", so.Layout.String(), route.String())
+	}
+	return nil
 }
 
 // Validate checks if the sequence options are valid
@@ -43,5 +257,56 @@ func (so *SequenceOptions) Validate() error {
 	if so.Volume < 0 || so.Volume > 100 {
 		return fmt.Errorf("invalid volume: %d", so.Volume)
 	}
+	if so.Balance < -1.0 || so.Balance > 1.0 {
+		return fmt.Errorf("invalid balance: %.2f", so.Balance.ToPercent())
+	}
+	if db := so.GainLevel.ToDB(); !(db >= MinGain && db <= MaxGain) {
+		return fmt.Errorf("invalid gain level: %.2f dB (must be between %.0f and %.0f)", db, MinGain, MaxGain)
+	}
+	if so.Layout < LayoutStereo || so.Layout > Layout51 {
+		return fmt.Errorf("invalid layout: %d", so.Layout)
+	}
+	if err := so.BackgroundLoop.Validate(); err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	for _, bg := range so.Backgrounds {
+		if err := ValidateBackgroundName(bg.Name); err != nil {
+			return err
+		}
+		if seen[bg.Name] {
+			return fmt.Errorf("duplicate background name: %q", bg.Name)
+		}
+		if strings.TrimSpace(bg.Path) == "" {
+			return fmt.Errorf("background %q has no path", bg.Name)
+		}
+		if err := bg.Loop.Validate(); err != nil {
+			return fmt.Errorf("background %q: %w", bg.Name, err)
+		}
+		seen[bg.Name] = true
+	}
+	seen = make(map[string]bool)
+	for _, cue := range so.Cues {
+		if err := ValidateCueName(cue.Name); err != nil {
+			return err
+		}
+		if seen[cue.Name] {
+			return fmt.Errorf("duplicate cue name: %q", cue.Name)
+		}
+		if strings.TrimSpace(cue.Path) == "" {
+			return fmt.Errorf("cue %q has no path", cue.Name)
+		}
+		seen[cue.Name] = true
+	}
+	seen = make(map[string]bool)
+	for _, wf := range so.Waveforms {
+		if err := wf.Validate(); err != nil {
+			return err
+		}
+		if seen[wf.Name] {
+			return fmt.Errorf("duplicate waveform name: %q", wf.Name)
+		}
+		seen[wf.Name] = true
+	}
 	return nil
 }
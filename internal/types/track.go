@@ -37,6 +37,14 @@ const (
 	TrackBrownNoise
 	// Track is a background noise
 	TrackBackground
+	// Track is a one-shot cue
+	TrackCue
+	// Track is a synthesized bell
+	TrackBell
+	// Track is a frequency-modulated tone
+	TrackFMTone
+	// Track is an amplitude-modulated tone
+	TrackAMTone
 )
 
 // String returns the string representation of the TrackType
@@ -62,12 +70,20 @@ func (tr TrackType) String() string {
 		return KeywordBrown
 	case TrackBackground:
 		return KeywordBackground
+	case TrackCue:
+		return KeywordCue
+	case TrackBell:
+		return KeywordBell
+	case TrackFMTone:
+		return KeywordFM
+	case TrackAMTone:
+		return KeywordAM
 	default:
 		return "unknown"
 	}
 }
 
-// EffectType represents the type of effect applied to a background track
+// EffectType represents the type of effect applied to a background or noise track
 type EffectType int
 
 const (
@@ -93,6 +109,40 @@ func (et EffectType) String() string {
 	}
 }
 
+// EnvelopeShape represents the shape of a pulse envelope
+type EnvelopeShape int
+
+const (
+	// Envelope follows the waveform (default)
+	EnvelopeDefault EnvelopeShape = iota
+	// Envelope with linear edges (square without attack/release)
+	EnvelopeSquare
+	// Envelope with smoothstep edges
+	EnvelopeSmoothstep
+	// Envelope with raised-cosine edges
+	EnvelopeRaisedCosine
+	// Envelope with exponential edges
+	EnvelopeExponential
+)
+
+// String returns the string representation of the EnvelopeShape
+func (es EnvelopeShape) String() string {
+	switch es {
+	case EnvelopeDefault:
+		return "default"
+	case EnvelopeSquare:
+		return KeywordSquare
+	case EnvelopeSmoothstep:
+		return KeywordSmoothstep
+	case EnvelopeRaisedCosine:
+		return KeywordRaisedCosine
+	case EnvelopeExponential:
+		return KeywordExponential
+	default:
+		return "unknown"
+	}
+}
+
 // Track represents a track configuration
 type Track struct {
 	// Track type
@@ -105,8 +155,28 @@ type Track struct {
 	Resonance float64
 	// Waveform shape
 	Waveform WaveformType
+	// Name of the user-defined waveform (custom waveform only)
+	WaveformName string
 	// Effect configuration
 	Effect
+	// Pulse envelope (isochronic tones and pulse effects)
+	Envelope Envelope
+	// Stereo position (-1.0-1.0 for -100-100%, left to right)
+	Pan PanType
+	// Background source name (empty for the default background) or cue name
+	Source string
+	// Background gain in dB (0 is unity), added to the gain level
+	Gain float64
+	// Speakers the track is routed to
+	Route RouteType
+	// Bell decay time in seconds (time for the strike to fall by 60 dB)
+	Decay float64
+	// Bell strike interval in seconds (0 strikes once per period)
+	Interval float64
+	// Partials of a tone built from its carrier
+	Partials Partials
+	// Modulation depth (frequency deviation in Hz for FM tones, 0-100% for AM tones)
+	Depth float64
 }
 
 // Effect represents a effect configuration
@@ -117,6 +187,57 @@ type Effect struct {
 	Intensity IntensityType
 }
 
+// Envelope represents the pulse envelope of isochronic tones and pulse effects
+type Envelope struct {
+	// Envelope shape
+	Shape EnvelopeShape
+	// Duty cycle (0-1.0 for 0-100% of the pulse period)
+	Duty DutyType
+	// Attack time in milliseconds
+	Attack float64
+	// Release time in milliseconds
+	Release float64
+}
+
+// String returns the string representation of the Envelope configuration
+func (e *Envelope) String() string {
+	return fmt.Sprintf("%s %s %s %.2f %s %.2f %s %.2f", KeywordEnvelope, e.Shape.String(), KeywordDuty, e.Duty.ToPercent(), KeywordAttack, e.Attack, KeywordRelease, e.Release)
+}
+
+// WaveformLabel returns the name of the track waveform, built-in or user-defined
+func (tr *Track) WaveformLabel() string {
+	if tr.Waveform == WaveformCustom {
+		return tr.WaveformName
+	}
+	return tr.Waveform.String()
+}
+
+// HasEnvelope checks if the track supports a pulse envelope
+func (tr *Track) HasEnvelope() bool {
+	return tr.Type == TrackIsochronicBeat || (tr.SupportsEffect() && tr.Effect.Type == EffectPulse)
+}
+
+// IsTone checks if the track is a pure, binaural, monaural or isochronic tone
+func (tr *Track) IsTone() bool {
+	return tr.Type == TrackPureTone || tr.Type == TrackBinauralBeat || tr.Type == TrackMonauralBeat || tr.Type == TrackIsochronicBeat
+}
+
+// IsModulated checks if the track is a frequency or amplitude-modulated tone
+func (tr *Track) IsModulated() bool {
+	return tr.Type == TrackFMTone || tr.Type == TrackAMTone
+}
+
+// IsOneShot checks if the track plays sounds triggered by the timeline
+// instead of a continuous signal
+func (tr *Track) IsOneShot() bool {
+	return tr.Type == TrackCue || tr.Type == TrackBell
+}
+
+// SupportsEffect checks if the track can carry a spin or pulse effect
+func (tr *Track) SupportsEffect() bool {
+	return tr.Type == TrackBackground || tr.Type == TrackWhiteNoise || tr.Type == TrackPinkNoise || tr.Type == TrackBrownNoise
+}
+
 // Validate checks if the track configuration is valid
 func (tr *Track) Validate() error {
 	if tr.Amplitude < 0 || tr.Amplitude > 4096 {
@@ -131,35 +252,174 @@ func (tr *Track) Validate() error {
 	if tr.Intensity < 0 || tr.Intensity > 1.0 {
 		return fmt.Errorf("intensity must be between 0 and 100. Received: %.2f", tr.Intensity.ToPercent())
 	}
+	if tr.Type == TrackCue {
+		if err := ValidateCueName(tr.Source); err != nil {
+			return err
+		}
+	} else if tr.Source != "" {
+		if tr.Type != TrackBackground {
+			return fmt.Errorf("background source is only supported on background tracks")
+		}
+		if err := ValidateBackgroundName(tr.Source); err != nil {
+			return err
+		}
+	}
+	if tr.Gain != 0 {
+		if tr.Type != TrackBackground {
+			return fmt.Errorf("gain is only supported on background tracks")
+		}
+		if !(tr.Gain >= MinGain && tr.Gain <= MaxGain) {
+			return fmt.Errorf("gain must be between %.0f and %.0f dB. Received: %.2f", MinGain, MaxGain, tr.Gain)
+		}
+	}
+	if tr.Type == TrackBell {
+		if tr.Carrier <= 0 {
+			return fmt.Errorf("bell frequency must be greater than 0. Received: %.2f", tr.Carrier)
+		}
+		if tr.Decay <= 0 || tr.Decay > MaxBellDecay {
+			return fmt.Errorf("bell decay must be greater than 0 and up to %.0f seconds. Received: %.2f", MaxBellDecay, tr.Decay)
+		}
+		if tr.Interval != 0 && tr.Interval < MinBellInterval {
+			return fmt.Errorf("bell interval must be at least %.2f seconds. Received: %.2f", MinBellInterval, tr.Interval)
+		}
+	} else if tr.Decay != 0 || tr.Interval != 0 {
+		return fmt.Errorf("decay and interval are only supported on bell tracks")
+	}
+	switch tr.Type {
+	case TrackFMTone:
+		if tr.Depth < 0 || tr.Depth > tr.Carrier {
+			return fmt.Errorf("fm depth must be between 0 and the carrier frequency. Received: %.2f", tr.Depth)
+		}
+	case TrackAMTone:
+		if tr.Depth < 0 || tr.Depth > 100 {
+			return fmt.Errorf("am depth must be between 0 and 100. Received: %.2f", tr.Depth)
+		}
+	default:
+		if tr.Depth != 0 {
+			return fmt.Errorf("depth is only supported on fm and am tones")
+		}
+	}
+	if tr.Partials.Kind != PartialsOff {
+		if !tr.IsTone() {
+			return fmt.Errorf("partials are only supported on pure, binaural, monaural and isochronic tones")
+		}
+		if err := tr.Partials.Validate(); err != nil {
+			return err
+		}
+	}
+	if tr.Waveform == WaveformCustom {
+		if err := ValidateWaveformName(tr.WaveformName); err != nil {
+			return err
+		}
+	} else if tr.WaveformName != "" {
+		return fmt.Errorf("waveform name is only supported on custom waveforms")
+	}
+	if tr.Effect.Type != EffectOff && !tr.SupportsEffect() {
+		return fmt.Errorf("%s effect is only supported on background and noise tracks", tr.Effect.Type.String())
+	}
+	if tr.Pan < -1.0 || tr.Pan > 1.0 {
+		return fmt.Errorf("pan must be between -100 and 100. Received: %.2f", tr.Pan.ToPercent())
+	}
+	if tr.Route < RouteFront || tr.Route > RouteAll {
+		return fmt.Errorf("invalid route: %d", tr.Route)
+	}
+	if tr.Envelope.Shape != EnvelopeDefault {
+		if !tr.HasEnvelope() {
+			return fmt.Errorf("envelope is only supported on isochronic tones and pulse effects")
+		}
+		if tr.Envelope.Duty <= 0 || tr.Envelope.Duty > 1.0 {
+			return fmt.Errorf("duty cycle must be greater than 0 and up to 100. Received: %.2f", tr.Envelope.Duty.ToPercent())
+		}
+		if tr.Envelope.Attack < 0 {
+			return fmt.Errorf("attack time must be positive. Received: %.2f", tr.Envelope.Attack)
+		}
+		if tr.Envelope.Release < 0 {
+			return fmt.Errorf("release time must be positive. Received: %.2f", tr.Envelope.Release)
+		}
+	}
 	return nil
 }
 
 // String returns the string representation of the Track configuration
 func (tr *Track) String() string {
+	line := tr.baseString()
+	if tr.Gain != 0 && tr.Type == TrackBackground {
+		line += fmt.Sprintf(" %s %.2f", KeywordGain, tr.Gain)
+	}
+	if tr.Pan != 0 && tr.Type != TrackOff && tr.Type != TrackSilence {
+		line += fmt.Sprintf(" %s %.2f", KeywordPan, tr.Pan.ToPercent())
+	}
+	if tr.Route != RouteFront && tr.Type != TrackOff && tr.Type != TrackSilence {
+		line += fmt.Sprintf(" %s %s", KeywordRoute, tr.Route.String())
+	}
+	return line
+}
+
+// baseString returns the string representation of the Track without the pan
+func (tr *Track) baseString() string {
 	switch tr.Type {
 	case TrackOff, TrackSilence:
 		return "--"
 	case TrackPureTone:
-		return fmt.Sprintf("%s %s %s %.2f %s %.2f", KeywordWaveform, tr.Waveform.String(), KeywordTone, tr.Carrier, KeywordAmplitude, tr.Amplitude.ToPercent())
+		line := fmt.Sprintf("%s %s %s %.2f %s %.2f", KeywordWaveform, tr.WaveformLabel(), KeywordTone, tr.Carrier, KeywordAmplitude, tr.Amplitude.ToPercent())
+		if tr.Partials.Kind != PartialsOff {
+			line += " " + tr.Partials.String()
+		}
+		return line
 	case TrackBinauralBeat, TrackMonauralBeat, TrackIsochronicBeat:
-		return fmt.Sprintf("%s %s %s %.2f %s %.2f %s %.2f", KeywordWaveform, tr.Waveform.String(), KeywordTone, tr.Carrier, tr.Type.String(), tr.Resonance, KeywordAmplitude, tr.Amplitude.ToPercent())
+		line := fmt.Sprintf("%s %s %s %.2f %s %.2f %s %.2f", KeywordWaveform, tr.WaveformLabel(), KeywordTone, tr.Carrier, tr.Type.String(), tr.Resonance, KeywordAmplitude, tr.Amplitude.ToPercent())
+		if tr.Partials.Kind != PartialsOff {
+			line += " " + tr.Partials.String()
+		}
+		if tr.Envelope.Shape != EnvelopeDefault {
+			line += " " + tr.Envelope.String()
+		}
+		return line
+	case TrackFMTone, TrackAMTone:
+		return fmt.Sprintf("%s %s %s %.2f %s %.2f %s %.2f %s %.2f", KeywordWaveform, tr.WaveformLabel(), KeywordTone, tr.Carrier, tr.Type.String(), tr.Resonance, KeywordDepth, tr.Depth, KeywordAmplitude, tr.Amplitude.ToPercent())
 	case TrackWhiteNoise, TrackPinkNoise, TrackBrownNoise:
-		return fmt.Sprintf("%s %s %s %.2f", KeywordNoise, tr.Type.String(), KeywordAmplitude, tr.Amplitude.ToPercent())
+		source := fmt.Sprintf("%s %s", KeywordNoise, tr.Type.String())
+		if tr.Effect.Type != EffectOff {
+			return tr.effectString(source)
+		}
+		return fmt.Sprintf("%s %s %.2f", source, KeywordAmplitude, tr.Amplitude.ToPercent())
 	case TrackBackground:
+		source := KeywordBackground
+		if tr.Source != "" {
+			source += " " + tr.Source
+		}
 		// Special handling for background effects
-		switch tr.Effect.Type {
-		case EffectSpin:
-			return fmt.Sprintf("%s %s %s %s %.2f %s %.2f %s %.2f %s %.2f", KeywordWaveform, tr.Waveform.String(), KeywordBackground, KeywordSpin, tr.Carrier, KeywordRate, tr.Resonance, KeywordIntensity, tr.Intensity.ToPercent(), KeywordAmplitude, tr.Amplitude.ToPercent())
-		case EffectPulse:
-			return fmt.Sprintf("%s %s %s %s %.2f %s %.2f %s %.2f", KeywordWaveform, tr.Waveform.String(), KeywordBackground, KeywordPulse, tr.Resonance, KeywordIntensity, tr.Intensity.ToPercent(), KeywordAmplitude, tr.Amplitude.ToPercent())
-		default:
-			return fmt.Sprintf("%s %s %.2f", KeywordBackground, KeywordAmplitude, tr.Amplitude.ToPercent())
+		if tr.Effect.Type != EffectOff {
+			return tr.effectString(source)
 		}
+		return fmt.Sprintf("%s %s %.2f", source, KeywordAmplitude, tr.Amplitude.ToPercent())
+	case TrackCue:
+		return fmt.Sprintf("%s %s %s %.2f", KeywordCue, tr.Source, KeywordAmplitude, tr.Amplitude.ToPercent())
+	case TrackBell:
+		line := fmt.Sprintf("%s %.2f %s %.2f", KeywordBell, tr.Carrier, KeywordDecay, tr.Decay)
+		if tr.Interval != 0 {
+			line += fmt.Sprintf(" %s %.2f", KeywordEvery, tr.Interval)
+		}
+		return fmt.Sprintf("%s %s %.2f", line, KeywordAmplitude, tr.Amplitude.ToPercent())
 	default:
 		return " ???"
 	}
 }
 
+// effectString returns the string representation of a source carrying a spin or pulse effect
+func (tr *Track) effectString(source string) string {
+	switch tr.Effect.Type {
+	case EffectSpin:
+		return fmt.Sprintf("%s %s %s %s %.2f %s %.2f %s %.2f %s %.2f", KeywordWaveform, tr.WaveformLabel(), source, KeywordSpin, tr.Carrier, KeywordRate, tr.Resonance, KeywordIntensity, tr.Intensity.ToPercent(), KeywordAmplitude, tr.Amplitude.ToPercent())
+	default:
+		line := fmt.Sprintf("%s %s %s %s %.2f %s %.2f %s %.2f", KeywordWaveform, tr.WaveformLabel(), source, KeywordPulse, tr.Resonance, KeywordIntensity, tr.Intensity.ToPercent(), KeywordAmplitude, tr.Amplitude.ToPercent())
+		if tr.Envelope.Shape != EnvelopeDefault {
+			line += " " + tr.Envelope.String()
+		}
+		return line
+	}
+}
+
 // ShortString returns a compact string representation of the track configuration
 func (tr *Track) ShortString() string {
 	switch tr.Type {
@@ -170,8 +430,20 @@ func (tr *Track) ShortString() string {
 	case TrackBinauralBeat, TrackMonauralBeat, TrackIsochronicBeat:
 		return fmt.Sprintf(" (%s:%.2f %s:%.2f %s:%.2f)",
 			KeywordTone, tr.Carrier, tr.Type.String(), tr.Resonance, KeywordAmplitude, tr.Amplitude.ToPercent())
+	case TrackFMTone, TrackAMTone:
+		return fmt.Sprintf(" (%s:%.2f %s:%.2f %s:%.2f %s:%.2f)",
+			KeywordTone, tr.Carrier, tr.Type.String(), tr.Resonance, KeywordDepth, tr.Depth, KeywordAmplitude, tr.Amplitude.ToPercent())
 	case TrackWhiteNoise, TrackPinkNoise, TrackBrownNoise:
-		return fmt.Sprintf(" (%s:%.2f)", KeywordNoise, tr.Amplitude.ToPercent())
+		switch tr.Effect.Type {
+		case EffectSpin:
+			return fmt.Sprintf(" (%s:%s %s:%s %s:%.2f %s:%.2f %s:%.2f %s:%.2f)",
+				KeywordNoise, tr.Type.String(), KeywordEffect, tr.Effect.Type.String(), KeywordWidth, tr.Carrier, KeywordRate, tr.Resonance, KeywordIntensity, tr.Intensity.ToPercent(), KeywordAmplitude, tr.Amplitude.ToPercent())
+		case EffectPulse:
+			return fmt.Sprintf(" (%s:%s %s:%s %s:%.2f %s:%.2f %s:%.2f)",
+				KeywordNoise, tr.Type.String(), KeywordEffect, tr.Effect.Type.String(), KeywordPulse, tr.Resonance, KeywordIntensity, tr.Intensity.ToPercent(), KeywordAmplitude, tr.Amplitude.ToPercent())
+		default:
+			return fmt.Sprintf(" (%s:%.2f)", KeywordNoise, tr.Amplitude.ToPercent())
+		}
 	case TrackBackground:
 		// Special handling for background effects
 		switch tr.Effect.Type {
@@ -184,6 +456,10 @@ func (tr *Track) ShortString() string {
 		default:
 			return fmt.Sprintf(" (%s:%.2f)", KeywordAmplitude, tr.Amplitude.ToPercent())
 		}
+	case TrackCue:
+		return fmt.Sprintf(" (%s:%s %s:%.2f)", KeywordCue, tr.Source, KeywordAmplitude, tr.Amplitude.ToPercent())
+	case TrackBell:
+		return fmt.Sprintf(" (%s:%.2f %s:%.2f %s:%.2f)", KeywordBell, tr.Carrier, KeywordDecay, tr.Decay, KeywordAmplitude, tr.Amplitude.ToPercent())
 	default:
 		return " ???"
 	}
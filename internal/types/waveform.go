@@ -11,6 +11,11 @@
 
 package types
 
+import "fmt"
+
+// MaxHarmonics is the most harmonics of a user-defined waveform
+const MaxHarmonics = 64
+
 // WaveformType represents the waveform shape
 type WaveformType int
 
@@ -20,6 +25,7 @@ const (
 	WaveformSquare                       // Square
 	WaveformTriangle                     // Triangle
 	WaveformSawtooth                     // Sawtooth
+	WaveformCustom                       // User-defined, declared in the sequence
 )
 
 // String returns the string representation of WaveformType
@@ -33,7 +39,36 @@ func (wt WaveformType) String() string {
 		return KeywordTriangle
 	case WaveformSawtooth:
 		return KeywordSawtooth
+	case WaveformCustom:
+		return "custom"
 	default:
 		return ""
 	}
 }
+
+// ParseWaveform parses a built-in waveform or the name of a user-defined waveform
+func ParseWaveform(name string) (WaveformType, string, error) {
+	for wt := WaveformSine; wt < WaveformCustom; wt++ {
+		if wt.String() == name {
+			return wt, "", nil
+		}
+	}
+	if err := ValidateWaveformName(name); err != nil {
+		return WaveformSine, "", err
+	}
+	return WaveformCustom, name, nil
+}
+
+// ValidateWaveformName checks if the name of a user-defined waveform is valid
+func ValidateWaveformName(name string) error {
+	if err := validateSourceName("waveform", name); err != nil {
+		return err
+	}
+
+	switch name {
+	case KeywordSine, KeywordSquare, KeywordTriangle, KeywordSawtooth, KeywordTone, KeywordNoise, KeywordBackground, KeywordHarmonics:
+		return fmt.Errorf("waveform name %q is reserved", name)
+	}
+
+	return nil
+}